@@ -0,0 +1,67 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+
+	defaultLocalURL = "/media"
+)
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+type Config struct {
+	// values: local, s3
+	Driver string `env:"DRIVER" envDefault:"local" validate:"oneof=local s3"`
+	// URL prefix objects are served under. Defaults to /media for the local
+	// driver and to the bucket URL for s3.
+	PublicURL string `env:"PUBLIC_URL"`
+
+	LocalDir string `env:"LOCAL_DIR" envDefault:"./data/media"`
+
+	S3 S3Config `envPrefix:"S3_"`
+}
+
+// Store keeps objects under slash-separated keys.
+type Store interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// New builds the store selected by cfg.Driver.
+func New(cfg Config) (Store, error) {
+	switch cfg.Driver {
+	case DriverLocal:
+		publicURL := cfg.PublicURL
+		if publicURL == "" {
+			publicURL = defaultLocalURL
+		}
+		return NewLocalStore(cfg.LocalDir, publicURL)
+	case DriverS3:
+		return NewS3Store(cfg.S3, cfg.PublicURL)
+	default:
+		return nil, fmt.Errorf("unknown blob driver %q", cfg.Driver)
+	}
+}
+
+// cleanKey rejects empty keys and keys that would escape the store root.
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimSuffix(base, "/") + "/" + key
+}
@@ -0,0 +1,41 @@
+package blob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_PutDelete(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(root, "/media/")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "products/1/a.png", strings.NewReader("data"), 4, "image/png"))
+
+	content, err := os.ReadFile(filepath.Join(root, "products", "1", "a.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(content))
+	assert.Equal(t, "/media/products/1/a.png", store.URL("products/1/a.png"))
+
+	require.NoError(t, store.Delete(ctx, "products/1/a.png"))
+	require.NoError(t, store.Delete(ctx, "products/1/a.png"))
+	_, err = os.Stat(filepath.Join(root, "products", "1", "a.png"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLocalStore_RejectsEscapingKey(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir(), "/media")
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../a.png", "/a.png", "a/../../b.png"} {
+		err := store.Put(context.Background(), key, strings.NewReader("x"), 1, "image/png")
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
@@ -20,6 +20,8 @@ type Config struct {
 	MinConnections  int32         `env:"MIN_CONNECTIONS" envDefault:"5"`
 	MaxConnLifetime time.Duration `env:"MAX_CONN_LIFETIME" env.Default:"5m"`
 	MaxConnIdleTime time.Duration `env:"MAX_CONN_IDLETIME" env.Default:"5m"`
+	// Statements running longer are logged with their trace ID. 0 disables it.
+	SlowQueryThreshold time.Duration `env:"SLOW_QUERY_THRESHOLD" envDefault:"200ms"`
 }
 
 type DB struct {
@@ -50,7 +52,7 @@ func (o DBOptions) SetRetryInterval(retryInterval time.Duration) DBOptions {
 	return o
 }
 
-func NewPgxPoolConfig(cfg Config) (*pgxpool.Config, error) {
+func NewPgxPoolConfig(cfg Config, logger *slog.Logger) (*pgxpool.Config, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.Database, cfg.SSLMode)
 
@@ -63,6 +65,7 @@ func NewPgxPoolConfig(cfg Config) (*pgxpool.Config, error) {
 	config.MinConns = cfg.MinConnections
 	config.MaxConnLifetime = cfg.MaxConnLifetime
 	config.MaxConnIdleTime = cfg.MaxConnIdleTime
+	config.ConnConfig.Tracer = NewQueryTracer(logger, cfg.SlowQueryThreshold)
 
 	return config, nil
 }
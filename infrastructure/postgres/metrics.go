@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+
+// RegisterMetrics reports connection pool statistics on every collection.
+func (db *DB) RegisterMetrics() error {
+	meter := otel.Meter(meterName)
+
+	var errs []error
+	gauge := func(name, description string) metric.Int64ObservableGauge {
+		g, err := meter.Int64ObservableGauge(name, metric.WithDescription(description), metric.WithUnit("{connection}"))
+		errs = append(errs, err)
+		return g
+	}
+	counter := func(name, description string) metric.Int64ObservableCounter {
+		c, err := meter.Int64ObservableCounter(name, metric.WithDescription(description))
+		errs = append(errs, err)
+		return c
+	}
+
+	acquired := gauge("db.pool.acquired_connections", "Connections currently in use")
+	idle := gauge("db.pool.idle_connections", "Idle connections in the pool")
+	constructing := gauge("db.pool.constructing_connections", "Connections being established")
+	total := gauge("db.pool.connections", "All connections in the pool")
+	maxConns := gauge("db.pool.max_connections", "Maximum size of the pool")
+	acquires := counter("db.pool.acquires", "Successful connection acquisitions")
+	emptyAcquires := counter("db.pool.empty_acquires", "Acquisitions that waited because the pool was empty")
+	canceledAcquires := counter("db.pool.canceled_acquires", "Acquisitions cancelled by their context")
+	acquireDuration, err := meter.Float64ObservableCounter("db.pool.acquire_duration",
+		metric.WithDescription("Total time spent waiting for connections"), metric.WithUnit("s"))
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stat := db.pool.Stat()
+		o.ObserveInt64(acquired, int64(stat.AcquiredConns()))
+		o.ObserveInt64(idle, int64(stat.IdleConns()))
+		o.ObserveInt64(constructing, int64(stat.ConstructingConns()))
+		o.ObserveInt64(total, int64(stat.TotalConns()))
+		o.ObserveInt64(maxConns, int64(stat.MaxConns()))
+		o.ObserveInt64(acquires, stat.AcquireCount())
+		o.ObserveInt64(emptyAcquires, stat.EmptyAcquireCount())
+		o.ObserveInt64(canceledAcquires, stat.CanceledAcquireCount())
+		o.ObserveFloat64(acquireDuration, stat.AcquireDuration().Seconds())
+		return nil
+	}, acquired, idle, constructing, total, maxConns, acquires, emptyAcquires, canceledAcquires, acquireDuration)
+
+	return err
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/BlackRRR/Irtea-test/pkg/observability/tracer"
+)
+
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+var (
+	stringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+	whitespace    = regexp.MustCompile(`\s+`)
+)
+
+// QueryTracer starts a child span for every statement and logs the ones
+// slower than the threshold. Spans carry the statement without literals;
+// arguments are never recorded.
+type QueryTracer struct {
+	logger    *slog.Logger
+	threshold time.Duration
+}
+
+// NewQueryTracer builds the tracer. A zero threshold disables slow-query
+// logging.
+func NewQueryTracer(logger *slog.Logger, threshold time.Duration) *QueryTracer {
+	return &QueryTracer{logger: logger, threshold: threshold}
+}
+
+type queryKey struct{}
+
+type query struct {
+	sql   string
+	start time.Time
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	sql := sanitizeSQL(data.SQL)
+	operation := operationOf(sql)
+
+	ctx, span := tracer.Start(ctx, "postgres "+operation)
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", operation),
+		attribute.String("db.statement", sql),
+	)
+
+	return context.WithValue(ctx, queryKey{}, query{sql: sql, start: time.Now()})
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	rows := data.CommandTag.RowsAffected()
+	span.SetAttributes(attribute.Int64("db.rows_affected", rows))
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+
+	q, ok := ctx.Value(queryKey{}).(query)
+	if !ok || t.threshold <= 0 {
+		return
+	}
+
+	if duration := time.Since(q.start); duration >= t.threshold {
+		t.logger.WarnContext(ctx, "Slow query",
+			slog.String("sql", q.sql),
+			slog.Duration("duration", duration),
+			slog.Int64("rows", rows),
+		)
+	}
+}
+
+// sanitizeSQL replaces string literals and collapses whitespace, so spans
+// neither leak inlined values nor vary with indentation.
+func sanitizeSQL(sql string) string {
+	sql = stringLiteral.ReplaceAllString(sql, "?")
+	return strings.TrimSpace(whitespace.ReplaceAllString(sql, " "))
+}
+
+// operationOf returns the leading keyword of the statement, e.g. SELECT.
+func operationOf(sql string) string {
+	operation, _, _ := strings.Cut(sql, " ")
+	return strings.ToUpper(operation)
+}
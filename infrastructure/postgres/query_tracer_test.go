@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSanitizeSQL(t *testing.T) {
+	sql := sanitizeSQL(`
+		SELECT id FROM orders
+		WHERE status = 'it''s pending' AND user_id = $1`)
+
+	assert.Equal(t, "SELECT id FROM orders WHERE status = ? AND user_id = $1", sql)
+	assert.Equal(t, "SELECT", operationOf(sql))
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	var logs bytes.Buffer
+	handler := &spanHandler{Handler: slog.NewTextHandler(&logs, nil)}
+	logger := slog.New(handler)
+
+	// Every statement is slow with a 1ns threshold.
+	qt := NewQueryTracer(logger, time.Nanosecond)
+	ctx := qt.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL: "update products  set quantity = $1 where description = 'x'",
+	})
+	qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+		CommandTag: pgconn.NewCommandTag("UPDATE 3"),
+		Err:        errors.New("deadlock detected"),
+	})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "postgres UPDATE", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.String("db.statement", "update products set quantity = $1 where description = ?"))
+	assert.Contains(t, span.Attributes(), attribute.Int64("db.rows_affected", 3))
+
+	assert.Contains(t, logs.String(), "Slow query")
+	// The logger takes trace_id from the context of the query's span.
+	assert.Equal(t, span.SpanContext().TraceID(), handler.traceID)
+}
+
+// spanHandler records the trace of the context a record is logged with.
+type spanHandler struct {
+	slog.Handler
+	traceID trace.TraceID
+}
+
+func (h *spanHandler) Handle(ctx context.Context, record slog.Record) error {
+	h.traceID = trace.SpanContextFromContext(ctx).TraceID()
+	return h.Handler.Handle(ctx, record)
+}
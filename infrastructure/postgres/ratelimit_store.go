@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/BlackRRR/Irtea-test/pkg/ratelimit"
+)
+
+var _ ratelimit.Store = (*RateLimitStore)(nil)
+
+// RateLimitStore shares rate-limit buckets between replicas. Buckets are
+// timed by the database clock, so replica clock skew does not matter.
+type RateLimitStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewRateLimitStore(pool *pgxpool.Pool) *RateLimitStore {
+	return &RateLimitStore{pool: pool}
+}
+
+func (s *RateLimitStore) Take(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Result, error) {
+	var result ratelimit.Result
+
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// The no-op update locks an existing row, so concurrent requests for
+		// the key take their tokens one after another.
+		query := `
+			INSERT INTO ratelimit.bucket AS b (key, tokens, updated_at, expires_at)
+			VALUES ($1, $2, NULL, statement_timestamp())
+			ON CONFLICT (key) DO UPDATE SET key = b.key
+			RETURNING b.tokens, b.updated_at, statement_timestamp()
+		`
+
+		var bucket ratelimit.Bucket
+		var updatedAt *time.Time
+		var now time.Time
+		if err := tx.QueryRow(ctx, query, key, float64(policy.Limit)).Scan(&bucket.Tokens, &updatedAt, &now); err != nil {
+			return fmt.Errorf("failed to lock rate limit bucket: %w", err)
+		}
+		if updatedAt != nil {
+			bucket.Updated = *updatedAt
+		}
+
+		bucket, result = policy.Take(bucket, now)
+
+		query = `
+			UPDATE ratelimit.bucket
+			SET tokens = $2, updated_at = $3, expires_at = $4
+			WHERE key = $1
+		`
+
+		if _, err := tx.Exec(ctx, query, key, bucket.Tokens, bucket.Updated, now.Add(result.Reset)); err != nil {
+			return fmt.Errorf("failed to update rate limit bucket: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Sweep deletes the buckets that have refilled and returns how many.
+func (s *RateLimitStore) Sweep(ctx context.Context) (int, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM ratelimit.bucket WHERE expires_at <= statement_timestamp()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sweep rate limit buckets: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// SchemaVersion is the newest migration in migrations/ the code depends on.
+// Bump it with every new migration.
+const SchemaVersion int64 = 20250927090000
+
+// CheckSchema fails when goose has not applied SchemaVersion yet. A newer
+// schema is fine, so rolling deploys keep old replicas ready.
+func (db *DB) CheckSchema(ctx context.Context) error {
+	var version int64
+	err := db.pool.QueryRow(ctx, `
+		SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied`,
+	).Scan(&version)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if version < SchemaVersion {
+		return fmt.Errorf("schema version is %d, want at least %d", version, SchemaVersion)
+	}
+
+	return nil
+}
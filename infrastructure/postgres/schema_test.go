@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVersion_MatchesMigrations(t *testing.T) {
+	entries, err := os.ReadDir("../../migrations")
+	require.NoError(t, err)
+
+	var newest int64
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		require.NoError(t, err, entry.Name())
+		newest = max(newest, version)
+	}
+
+	assert.Equal(t, newest, SchemaVersion, "bump SchemaVersion to the newest migration")
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/codes"
+	"github.com/BlackRRR/Irtea-test/pkg/observability/tracer"
 )
 
 type TxManager struct {
@@ -17,8 +19,31 @@ func NewTxManager(pool *pgxpool.Pool) *TxManager {
 	return &TxManager{pool: pool}
 }
 
-func (tm *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
-	tx, err := tm.pool.BeginTx(ctx, pgx.TxOptions{})
+// WithTx runs fn in a transaction. Called within an existing transaction it
+// opens a savepoint instead, so a failing fn only rolls back its own work.
+// The transaction is traced as the parent span of its statements.
+func (tm *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	parent, nested := GetTx(ctx)
+
+	spanName := "postgres transaction"
+	if nested {
+		spanName = "postgres savepoint"
+	}
+	ctx, span := tracer.Start(ctx, spanName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var tx pgx.Tx
+	if nested {
+		tx, err = parent.Begin(ctx)
+	} else {
+		tx, err = tm.pool.BeginTx(ctx, pgx.TxOptions{})
+	}
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -30,7 +55,7 @@ func (tm *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context) er
 		}
 	}()
 
-	txCtx := context.WithValue(ctx, txKey{}, tx)
+	txCtx := ContextWithTx(ctx, tx)
 
 	if err = fn(txCtx); err != nil {
 		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
@@ -48,6 +73,12 @@ func (tm *TxManager) WithTx(ctx context.Context, fn func(ctx context.Context) er
 
 type txKey struct{}
 
+// ContextWithTx returns a copy of ctx in which repositories run their
+// statements through tx.
+func ContextWithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
 func GetTx(ctx context.Context) (pgx.Tx, bool) {
 	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
 	return tx, ok
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// MissingOrConflict tells apart the two reasons a versioned update of the row
+// id in table can match no rows: notFound when the row is gone, conflict when
+// its version has moved on.
+func MissingOrConflict(ctx context.Context, q Querier, table, id string, notFound, conflict error) error {
+	query := `SELECT EXISTS (SELECT 1 FROM ` + table + ` WHERE id = $1)`
+
+	var exists bool
+	if err := q.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check existence in %s: %w", table, err)
+	}
+
+	if !exists {
+		return notFound
+	}
+
+	return conflict
+}
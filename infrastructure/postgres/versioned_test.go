@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errMissing  = errors.New("missing")
+	errConflict = errors.New("conflict")
+)
+
+// existsQuerier answers the existence check with exists.
+type existsQuerier struct {
+	Querier
+	exists bool
+	sql    string
+}
+
+func (q *existsQuerier) QueryRow(_ context.Context, sql string, _ ...any) pgx.Row {
+	q.sql = sql
+	return existsRow{q.exists}
+}
+
+type existsRow struct{ exists bool }
+
+func (r existsRow) Scan(dest ...any) error {
+	*dest[0].(*bool) = r.exists
+	return nil
+}
+
+func TestMissingOrConflict(t *testing.T) {
+	q := &existsQuerier{}
+	err := MissingOrConflict(context.Background(), q, "products.product", "42", errMissing, errConflict)
+	assert.ErrorIs(t, err, errMissing)
+	assert.Contains(t, q.sql, "FROM products.product WHERE id = $1")
+
+	q.exists = true
+	err = MissingOrConflict(context.Background(), q, "products.product", "42", errMissing, errConflict)
+	assert.ErrorIs(t, err, errConflict)
+}
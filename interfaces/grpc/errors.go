@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	orderDomain "github.com/BlackRRR/Irtea-test/internal/order/domain"
+	productApp "github.com/BlackRRR/Irtea-test/internal/product/app"
+	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes maps domain errors to status codes. Errors that are not listed
+// become INTERNAL without their message.
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{userDomain.ErrUserNotFound, codes.NotFound},
+	{productDomain.ErrProductNotFound, codes.NotFound},
+	{productDomain.ErrVariantNotFound, codes.NotFound},
+	{productDomain.ErrImageNotFound, codes.NotFound},
+	{orderDomain.ErrOrderNotFound, codes.NotFound},
+	{orderDomain.ErrCustomerNotFound, codes.NotFound},
+
+	{userDomain.ErrUserAlreadyExists, codes.AlreadyExists},
+	{productDomain.ErrDuplicateSKU, codes.AlreadyExists},
+	{productDomain.ErrDuplicateVariantOptions, codes.AlreadyExists},
+
+	// Lost optimistic-locking races; the client should re-read and retry.
+	{userDomain.ErrUserVersionConflict, codes.Aborted},
+	{productDomain.ErrProductVersionConflict, codes.Aborted},
+	{orderDomain.ErrOrderVersionConflict, codes.Aborted},
+
+	{userDomain.ErrInvalidCredentials, codes.Unauthenticated},
+
+	{userDomain.ErrUserAlreadyBlocked, codes.FailedPrecondition},
+	{userDomain.ErrUserNotBlocked, codes.FailedPrecondition},
+	{orderDomain.ErrInvalidOrderStatus, codes.FailedPrecondition},
+	{orderDomain.ErrOrderCannotBeModified, codes.FailedPrecondition},
+	{orderDomain.ErrCustomerBlocked, codes.FailedPrecondition},
+	{orderDomain.ErrOrderLimitExceeded, codes.FailedPrecondition},
+	{orderDomain.ErrUserLimitExceeded, codes.FailedPrecondition},
+	{productDomain.ErrInsufficientStock, codes.FailedPrecondition},
+	{productDomain.ErrProductArchived, codes.FailedPrecondition},
+	{productDomain.ErrProductAlreadyArchived, codes.FailedPrecondition},
+	{productDomain.ErrProductNotArchived, codes.FailedPrecondition},
+	{productDomain.ErrProductHasOrders, codes.FailedPrecondition},
+	{productDomain.ErrOptionsLockedByVariants, codes.FailedPrecondition},
+	{productDomain.ErrReservationNotActive, codes.FailedPrecondition},
+	{productDomain.ErrReservationExpired, codes.FailedPrecondition},
+	{productDomain.ErrBackordersNotAllowed, codes.FailedPrecondition},
+	{productDomain.ErrBackorderLimitExceeded, codes.FailedPrecondition},
+
+	{userDomain.ErrUserTooYoung, codes.InvalidArgument},
+	{userDomain.ErrInvalidPassword, codes.InvalidArgument},
+	{orderDomain.ErrEmptyOrder, codes.InvalidArgument},
+	{productDomain.ErrVariantRequired, codes.InvalidArgument},
+	{productDomain.ErrQuantityToAddMustBePositive, codes.InvalidArgument},
+	{productDomain.ErrInventoryQuantityCannotBeNeg, codes.InvalidArgument},
+	{productDomain.ErrMoneyCannotBeNeg, codes.InvalidArgument},
+	{productDomain.ErrProductDescCannotBeEmpty, codes.InvalidArgument},
+	{productDomain.ErrQuantityToAddMustBe, codes.InvalidArgument},
+	{productDomain.ErrInvalidPrice, codes.InvalidArgument},
+	{productDomain.ErrInvalidQuantity, codes.InvalidArgument},
+	{productDomain.ErrVariantSKUCannotBeEmpty, codes.InvalidArgument},
+	{productDomain.ErrInvalidVariantOptions, codes.InvalidArgument},
+	{productDomain.ErrOptionNameCannotBeEmpty, codes.InvalidArgument},
+	{productDomain.ErrOptionValuesCannotBeEmpty, codes.InvalidArgument},
+	{productDomain.ErrDuplicateOptionName, codes.InvalidArgument},
+	{productDomain.ErrInvalidReorderThreshold, codes.InvalidArgument},
+	{productDomain.ErrInvalidBackorderLimit, codes.InvalidArgument},
+	{productDomain.ErrInvalidPurchaseLimits, codes.InvalidArgument},
+}
+
+// statusError converts an error returned by a handler into a status error.
+// The second result reports whether err was mapped to a known code.
+func statusError(err error) (error, bool) {
+	if err == nil {
+		return nil, true
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err, true
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error()), true
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error()), true
+	}
+
+	var validationErr *productApp.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Error()), true
+	}
+
+	for _, mapping := range errorCodes {
+		if errors.Is(err, mapping.err) {
+			return status.Error(mapping.code, err.Error()), true
+		}
+	}
+
+	return status.Error(codes.Internal, "internal server error"), false
+}
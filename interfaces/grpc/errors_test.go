@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	orderDomain "github.com/BlackRRR/Irtea-test/internal/order/domain"
+	productApp "github.com/BlackRRR/Irtea-test/internal/product/app"
+	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	validationErr := &productApp.ValidationError{}
+	validationErr.Add("price", productDomain.ErrMoneyCannotBeNeg)
+
+	tests := []struct {
+		name  string
+		err   error
+		code  codes.Code
+		known bool
+	}{
+		{"not found", userDomain.ErrUserNotFound, codes.NotFound, true},
+		{"wrapped", fmt.Errorf("get order: %w", orderDomain.ErrOrderNotFound), codes.NotFound, true},
+		{"already exists", userDomain.ErrUserAlreadyExists, codes.AlreadyExists, true},
+		{"version conflict", productDomain.ErrProductVersionConflict, codes.Aborted, true},
+		{"invalid transition", orderDomain.ErrInvalidOrderStatus, codes.FailedPrecondition, true},
+		{"invalid argument", userDomain.ErrUserTooYoung, codes.InvalidArgument, true},
+		{"validation", validationErr, codes.InvalidArgument, true},
+		{"canceled", context.Canceled, codes.Canceled, true},
+		{"status passes through", status.Error(codes.InvalidArgument, "bad id"), codes.InvalidArgument, true},
+		{"unknown", errors.New("connection refused"), codes.Internal, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err, known := statusError(tt.err)
+
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.known, known)
+		})
+	}
+}
+
+func TestStatusError_HidesUnknownErrors(t *testing.T) {
+	err, _ := statusError(errors.New(`pq: relation "orders" does not exist`))
+
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+}
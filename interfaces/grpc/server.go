@@ -0,0 +1,225 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"time"
+
+	orderServer "github.com/BlackRRR/Irtea-test/internal/order/interfaces/grpc"
+	productServer "github.com/BlackRRR/Irtea-test/internal/product/interfaces/grpc"
+	userServer "github.com/BlackRRR/Irtea-test/internal/user/interfaces/grpc"
+	orderv1 "github.com/BlackRRR/Irtea-test/pkg/api/irtea/order/v1"
+	productv1 "github.com/BlackRRR/Irtea-test/pkg/api/irtea/product/v1"
+	userv1 "github.com/BlackRRR/Irtea-test/pkg/api/irtea/user/v1"
+	"github.com/BlackRRR/Irtea-test/pkg/observability/tracer"
+	"github.com/BlackRRR/Irtea-test/pkg/requestctx"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const headerRequestID = "x-request-id"
+
+type Config struct {
+	Port string `env:"PORT" envDefault:"9090"`
+	// How long in-flight calls may take to finish on shutdown before they
+	// are cut off.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
+}
+
+type Server struct {
+	server *grpc.Server
+	config Config
+	logger *slog.Logger
+}
+
+func NewServer(
+	config Config,
+	logger *slog.Logger,
+	usersServer *userServer.UsersServer,
+	productsServer *productServer.ProductsServer,
+	ordersServer *orderServer.OrdersServer,
+) *Server {
+	s := &Server{
+		config: config,
+		logger: logger,
+	}
+
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			requestIDUnaryInterceptor,
+			tracer.UnaryServerInterceptor(),
+			s.loggingUnaryInterceptor,
+			s.errorUnaryInterceptor,
+			s.recoveryUnaryInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			requestIDStreamInterceptor,
+			tracer.StreamServerInterceptor(),
+			s.loggingStreamInterceptor,
+			s.errorStreamInterceptor,
+			s.recoveryStreamInterceptor,
+		),
+	)
+
+	userv1.RegisterUserServiceServer(s.server, usersServer)
+	productv1.RegisterProductServiceServer(s.server, productsServer)
+	orderv1.RegisterOrderServiceServer(s.server, ordersServer)
+	reflection.Register(s.server)
+
+	return s
+}
+
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", ":"+s.config.Port)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Starting gRPC server", slog.String("port", s.config.Port))
+
+	return s.server.Serve(listener)
+}
+
+// Shutdown stops accepting calls and waits up to ShutdownTimeout for the
+// running ones. ctx may already be done when the app is shutting down, so
+// only its values are kept.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.InfoContext(ctx, "Shutting down gRPC server")
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.config.ShutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// requestIDUnaryInterceptor takes the request ID from the x-request-id
+// metadata or generates one, and sends it back in the response header.
+func requestIDUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+func requestIDStreamInterceptor(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: stream, ctx: withRequestID(stream.Context())})
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	var requestID string
+	if values := metadata.ValueFromIncomingContext(ctx, headerRequestID); len(values) > 0 {
+		requestID = values[0]
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(headerRequestID, requestID))
+
+	return requestctx.WithRequestID(ctx, requestID)
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *Server) loggingUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	s.logCall(ctx, info.FullMethod, err, time.Since(start))
+	return resp, err
+}
+
+func (s *Server) loggingStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, stream)
+
+	s.logCall(stream.Context(), info.FullMethod, err, time.Since(start))
+	return err
+}
+
+func (s *Server) logCall(ctx context.Context, method string, err error, duration time.Duration) {
+	s.logger.InfoContext(ctx, "gRPC request",
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", duration),
+	)
+}
+
+func (s *Server) errorUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, s.mapError(ctx, info.FullMethod, err)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) errorStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, stream); err != nil {
+		return s.mapError(stream.Context(), info.FullMethod, err)
+	}
+
+	return nil
+}
+
+func (s *Server) mapError(ctx context.Context, method string, err error) error {
+	statusErr, known := statusError(err)
+	if !known {
+		s.logger.ErrorContext(ctx, "gRPC error",
+			slog.Any("error", err),
+			slog.String("method", method),
+		)
+	}
+
+	return statusErr
+}
+
+func (s *Server) recoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer s.recoverPanic(ctx, info.FullMethod, &err)
+
+	return handler(ctx, req)
+}
+
+func (s *Server) recoveryStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer s.recoverPanic(stream.Context(), info.FullMethod, &err)
+
+	return handler(srv, stream)
+}
+
+// recoverPanic turns a panic in a handler into INTERNAL; an unrecovered one
+// would take down the whole process.
+func (s *Server) recoverPanic(ctx context.Context, method string, err *error) {
+	if r := recover(); r != nil {
+		s.logger.ErrorContext(ctx, "Panic recovered",
+			slog.String("method", method),
+			slog.Any("panic", r),
+			slog.String("stack", string(debug.Stack())),
+		)
+
+		*err = status.Error(codes.Internal, "internal server error")
+	}
+}
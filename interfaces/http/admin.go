@@ -0,0 +1,112 @@
+package http
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/BlackRRR/Irtea-test/pkg/errcode"
+	obs "github.com/BlackRRR/Irtea-test/pkg/observability/logger"
+	"github.com/BlackRRR/Irtea-test/pkg/validator"
+)
+
+var (
+	ErrAdminDisabled     = errcode.New("admin-disabled", "admin API is disabled")
+	ErrAdminUnauthorized = errcode.New("admin-unauthorized", "invalid admin token")
+	ErrLogLevelRequired  = errcode.New("log-level-required", "level is required for the root logger")
+)
+
+// LogLevelRequest changes the root level, or the level of a named logger and
+// its children. Without a level the logger's override is removed.
+type LogLevelRequest struct {
+	Level  string `json:"level" validate:"omitempty,oneof=debug info warn error"`
+	Logger string `json:"logger"`
+	// Restores the previous level after this long, e.g. "15m".
+	RevertAfter string `json:"revert_after" validate:"omitempty,duration"`
+}
+
+type LoggerLevelResponse struct {
+	Level    string `json:"level"`
+	RevertAt string `json:"revert_at,omitempty"`
+}
+
+type LogLevelResponse struct {
+	Level    string `json:"level"`
+	RevertAt string `json:"revert_at,omitempty"`
+	// Overrides by logger name
+	Loggers map[string]LoggerLevelResponse `json:"loggers"`
+}
+
+// adminOnly lets through requests bearing the admin token. Without a
+// configured token the admin API is off.
+func (s *Server) adminOnly(c *fiber.Ctx) error {
+	if s.config.AdminToken == "" {
+		return ErrAdminDisabled
+	}
+
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.AdminToken)) != 1 {
+		return ErrAdminUnauthorized
+	}
+
+	return c.Next()
+}
+
+func (s *Server) getLogLevel(c *fiber.Ctx) error {
+	root, loggers := s.logLevels.Levels()
+
+	resp := LogLevelResponse{
+		Level:    string(root.Level),
+		RevertAt: formatRevertAt(root.RevertAt),
+		Loggers:  make(map[string]LoggerLevelResponse, len(loggers)),
+	}
+	for name, setting := range loggers {
+		resp.Loggers[name] = LoggerLevelResponse{
+			Level:    string(setting.Level),
+			RevertAt: formatRevertAt(setting.RevertAt),
+		}
+	}
+
+	return c.JSON(resp)
+}
+
+func (s *Server) setLogLevel(c *fiber.Ctx) error {
+	var req LogLevelRequest
+	if err := validator.ReadRequest(c, &req); err != nil {
+		return err
+	}
+
+	var revertAfter time.Duration
+	if req.RevertAfter != "" {
+		// Checked by the duration rule.
+		revertAfter, _ = time.ParseDuration(req.RevertAfter)
+	}
+
+	switch {
+	case req.Level != "":
+		if err := s.logLevels.Set(req.Logger, obs.LogLevel(req.Level), revertAfter); err != nil {
+			return err
+		}
+	case req.Logger != "":
+		s.logLevels.Reset(req.Logger, revertAfter)
+	default:
+		return ErrLogLevelRequired
+	}
+
+	s.logger.WarnContext(c.UserContext(), "Log level changed",
+		slog.String("logger", req.Logger),
+		slog.String("level", req.Level),
+		slog.String("revert_after", req.RevertAfter),
+	)
+
+	return s.getLogLevel(c)
+}
+
+func formatRevertAt(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -4,29 +4,122 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"errors"
 	"log/slog"
+	"net/http"
+	"strconv"
+
+	productApp "github.com/BlackRRR/Irtea-test/internal/product/app"
+	"github.com/BlackRRR/Irtea-test/pkg/errcode"
+	"github.com/BlackRRR/Irtea-test/pkg/etag"
+	"github.com/BlackRRR/Irtea-test/pkg/i18n"
+	"github.com/BlackRRR/Irtea-test/pkg/problem"
+	"github.com/BlackRRR/Irtea-test/pkg/validator"
 )
 
 type ErrorHandler struct {
 	logger *slog.Logger
 }
 
+// Init renders every error returned by a handler as problem details in the
+// language negotiated from Accept-Language. Error messages are logged, not
+// sent: internal errors at error level, client errors at debug.
 func (e *ErrorHandler) Init() func(ctx *fiber.Ctx, err error) error {
 	return func(ctx *fiber.Ctx, err error) error {
-		code := fiber.StatusInternalServerError
-		var errw *fiber.Error
-		if errors.As(err, &errw) {
-			code = errw.Code
-		}
+		lang := i18n.Language(ctx)
+		p := problemFor(ctx, err, lang)
+		p.Title = title(p, lang)
 
-		e.logger.Error("HTTP error",
+		level, message := slog.LevelDebug, "HTTP client error"
+		if p.Status >= fiber.StatusInternalServerError {
+			level, message = slog.LevelError, "HTTP error"
+		}
+		e.logger.Log(ctx.UserContext(), level, message,
 			slog.Any("error", err),
-			slog.Int("status_code", code),
+			slog.Int("status_code", p.Status),
 			slog.String("path", ctx.Path()),
 			slog.String("method", ctx.Method()),
 		)
 
-		return ctx.Status(code).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		ctx.Set(fiber.HeaderContentLanguage, lang)
+		return problem.Write(ctx, p)
+	}
+}
+
+func problemFor(c *fiber.Ctx, err error, lang string) *problem.Problem {
+	var p *problem.Problem
+	if errors.As(err, &p) {
+		return p
+	}
+
+	var requestErr *validator.RequestError
+	if errors.As(err, &requestErr) {
+		if requestErr.Malformed {
+			p = typed("malformed-body", fiber.StatusBadRequest)
+			p.Detail = requestErr.Detail
+		} else {
+			p = typed("validation-failed", fiber.StatusUnprocessableEntity)
+		}
+		for _, field := range requestErr.Fields {
+			p.Errors = append(p.Errors, problem.FieldError{
+				Pointer: field.Pointer,
+				Rule:    field.Rule,
+				Param:   field.Param,
+				Detail:  field.Message(lang),
+			})
+		}
+		return p
+	}
+
+	// Checked before the registry: a ValidationError also matches the
+	// domain errors of its fields.
+	var validationErr *productApp.ValidationError
+	if errors.As(err, &validationErr) {
+		p = typed("validation-failed", fiber.StatusUnprocessableEntity)
+		for _, field := range validationErr.Fields {
+			detail := field.Err.Error()
+			if code, ok := errcode.Of(field.Err); ok {
+				if message, ok := messages.Message(lang, code); ok {
+					detail = message
+				}
+			}
+			p.Errors = append(p.Errors, problem.FieldError{Pointer: "/" + field.Field, Detail: detail})
+		}
+		return p
+	}
+
+	if p, ok := problems.Lookup(err); ok {
+		for _, conflict := range versionConflicts {
+			if errors.Is(err, conflict) {
+				p.Status = etag.ConflictStatus(c)
+			}
+		}
+		return p
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return problem.New(fiberErr.Code, fiberErr.Message)
+	}
+
+	return problem.New(fiber.StatusInternalServerError, "")
+}
+
+func typed(code string, status int) *problem.Problem {
+	return &problem.Problem{Type: ProblemTypeBase + code, Status: status, Code: code}
+}
+
+// title translates the title of a problem from its code, or from its status
+// when it has none.
+func title(p *problem.Problem, lang string) string {
+	if p.Code != "" {
+		if message, ok := messages.Message(lang, p.Code); ok {
+			return message
+		}
+	} else if message, ok := statusTitles.Message(lang, strconv.Itoa(p.Status)); ok {
+		return message
+	}
+
+	if p.Title != "" {
+		return p.Title
 	}
+	return http.StatusText(p.Status)
 }
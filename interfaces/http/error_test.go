@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	orderDomain "github.com/BlackRRR/Irtea-test/internal/order/domain"
+	productApp "github.com/BlackRRR/Irtea-test/internal/product/app"
+	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/i18n"
+	"github.com/BlackRRR/Irtea-test/pkg/problem"
+)
+
+func serveError(t *testing.T, err error, headers map[string]string) (int, string, problem.Problem) {
+	t.Helper()
+
+	errHandler := ErrorHandler{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	app := fiber.New(fiber.Config{ErrorHandler: errHandler.Init()})
+	app.Use(requestid.New(requestid.Config{Generator: func() string { return "req-1" }}))
+	app.Get("/fail", func(c *fiber.Ctx) error { return err })
+
+	req := httptest.NewRequest(fiber.MethodGet, "/fail?x=1", nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, testErr := app.Test(req)
+	require.NoError(t, testErr)
+
+	var body problem.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	return resp.StatusCode, resp.Header.Get(fiber.HeaderContentType), body
+}
+
+func TestErrorHandler_DomainError(t *testing.T) {
+	status, contentType, body := serveError(t, fmt.Errorf("reserve: %w", productDomain.ErrInsufficientStock), nil)
+
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, problem.ContentType, contentType)
+	assert.Equal(t, problem.Problem{
+		Type:      ProblemTypeBase + "insufficient-stock",
+		Title:     "Insufficient stock",
+		Status:    fiber.StatusConflict,
+		Code:      "insufficient-stock",
+		Instance:  "/fail?x=1",
+		RequestID: "req-1",
+	}, body)
+}
+
+// The wrapped message is logged for the server, not sent to the client.
+func TestErrorHandler_ClientErrorLogged(t *testing.T) {
+	var logs bytes.Buffer
+	errHandler := ErrorHandler{logger: slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))}
+	app := fiber.New(fiber.Config{ErrorHandler: errHandler.Init()})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fmt.Errorf("%w: product 42", orderDomain.ErrOrderLimitExceeded)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/fail", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "ru")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	var body problem.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Превышен лимит товара на один заказ", body.Title)
+	assert.Empty(t, body.Detail)
+
+	assert.Contains(t, logs.String(), "level=DEBUG")
+	assert.Contains(t, logs.String(), "product 42")
+}
+
+func TestErrorHandler_VersionConflict(t *testing.T) {
+	status, _, body := serveError(t, orderDomain.ErrOrderVersionConflict, nil)
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, fiber.StatusConflict, body.Status)
+
+	status, _, body = serveError(t, orderDomain.ErrOrderVersionConflict, map[string]string{fiber.HeaderIfMatch: `"3"`})
+	assert.Equal(t, fiber.StatusPreconditionFailed, status)
+	assert.Equal(t, fiber.StatusPreconditionFailed, body.Status)
+	assert.Equal(t, ProblemTypeBase+"version-conflict", body.Type)
+}
+
+func TestErrorHandler_ValidationError(t *testing.T) {
+	validationErr := &productApp.ValidationError{}
+	validationErr.Add("price", productDomain.ErrMoneyCannotBeNeg)
+
+	status, _, body := serveError(t, validationErr, nil)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	assert.Equal(t, ProblemTypeBase+"validation-failed", body.Type)
+	assert.Equal(t, []problem.FieldError{{Pointer: "/price", Detail: "Price cannot be negative"}}, body.Errors)
+}
+
+func TestErrorHandler_Russian(t *testing.T) {
+	headers := map[string]string{fiber.HeaderAcceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8"}
+
+	_, _, body := serveError(t, productDomain.ErrInsufficientStock, headers)
+	assert.Equal(t, "Недостаточно товара на складе", body.Title)
+	assert.Equal(t, "insufficient-stock", body.Code)
+	assert.Empty(t, body.Detail)
+
+	validationErr := &productApp.ValidationError{}
+	validationErr.Add("price", productDomain.ErrMoneyCannotBeNeg)
+	_, _, body = serveError(t, validationErr, headers)
+	assert.Equal(t, "Ошибка валидации", body.Title)
+	assert.Equal(t, []problem.FieldError{{Pointer: "/price", Detail: "Цена не может быть отрицательной"}}, body.Errors)
+
+	_, _, body = serveError(t, errors.New("pq: connection refused"), headers)
+	assert.Equal(t, "Внутренняя ошибка сервера", body.Title)
+}
+
+func TestErrorHandler_ContentLanguage(t *testing.T) {
+	errHandler := ErrorHandler{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	app := fiber.New(fiber.Config{ErrorHandler: errHandler.Init()})
+	app.Get("/fail", func(c *fiber.Ctx) error { return productDomain.ErrProductNotFound })
+
+	req := httptest.NewRequest(fiber.MethodGet, "/fail", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "de, ru;q=0.5")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ru", resp.Header.Get(fiber.HeaderContentLanguage))
+}
+
+// Every registered error needs a title in every language.
+func TestMessages_CoverProblems(t *testing.T) {
+	for _, mapping := range problems.Mappings() {
+		for _, lang := range i18n.Languages {
+			_, ok := messages[mapping.Err.Code()][lang]
+			assert.True(t, ok, "no %s title for %q", lang, mapping.Err.Code())
+		}
+	}
+}
+
+func TestErrorHandler_Problem(t *testing.T) {
+	status, _, body := serveError(t, problem.New(fiber.StatusBadRequest, "Invalid product ID format"), nil)
+
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, problem.TypeBlank, body.Type)
+	assert.Equal(t, "Bad Request", body.Title)
+	assert.Equal(t, "Invalid product ID format", body.Detail)
+}
+
+func TestErrorHandler_FiberError(t *testing.T) {
+	status, _, body := serveError(t, fiber.ErrRequestEntityTooLarge, nil)
+
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, "Request Entity Too Large", body.Title)
+}
+
+func TestErrorHandler_InternalError(t *testing.T) {
+	status, _, body := serveError(t, errors.New("pq: connection refused"), nil)
+
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.Equal(t, "Internal Server Error", body.Title)
+	assert.Empty(t, body.Detail)
+	assert.Equal(t, "req-1", body.RequestID)
+}
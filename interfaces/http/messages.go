@@ -0,0 +1,108 @@
+package http
+
+import "github.com/BlackRRR/Irtea-test/pkg/i18n"
+
+// messages titles problems by error code.
+var messages = i18n.Catalog{
+	"malformed-body":    {i18n.English: "Malformed request body", i18n.Russian: "Некорректное тело запроса"},
+	"validation-failed": {i18n.English: "Validation failed", i18n.Russian: "Ошибка валидации"},
+
+	"user-not-found":     {i18n.English: "User not found", i18n.Russian: "Пользователь не найден"},
+	"product-not-found":  {i18n.English: "Product not found", i18n.Russian: "Товар не найден"},
+	"variant-not-found":  {i18n.English: "Product variant not found", i18n.Russian: "Вариант товара не найден"},
+	"image-not-found":    {i18n.English: "Product image not found", i18n.Russian: "Изображение товара не найдено"},
+	"order-not-found":    {i18n.English: "Order not found", i18n.Russian: "Заказ не найден"},
+	"webhook-not-found":  {i18n.English: "Webhook not found", i18n.Russian: "Вебхук не найден"},
+	"delivery-not-found": {i18n.English: "Webhook delivery not found", i18n.Russian: "Доставка вебхука не найдена"},
+
+	"version-conflict": {i18n.English: "Modified by another request", i18n.Russian: "Изменено другим запросом"},
+	"weak-etag":        {i18n.English: "Weak entity tags cannot be used with If-Match", i18n.Russian: "Слабые ETag нельзя использовать в If-Match"},
+	"malformed-etag":   {i18n.English: "Invalid If-Match header", i18n.Russian: "Некорректный заголовок If-Match"},
+
+	"user-already-exists":       {i18n.English: "User already exists", i18n.Russian: "Пользователь уже существует"},
+	"user-already-blocked":      {i18n.English: "User is already blocked", i18n.Russian: "Пользователь уже заблокирован"},
+	"user-not-blocked":          {i18n.English: "User is not blocked", i18n.Russian: "Пользователь не заблокирован"},
+	"duplicate-sku":             {i18n.English: "A variant with this SKU already exists", i18n.Russian: "Вариант с таким артикулом уже существует"},
+	"duplicate-variant-options": {i18n.English: "A variant with these options already exists", i18n.Russian: "Вариант с такими опциями уже существует"},
+	"options-locked":            {i18n.English: "Options cannot be changed once variants exist", i18n.Russian: "Опции нельзя менять, когда у товара есть варианты"},
+	"product-archived":          {i18n.English: "Product is archived", i18n.Russian: "Товар в архиве"},
+	"product-already-archived":  {i18n.English: "Product is already archived", i18n.Russian: "Товар уже в архиве"},
+	"product-not-archived":      {i18n.English: "Product is not archived", i18n.Russian: "Товар не в архиве"},
+	"product-has-orders":        {i18n.English: "Product has been ordered and cannot be deleted", i18n.Russian: "Товар уже заказывали, его нельзя удалить"},
+	"too-many-images":           {i18n.English: "Product has too many images", i18n.Russian: "У товара слишком много изображений"},
+	"insufficient-stock":        {i18n.English: "Insufficient stock", i18n.Russian: "Недостаточно товара на складе"},
+	"backorders-not-allowed":    {i18n.English: "Product does not accept backorders or pre-orders", i18n.Russian: "Товар нельзя заказать сверх остатка или предзаказать"},
+	"backorder-limit-exceeded":  {i18n.English: "Backorder limit exceeded", i18n.Russian: "Превышен лимит заказов сверх остатка"},
+	"reservation-not-active":    {i18n.English: "Stock reservation is not active", i18n.Russian: "Резерв товара неактивен"},
+	"reservation-expired":       {i18n.English: "Stock reservation has expired", i18n.Russian: "Срок резерва товара истёк"},
+	"invalid-order-status":      {i18n.English: "Invalid order status transition", i18n.Russian: "Недопустимая смена статуса заказа"},
+	"order-not-modifiable":      {i18n.English: "Order cannot be modified in its current status", i18n.Russian: "Заказ нельзя изменить в текущем статусе"},
+
+	"customer-not-found":   {i18n.English: "Customer not found", i18n.Russian: "Покупатель не найден"},
+	"customer-blocked":     {i18n.English: "Customer is blocked", i18n.Russian: "Покупатель заблокирован"},
+	"order-limit-exceeded": {i18n.English: "Order exceeds the per-order limit of a product", i18n.Russian: "Превышен лимит товара на один заказ"},
+	"user-limit-exceeded":  {i18n.English: "Order exceeds the per-customer limit of a product", i18n.Russian: "Превышен лимит товара на одного покупателя"},
+
+	"invalid-credentials":    {i18n.English: "Invalid credentials", i18n.Russian: "Неверные учётные данные"},
+	"rate-limited":           {i18n.English: "Too many requests, try again later", i18n.Russian: "Слишком много запросов, повторите позже"},
+	"admin-unauthorized":     {i18n.English: "Invalid admin token", i18n.Russian: "Неверный токен администратора"},
+	"admin-disabled":         {i18n.English: "Admin API is disabled", i18n.Russian: "API администратора отключён"},
+	"unsupported-image-type": {i18n.English: "Unsupported image type", i18n.Russian: "Неподдерживаемый тип изображения"},
+	"image-too-large":        {i18n.English: "Image is too large", i18n.Russian: "Изображение слишком большое"},
+
+	"user-too-young":                  {i18n.English: "User must be at least 18 years old", i18n.Russian: "Пользователю должно быть не меньше 18 лет"},
+	"invalid-password":                {i18n.English: "Password must be at least 8 characters long", i18n.Russian: "Пароль должен быть не короче 8 символов"},
+	"empty-first-name":                {i18n.English: "First name cannot be empty", i18n.Russian: "Имя не может быть пустым"},
+	"empty-last-name":                 {i18n.English: "Last name cannot be empty", i18n.Russian: "Фамилия не может быть пустой"},
+	"empty-order":                     {i18n.English: "Order cannot be empty", i18n.Russian: "Заказ не может быть пустым"},
+	"invalid-item-quantity":           {i18n.English: "Order item quantity must be positive", i18n.Russian: "Количество в позиции заказа должно быть положительным"},
+	"variant-required":                {i18n.English: "Product has variants, a variant must be specified", i18n.Russian: "У товара есть варианты, укажите вариант"},
+	"quantity-to-add-not-positive":    {i18n.English: "Quantity to add must be positive", i18n.Russian: "Добавляемое количество должно быть положительным"},
+	"quantity-to-remove-not-negative": {i18n.English: "Quantity must be less than zero", i18n.Russian: "Количество должно быть меньше нуля"},
+	"invalid-quantity":                {i18n.English: "Invalid quantity", i18n.Russian: "Некорректное количество"},
+	"negative-inventory":              {i18n.English: "Inventory quantity cannot be negative", i18n.Russian: "Остаток не может быть отрицательным"},
+	"negative-price":                  {i18n.English: "Price cannot be negative", i18n.Russian: "Цена не может быть отрицательной"},
+	"invalid-price":                   {i18n.English: "Invalid price", i18n.Russian: "Некорректная цена"},
+	"empty-description":               {i18n.English: "Product description cannot be empty", i18n.Russian: "Описание товара не может быть пустым"},
+	"empty-sku":                       {i18n.English: "Variant SKU cannot be empty", i18n.Russian: "Артикул варианта не может быть пустым"},
+	"invalid-variant-options":         {i18n.English: "Variant options do not match the product's option axes", i18n.Russian: "Опции варианта не соответствуют осям опций товара"},
+	"empty-option-name":               {i18n.English: "Option name cannot be empty", i18n.Russian: "Название опции не может быть пустым"},
+	"empty-option-values":             {i18n.English: "Option values cannot be empty", i18n.Russian: "Значения опции не могут быть пустыми"},
+	"duplicate-option-name":           {i18n.English: "Option names must be unique", i18n.Russian: "Названия опций должны быть уникальными"},
+	"invalid-reorder-threshold":       {i18n.English: "Reorder threshold cannot be negative", i18n.Russian: "Порог дозаказа не может быть отрицательным"},
+	"invalid-backorder-limit":         {i18n.English: "Backorder limit cannot be negative", i18n.Russian: "Лимит заказов сверх остатка не может быть отрицательным"},
+	"invalid-purchase-limits":         {i18n.English: "Purchase limits must be positive and a per-customer limit needs a window", i18n.Russian: "Лимиты покупки должны быть положительными, а лимиту на покупателя нужен период"},
+	"invalid-image-order":             {i18n.English: "Image order must list every product image exactly once", i18n.Russian: "Порядок должен содержать каждое изображение товара ровно один раз"},
+	"invalid-import-mode":             {i18n.English: "Import mode must be atomic or best_effort", i18n.Russian: "Режим импорта должен быть atomic или best_effort"},
+	"invalid-alert-status":            {i18n.English: "Alert status must be open, resolved or all", i18n.Russian: "Статус оповещения должен быть open, resolved или all"},
+	"invalid-webhook-url":             {i18n.English: "Webhook URL must be an absolute http or https URL", i18n.Russian: "URL вебхука должен быть абсолютным http- или https-адресом"},
+	"no-event-types":                  {i18n.English: "Webhook must subscribe to at least one event type", i18n.Russian: "Вебхук должен подписываться хотя бы на один тип событий"},
+	"unknown-event-type":              {i18n.English: "Unknown webhook event type", i18n.Russian: "Неизвестный тип события вебхука"},
+	"secret-too-short":                {i18n.English: "Webhook secret must be at least 16 characters", i18n.Russian: "Секрет вебхука должен быть не короче 16 символов"},
+
+	"invalid-user-id":         {i18n.English: "Invalid user ID format", i18n.Russian: "Некорректный формат ID пользователя"},
+	"invalid-order-id":        {i18n.English: "Invalid order ID format", i18n.Russian: "Некорректный формат ID заказа"},
+	"invalid-product-id":      {i18n.English: "Invalid product ID format", i18n.Russian: "Некорректный формат ID товара"},
+	"invalid-variant-id":      {i18n.English: "Invalid variant ID format", i18n.Russian: "Некорректный формат ID варианта"},
+	"invalid-image-id":        {i18n.English: "Invalid image ID format", i18n.Russian: "Некорректный формат ID изображения"},
+	"invalid-webhook-id":      {i18n.English: "Invalid webhook ID format", i18n.Russian: "Некорректный формат ID вебхука"},
+	"invalid-delivery-id":     {i18n.English: "Invalid delivery ID format", i18n.Russian: "Некорректный формат ID доставки"},
+	"invalid-last-event-id":   {i18n.English: "Invalid Last-Event-ID", i18n.Russian: "Некорректный Last-Event-ID"},
+	"invalid-purchase-window": {i18n.English: "Invalid purchase window", i18n.Russian: "Некорректный период покупки"},
+	"image-required":          {i18n.English: `Multipart field "image" is required`, i18n.Russian: `Поле формы "image" обязательно`},
+	"invalid-image-upload":    {i18n.English: "Invalid image upload", i18n.Russian: "Некорректная загрузка изображения"},
+	"unknown-catalog-format":  {i18n.English: "Format must be csv or ndjson", i18n.Russian: "Формат должен быть csv или ndjson"},
+	"log-level-required":      {i18n.English: "Level is required for the root logger", i18n.Russian: "Для корневого логгера нужно указать уровень"},
+}
+
+// statusTitles titles untyped problems by status code. Statuses that are not
+// listed keep their English reason phrase.
+var statusTitles = i18n.Catalog{
+	"400": {i18n.English: "Bad Request", i18n.Russian: "Некорректный запрос"},
+	"404": {i18n.English: "Not Found", i18n.Russian: "Не найдено"},
+	"405": {i18n.English: "Method Not Allowed", i18n.Russian: "Метод не поддерживается"},
+	"413": {i18n.English: "Request Entity Too Large", i18n.Russian: "Слишком большой запрос"},
+	"415": {i18n.English: "Unsupported Media Type", i18n.Russian: "Неподдерживаемый тип содержимого"},
+	"500": {i18n.English: "Internal Server Error", i18n.Russian: "Внутренняя ошибка сервера"},
+	"503": {i18n.English: "Service Unavailable", i18n.Russian: "Сервис недоступен"},
+}
@@ -4,36 +4,33 @@ import (
 	"log/slog"
 	"github.com/gofiber/fiber/v2"
 	"fmt"
-	"github.com/BlackRRR/Irtea-test/pkg/observability/tracer"
+	otelfiber "github.com/BlackRRR/Irtea-test/pkg/observability/tracer/middleware"
 	"time"
 	"github.com/getsentry/sentry-go"
 	"runtime/debug"
 	sentryPkg "github.com/BlackRRR/Irtea-test/pkg/observability/sentry"
+	"github.com/BlackRRR/Irtea-test/pkg/problem"
+	"github.com/BlackRRR/Irtea-test/pkg/ratelimit"
 )
 
 type Middleware struct {
-	logger *slog.Logger
+	logger     *slog.Logger
+	rateLimits ratelimit.Store
 }
 
-func NewMiddleware(logger *slog.Logger) *Middleware {
-	return &Middleware{logger: logger}
+// NewMiddleware builds the middleware. A nil rateLimits store disables rate
+// limiting.
+func NewMiddleware(logger *slog.Logger, rateLimits ratelimit.Store) *Middleware {
+	return &Middleware{logger: logger, rateLimits: rateLimits}
 }
 
+// TracingMiddleware starts a server span per request and records the HTTP
+// duration, size and active request metrics. Errors are rendered inside it,
+// so spans and the middleware before it see the final status.
 func (m *Middleware) TracingMiddleware() fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		spanName := fmt.Sprintf("%s %s", c.Method(), c.Path())
-		ctx, span := tracer.Start(c.Context(), spanName,
-			tracer.String("http.method", c.Method()),
-			tracer.String("http.path", c.Path()),
-			tracer.String("http.user_agent", c.Get("User-Agent")),
-			tracer.String("http.request_id", c.Get("X-Request-Id")),
-		)
-		defer span.End()
-
-		c.SetUserContext(ctx)
-
-		return c.Next()
-	}
+	return otelfiber.Middleware(otelfiber.WithSpanNameFormatter(func(c *fiber.Ctx) string {
+		return c.Method() + " " + c.Route().Path
+	}))
 }
 
 func (m *Middleware) LoggingMiddleware() fiber.Handler {
@@ -44,13 +41,14 @@ func (m *Middleware) LoggingMiddleware() fiber.Handler {
 
 		duration := time.Since(start)
 
-		m.logger.Info("HTTP request",
+		// The user context now holds the request span, and the request ID
+		// through the fiber locals.
+		m.logger.InfoContext(c.UserContext(), "HTTP request",
 			slog.String("method", c.Method()),
 			slog.String("path", c.Path()),
 			slog.Int("status", c.Response().StatusCode()),
 			slog.Duration("duration", duration),
 			slog.String("user_agent", c.Get("User-Agent")),
-			slog.String("request_id", c.Get("X-Request-Id")),
 		)
 
 		return err
@@ -64,7 +62,7 @@ func (m *Middleware) RecoveryMiddleware() fiber.Handler {
 				stack := debug.Stack()
 
 				// Log panic locally
-				m.logger.Error("Panic recovered",
+				m.logger.ErrorContext(c.UserContext(), "Panic recovered",
 					slog.String("method", c.Method()),
 					slog.String("path", c.Path()),
 					slog.Any("panic", r),
@@ -89,9 +87,7 @@ func (m *Middleware) RecoveryMiddleware() fiber.Handler {
 				})
 
 				// Return 500 error
-				c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": "Internal server error",
-				})
+				_ = problem.Write(c, problem.New(fiber.StatusInternalServerError, ""))
 			}
 		}()
 
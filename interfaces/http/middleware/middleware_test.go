@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BlackRRR/Irtea-test/pkg/observability/logger/zapslog"
+	"github.com/BlackRRR/Irtea-test/pkg/requestctx"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"log/slog"
+)
+
+func TestLoggingMiddleware_ContextFields(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	m := NewMiddleware(slog.New(zapslog.NewHandler(true, core)), nil)
+
+	var traceID string
+	app := fiber.New()
+	app.Use(requestid.New(requestid.Config{ContextKey: requestctx.RequestIDKey, Generator: func() string { return "req-1" }}))
+	app.Use(m.LoggingMiddleware())
+	app.Use(m.TracingMiddleware())
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		c.Locals(UserIDKey, "user-1")
+		traceID = trace.SpanContextFromContext(c.UserContext()).TraceID().String()
+		m.logger.InfoContext(c.UserContext(), "Handling")
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/orders/1", nil))
+	require.NoError(t, err)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		fields := entry.ContextMap()
+		assert.Equal(t, "req-1", fields["request_id"], entry.Message)
+		assert.Equal(t, "user-1", fields["user_id"], entry.Message)
+		assert.Equal(t, traceID, fields["trace_id"], entry.Message)
+	}
+}
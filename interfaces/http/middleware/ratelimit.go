@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/BlackRRR/Irtea-test/pkg/errcode"
+	"github.com/BlackRRR/Irtea-test/pkg/ratelimit"
+	"github.com/BlackRRR/Irtea-test/pkg/requestctx"
+)
+
+// UserIDKey is the local under which authentication is to store the caller's
+// user ID for KeyByUser. The API does not authenticate callers yet, so no
+// route sets it. Logs written with the request context carry it.
+const UserIDKey = requestctx.UserIDKey
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+var ErrRateLimited = errcode.New("rate-limited", "rate limit exceeded")
+
+// KeyFunc identifies who a request is counted against.
+type KeyFunc func(c *fiber.Ctx) string
+
+func KeyByIP(c *fiber.Ctx) string {
+	return "ip:" + c.IP()
+}
+
+// KeyByUser counts authenticated requests per user and the rest per IP.
+func KeyByUser(c *fiber.Ctx) string {
+	if userID, ok := c.Locals(UserIDKey).(string); ok && userID != "" {
+		return "user:" + userID
+	}
+	return KeyByIP(c)
+}
+
+// RateLimit limits the requests of each key under the named policy. Routes
+// given the same name share buckets. Requests are let through when the
+// store fails, so an outage of the store does not take the API down.
+func (m *Middleware) RateLimit(name string, policy ratelimit.Policy, key KeyFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if m.rateLimits == nil || !policy.Enabled() {
+			return c.Next()
+		}
+
+		result, err := m.rateLimits.Take(c.UserContext(), name+":"+key(c), policy)
+		if err != nil {
+			m.logger.WarnContext(c.UserContext(), "Rate limit store failed",
+				slog.String("policy", name),
+				slog.Any("error", err),
+			)
+			return c.Next()
+		}
+
+		c.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+		c.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+		c.Set(HeaderRateLimitReset, seconds(result.Reset))
+		c.Set(HeaderRateLimitPolicy, policy.String())
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, seconds(result.RetryAfter))
+			return ErrRateLimited
+		}
+
+		return c.Next()
+	}
+}
+
+// seconds rounds d up to whole seconds, as the headers require.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/BlackRRR/Irtea-test/pkg/ratelimit"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Policy) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func limitedApp(store ratelimit.Store, key KeyFunc) *fiber.App {
+	m := NewMiddleware(slog.New(slog.NewTextHandler(io.Discard, nil)), store)
+	policy := ratelimit.Policy{Limit: 2, Period: time.Minute}
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if user := c.Get("X-Test-User"); user != "" {
+			c.Locals(UserIDKey, user)
+		}
+		return c.Next()
+	})
+	app.Post("/", m.RateLimit("test", policy, key), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	return app
+}
+
+func post(t *testing.T, app *fiber.App, user string) (int, map[string]string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/", nil)
+	if user != "" {
+		req.Header.Set("X-Test-User", user)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	headers := map[string]string{}
+	for _, name := range []string{HeaderRateLimitLimit, HeaderRateLimitRemaining, HeaderRateLimitReset, HeaderRateLimitPolicy, fiber.HeaderRetryAfter} {
+		headers[name] = resp.Header.Get(name)
+	}
+	return resp.StatusCode, headers
+}
+
+func TestRateLimit(t *testing.T) {
+	app := limitedApp(ratelimit.NewMemoryStore(), KeyByIP)
+
+	status, headers := post(t, app, "")
+	assert.Equal(t, fiber.StatusNoContent, status)
+	assert.Equal(t, map[string]string{
+		HeaderRateLimitLimit:     "2",
+		HeaderRateLimitRemaining: "1",
+		HeaderRateLimitReset:     "30",
+		HeaderRateLimitPolicy:    "2;w=60",
+		fiber.HeaderRetryAfter:   "",
+	}, headers)
+
+	status, _ = post(t, app, "")
+	assert.Equal(t, fiber.StatusNoContent, status)
+
+	// The default error handler renders ErrRateLimited as a 500; the server's
+	// maps it to 429.
+	status, headers = post(t, app, "")
+	assert.NotEqual(t, fiber.StatusNoContent, status)
+	assert.Equal(t, "0", headers[HeaderRateLimitRemaining])
+	assert.Equal(t, "30", headers[fiber.HeaderRetryAfter])
+}
+
+func TestRateLimit_KeyByUser(t *testing.T) {
+	app := limitedApp(ratelimit.NewMemoryStore(), KeyByUser)
+
+	for range 2 {
+		status, _ := post(t, app, "alice")
+		assert.Equal(t, fiber.StatusNoContent, status)
+	}
+
+	status, _ := post(t, app, "alice")
+	assert.NotEqual(t, fiber.StatusNoContent, status)
+
+	// Other users and anonymous callers have their own buckets.
+	status, _ = post(t, app, "bob")
+	assert.Equal(t, fiber.StatusNoContent, status)
+	status, _ = post(t, app, "")
+	assert.Equal(t, fiber.StatusNoContent, status)
+}
+
+func TestRateLimit_StoreFailure(t *testing.T) {
+	app := limitedApp(failingStore{}, KeyByIP)
+
+	status, headers := post(t, app, "")
+	assert.Equal(t, fiber.StatusNoContent, status)
+	assert.Empty(t, headers[HeaderRateLimitLimit])
+}
@@ -0,0 +1,519 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/BlackRRR/Irtea-test/interfaces/http/middleware"
+	orderDto "github.com/BlackRRR/Irtea-test/internal/order/interfaces/http/dto"
+	productApp "github.com/BlackRRR/Irtea-test/internal/product/app"
+	productDto "github.com/BlackRRR/Irtea-test/internal/product/interfaces/http/dto"
+	userDto "github.com/BlackRRR/Irtea-test/internal/user/interfaces/http/dto"
+	webhookDto "github.com/BlackRRR/Irtea-test/internal/webhook/interfaces/http/dto"
+	"github.com/BlackRRR/Irtea-test/pkg/openapi"
+	"github.com/BlackRRR/Irtea-test/pkg/problem"
+	"github.com/BlackRRR/Irtea-test/pkg/health"
+)
+
+type HealthResponse struct {
+	Status    string `json:"status"`
+	Timestamp string `json:"timestamp"`
+	Service   string `json:"service"`
+}
+
+const docsPage = `<!doctype html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Irtea API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({url: "/v1/openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>
+`
+
+var (
+	ifMatch = openapi.HeaderParam(fiber.HeaderIfMatch,
+		`Version from the ETag, as "<version>". The request fails with 412 when the entity has changed.`)
+
+	pagination = []openapi.Param{
+		openapi.QueryParam("limit", 10, "Page size"),
+		openapi.QueryParam("offset", 0, "Number of items to skip"),
+	}
+
+	eventStream = openapi.Content{
+		MediaType: "text/event-stream",
+		Schema: &openapi.Schema{
+			Type:        "string",
+			Description: `"status" events whose data is an OrderStatusEvent, and ": heartbeat" comments`,
+		},
+	}
+
+	catalogFormat = openapi.QueryParam("format", "", "csv or ndjson; defaults to the media type")
+
+	catalogFile = []openapi.Content{
+		{MediaType: "text/csv", Schema: &openapi.Schema{Type: "string", Description: "Header row with id, description, tags, price and quantity"}},
+		{MediaType: "application/x-ndjson", Schema: &openapi.Schema{Type: "string", Description: "One ProductRecord per line"}},
+	}
+
+	imageUpload = openapi.Content{
+		MediaType: fiber.MIMEMultipartForm,
+		Schema: &openapi.Schema{
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"image": {Type: "string", Format: "binary"}},
+			Required:   []string{"image"},
+		},
+	}
+)
+
+// reply is a JSON reply that carries the entity version in an ETag header
+// when etag is set.
+func reply(status int, description string, body any, etag bool) openapi.Reply {
+	r := openapi.Reply{
+		Status:      status,
+		Description: description,
+		Content:     []openapi.Content{openapi.JSON(body)},
+	}
+	if etag {
+		r.Headers = map[string]*openapi.Header{
+			fiber.HeaderETag: {Description: "Entity version", Schema: &openapi.Schema{Type: "string"}},
+		}
+	}
+
+	return r
+}
+
+func noContent() openapi.Reply {
+	return openapi.Reply{Status: http.StatusNoContent, Description: http.StatusText(http.StatusNoContent)}
+}
+
+// errorReplies declares problem details replies. The type URIs are listed in
+// problems.go.
+func errorReplies(statuses ...int) []openapi.Reply {
+	replies := make([]openapi.Reply, 0, len(statuses))
+	for _, status := range statuses {
+		replies = append(replies, openapi.Reply{
+			Status:      status,
+			Description: http.StatusText(status),
+			Content:     []openapi.Content{{MediaType: problem.ContentType, Value: problem.Problem{}}},
+		})
+	}
+
+	return replies
+}
+
+// rateLimited declares the reply of routes behind a rate-limit policy.
+func rateLimited() []openapi.Reply {
+	seconds := &openapi.Schema{Type: "integer"}
+
+	return []openapi.Reply{{
+		Status:      http.StatusTooManyRequests,
+		Description: http.StatusText(http.StatusTooManyRequests),
+		Headers: map[string]*openapi.Header{
+			fiber.HeaderRetryAfter:              {Description: "Seconds until a request is let through", Schema: seconds},
+			middleware.HeaderRateLimitLimit:     {Description: "Requests allowed in a burst", Schema: seconds},
+			middleware.HeaderRateLimitRemaining: {Description: "Requests left in the bucket", Schema: seconds},
+			middleware.HeaderRateLimitReset:     {Description: "Seconds until the bucket is full", Schema: seconds},
+			middleware.HeaderRateLimitPolicy:    {Description: `Policy, e.g. "5;w=60"`, Schema: &openapi.Schema{Type: "string"}},
+		},
+		Content: []openapi.Content{{MediaType: problem.ContentType, Value: problem.Problem{}}},
+	}}
+}
+
+func replies(first openapi.Reply, rest ...[]openapi.Reply) []openapi.Reply {
+	all := []openapi.Reply{first}
+	for _, r := range rest {
+		all = append(all, r...)
+	}
+
+	return all
+}
+
+// OpenAPI describes every route registered by setupRoutes. TestOpenAPI fails
+// when the two disagree or when api/openapi.json is out of date.
+func OpenAPI() *openapi.Document {
+	b := openapi.New(
+		openapi.Info{Title: "Irtea API", Version: "1.0.0"},
+		&openapi.Schema{Type: "string", Format: "uuid"},
+	)
+
+	const (
+		statusBadRequest    = http.StatusBadRequest
+		statusNotFound      = http.StatusNotFound
+		statusConflict      = http.StatusConflict
+		statusPrecondition  = http.StatusPreconditionFailed
+		statusUnprocessable = http.StatusUnprocessableEntity
+		statusInternal      = http.StatusInternalServerError
+	)
+
+	b.Add(
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/health", ID: "healthCheck", Tag: "system",
+			Summary:   "Service health check, an alias of /v1/health/live",
+			Responses: []openapi.Reply{reply(http.StatusOK, "Service is up", HealthResponse{}, false)},
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/health/live", ID: "liveness", Tag: "system",
+			Summary:   "Liveness probe",
+			Responses: []openapi.Reply{reply(http.StatusOK, "Process is serving requests", HealthResponse{}, false)},
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/health/ready", ID: "readiness", Tag: "system",
+			Summary: "Readiness probe. Fails while a required dependency is down and during shutdown",
+			Responses: []openapi.Reply{
+				reply(http.StatusOK, "Ready to receive traffic; optional checks may be degraded", health.Report{}, false),
+				reply(http.StatusServiceUnavailable, "Failing or draining", health.Report{}, false),
+			},
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/openapi.json", ID: "getOpenAPI", Tag: "system",
+			Summary: "This document",
+			Responses: []openapi.Reply{{
+				Status: http.StatusOK, Description: "OpenAPI document",
+				Content: []openapi.Content{{MediaType: openapi.MediaTypeJSON, Schema: &openapi.Schema{Type: "object"}}},
+			}},
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/docs", ID: "getDocs", Tag: "system",
+			Summary: "Interactive API documentation",
+			Responses: []openapi.Reply{{
+				Status: http.StatusOK, Description: "HTML page",
+				Content: []openapi.Content{{MediaType: fiber.MIMETextHTML, Schema: &openapi.Schema{Type: "string"}}},
+			}},
+		},
+	)
+
+	// Bodies that break a validate rule.
+	invalidBody := errorReplies(statusUnprocessable)
+
+	adminToken := []openapi.Param{openapi.HeaderParam(fiber.HeaderAuthorization, `"Bearer <HTTP_SERVER_ADMIN_TOKEN>". The admin API answers 404 when no token is configured.`)}
+
+	b.Add(
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/admin/log-level", ID: "getLogLevel", Tag: "admin",
+			Summary: "Root log level and the overrides of named loggers",
+			Params:  adminToken,
+			Responses: replies(reply(http.StatusOK, "Log levels", LogLevelResponse{}, false),
+				errorReplies(http.StatusUnauthorized, statusNotFound)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/admin/log-level", ID: "setLogLevel", Tag: "admin",
+			Summary: "Change the root level or a named logger's, optionally for a while",
+			Params:  adminToken,
+			Body:    []openapi.Content{openapi.JSON(LogLevelRequest{})},
+			Responses: replies(reply(http.StatusOK, "Log levels", LogLevelResponse{}, false),
+				errorReplies(statusBadRequest, http.StatusUnauthorized, statusNotFound), invalidBody),
+		},
+	)
+
+	b.Add(
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/users/register", ID: "registerUser", Tag: "users",
+			Summary: "Register a user",
+			Body:    []openapi.Content{openapi.JSON(userDto.RegisterRequest{})},
+			Responses: replies(reply(http.StatusCreated, "Registered user", userDto.UserResponse{}, true),
+				errorReplies(statusBadRequest, statusConflict, statusInternal), invalidBody, rateLimited()),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/users/:id", ID: "getUser", Tag: "users",
+			Summary: "Get a user",
+			Responses: replies(reply(http.StatusOK, "User", userDto.UserResponse{}, true),
+				errorReplies(statusBadRequest, statusNotFound, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/users/:id/block", ID: "blockUser", Tag: "users",
+			Summary: "Block a user from placing orders",
+			Params:  []openapi.Param{ifMatch},
+			Responses: replies(reply(http.StatusOK, "Blocked user", userDto.UserResponse{}, true),
+				errorReplies(statusBadRequest, statusNotFound, statusConflict, statusPrecondition, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/users/:id/unblock", ID: "unblockUser", Tag: "users",
+			Summary: "Let a blocked user place orders again",
+			Params:  []openapi.Param{ifMatch},
+			Responses: replies(reply(http.StatusOK, "Unblocked user", userDto.UserResponse{}, true),
+				errorReplies(statusBadRequest, statusNotFound, statusConflict, statusPrecondition, statusInternal)),
+		},
+	)
+
+	product := reply(http.StatusOK, "Product", productDto.ProductResponse{}, true)
+	versionedProductErrors := errorReplies(statusBadRequest, statusNotFound, statusConflict, statusPrecondition, statusInternal)
+
+	b.Add(
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/products", ID: "createProduct", Tag: "products",
+			Summary: "Create a product",
+			Body:    []openapi.Content{openapi.JSON(productDto.CreateProductRequest{})},
+			Responses: replies(reply(http.StatusCreated, "Created product", productDto.ProductResponse{}, true),
+				errorReplies(statusBadRequest, statusInternal), invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/products", ID: "listProducts", Tag: "products",
+			Summary: "List products",
+			Params:  pagination,
+			Responses: replies(reply(http.StatusOK, "Page of products", productDto.ProductListResponse{}, false),
+				errorReplies(statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/products/import", ID: "importProducts", Tag: "catalog",
+			Summary:     "Import products from CSV or NDJSON",
+			Description: "In atomic mode every row is stored or none is; best_effort stores the valid rows.",
+			Params: []openapi.Param{
+				catalogFormat,
+				{In: "query", Name: "mode", Default: string(productApp.ImportModeAtomic), Enum: []any{
+					string(productApp.ImportModeAtomic), string(productApp.ImportModeBestEffort),
+				}},
+				openapi.QueryParam("dry_run", false, "Only validate the rows"),
+			},
+			Body: catalogFile,
+			Responses: replies(reply(http.StatusOK, "Per-row import report", productApp.ImportReport{}, false),
+				[]openapi.Reply{reply(statusUnprocessable, "Atomic import rejected; nothing was stored", productApp.ImportReport{}, false)},
+				errorReplies(statusBadRequest, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/products/export", ID: "exportProducts", Tag: "catalog",
+			Summary: "Export the active catalog as CSV or NDJSON",
+			Params:  []openapi.Param{catalogFormat},
+			Responses: replies(openapi.Reply{Status: http.StatusOK, Description: "Catalog file", Content: catalogFile},
+				errorReplies(statusBadRequest)),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/products/:id", ID: "getProduct", Tag: "products",
+			Summary:     "Get a product",
+			Description: "The ETag does not change when orders reserve or release stock, so reserved and available may differ for the same ETag.",
+			Responses:   replies(product, errorReplies(statusBadRequest, statusNotFound, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPatch, Path: "/v1/products/:id", ID: "patchProduct", Tag: "products",
+			Summary: "Update a product with a JSON Merge Patch",
+			Params:  []openapi.Param{ifMatch},
+			Body:    []openapi.Content{{MediaType: "application/merge-patch+json", Value: productDto.PatchProductRequest{}}, openapi.JSON(productDto.PatchProductRequest{})},
+			Responses: replies(product,
+				errorReplies(statusUnprocessable),
+				versionedProductErrors),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/products/:id/price", ID: "updateProductPrice", Tag: "products",
+			Summary:   "Set the product price",
+			Params:    []openapi.Param{ifMatch},
+			Body:      []openapi.Content{openapi.JSON(productDto.UpdatePriceRequest{})},
+			Responses: replies(product, versionedProductErrors, invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/products/:id/stock", ID: "adjustProductStock", Tag: "products",
+			Summary:   "Change the stock on hand by a signed quantity",
+			Params:    []openapi.Param{ifMatch},
+			Body:      []openapi.Content{openapi.JSON(productDto.AdjustStockRequest{})},
+			Responses: replies(product, versionedProductErrors, invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/products/:id/purchase-limits", ID: "setPurchaseLimits", Tag: "products",
+			Summary:   "Replace the purchase limits",
+			Params:    []openapi.Param{ifMatch},
+			Body:      []openapi.Content{openapi.JSON(productDto.PurchaseLimitsRequest{})},
+			Responses: replies(product, versionedProductErrors, invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodDelete, Path: "/v1/products/:id/purchase-limits", ID: "clearPurchaseLimits", Tag: "products",
+			Summary:   "Remove the purchase limits",
+			Params:    []openapi.Param{ifMatch},
+			Responses: replies(product, versionedProductErrors),
+		},
+		openapi.Route{
+			Method: fiber.MethodDelete, Path: "/v1/products/:id", ID: "deleteProduct", Tag: "products",
+			Summary:     "Archive a product, or delete it with ?hard=true",
+			Description: "Products that have been ordered can only be archived. If-Match applies to archiving.",
+			Params:      []openapi.Param{openapi.QueryParam("hard", false, "Delete instead of archiving"), ifMatch},
+			Responses: replies(reply(http.StatusOK, "Archived product", productDto.ProductResponse{}, true),
+				[]openapi.Reply{noContent()},
+				errorReplies(statusBadRequest, statusNotFound, statusConflict, statusPrecondition, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/products/:id/restore", ID: "restoreProduct", Tag: "products",
+			Summary:   "Restore an archived product",
+			Params:    []openapi.Param{ifMatch},
+			Responses: replies(product, versionedProductErrors),
+		},
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/products/:id/variants", ID: "addVariant", Tag: "variants",
+			Summary: "Add a variant",
+			Params:  []openapi.Param{ifMatch},
+			Body:    []openapi.Content{openapi.JSON(productDto.AddVariantRequest{})},
+			Responses: replies(reply(http.StatusCreated, "Product with the new variant", productDto.ProductResponse{}, true),
+				versionedProductErrors, invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/products/:id/variants/:variantId/price", ID: "updateVariantPrice", Tag: "variants",
+			Summary:   "Set a variant price",
+			Params:    []openapi.Param{ifMatch},
+			Body:      []openapi.Content{openapi.JSON(productDto.UpdatePriceRequest{})},
+			Responses: replies(product, versionedProductErrors, invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/products/:id/variants/:variantId/stock", ID: "adjustVariantStock", Tag: "variants",
+			Summary:   "Change a variant's stock by a signed quantity",
+			Params:    []openapi.Param{ifMatch},
+			Body:      []openapi.Content{openapi.JSON(productDto.AdjustStockRequest{})},
+			Responses: replies(product, versionedProductErrors, invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/products/:id/images", ID: "uploadImage", Tag: "images",
+			Summary: "Upload an image",
+			Params:  []openapi.Param{ifMatch},
+			Body:    []openapi.Content{imageUpload},
+			Responses: replies(reply(http.StatusCreated, "Product with the new image", productDto.ProductResponse{}, true),
+				versionedProductErrors,
+				errorReplies(http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/products/:id/images/order", ID: "reorderImages", Tag: "images",
+			Summary:   "Reorder the images",
+			Params:    []openapi.Param{ifMatch},
+			Body:      []openapi.Content{openapi.JSON(productDto.ReorderImagesRequest{})},
+			Responses: replies(product, versionedProductErrors, invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/products/:id/images/:imageId/primary", ID: "setPrimaryImage", Tag: "images",
+			Summary:   "Make an image the primary one",
+			Params:    []openapi.Param{ifMatch},
+			Responses: replies(product, versionedProductErrors),
+		},
+		openapi.Route{
+			Method: fiber.MethodDelete, Path: "/v1/products/:id/images/:imageId", ID: "deleteImage", Tag: "images",
+			Summary:   "Delete an image",
+			Params:    []openapi.Param{ifMatch},
+			Responses: replies(product, versionedProductErrors),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/inventory/alerts", ID: "listStockAlerts", Tag: "inventory",
+			Summary: "List low-stock alerts",
+			Params: append([]openapi.Param{{
+				In: "query", Name: "status", Default: string(productApp.AlertStatusOpen),
+				Enum: []any{string(productApp.AlertStatusOpen), string(productApp.AlertStatusResolved), string(productApp.AlertStatusAll)},
+			}}, pagination...),
+			Responses: replies(reply(http.StatusOK, "Page of alerts", productDto.StockAlertListResponse{}, false),
+				errorReplies(statusBadRequest, statusInternal)),
+		},
+	)
+
+	order := reply(http.StatusOK, "Order", orderDto.OrderResponse{}, true)
+	lastEventID := openapi.HeaderParam("Last-Event-ID", "Resume after this change; last_event_id in the query works too")
+
+	b.Add(
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/orders", ID: "placeOrder", Tag: "orders",
+			Summary: "Place an order",
+			Body:    []openapi.Content{openapi.JSON(orderDto.PlaceOrderRequest{})},
+			Responses: replies(reply(http.StatusCreated, "Placed order", orderDto.OrderResponse{}, true),
+				errorReplies(statusBadRequest, statusNotFound, statusConflict, statusUnprocessable, statusInternal), rateLimited()),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/orders/:id", ID: "getOrder", Tag: "orders",
+			Summary:   "Get an order",
+			Responses: replies(order, errorReplies(statusBadRequest, statusNotFound, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/orders/:id/events", ID: "streamOrderEvents", Tag: "orders",
+			Summary: "Stream the order's status changes as server-sent events",
+			Params:  []openapi.Param{lastEventID},
+			Responses: replies(openapi.Reply{Status: http.StatusOK, Description: "Event stream", Content: []openapi.Content{eventStream}},
+				errorReplies(statusBadRequest, statusNotFound, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/orders/:id/confirm", ID: "confirmOrder", Tag: "orders",
+			Summary: "Confirm a pending order",
+			Params:  []openapi.Param{ifMatch},
+			Responses: replies(order, errorReplies(statusBadRequest, statusNotFound, statusConflict, statusPrecondition, statusInternal),
+				rateLimited()),
+		},
+		openapi.Route{
+			Method: fiber.MethodPut, Path: "/v1/orders/:id/cancel", ID: "cancelOrder", Tag: "orders",
+			Summary: "Cancel an order",
+			Params:  []openapi.Param{ifMatch},
+			Responses: replies(order, errorReplies(statusBadRequest, statusNotFound, statusConflict, statusPrecondition, statusInternal),
+				rateLimited()),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/orders/users/:userId", ID: "listUserOrders", Tag: "orders",
+			Summary: "List a user's orders",
+			Params:  pagination,
+			Responses: replies(reply(http.StatusOK, "Page of orders", orderDto.OrderListResponse{}, false),
+				errorReplies(statusBadRequest, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/orders/users/:userId/events", ID: "streamUserOrderEvents", Tag: "orders",
+			Summary: "Stream status changes of a user's orders as server-sent events",
+			Params:  []openapi.Param{lastEventID},
+			Responses: replies(openapi.Reply{Status: http.StatusOK, Description: "Event stream", Content: []openapi.Content{eventStream}},
+				errorReplies(statusBadRequest, statusInternal)),
+		},
+	)
+
+	b.Add(
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/webhooks", ID: "createWebhook", Tag: "webhooks",
+			Summary: "Subscribe a URL to domain events",
+			Body:    []openapi.Content{openapi.JSON(webhookDto.CreateSubscriptionRequest{})},
+			Responses: replies(reply(http.StatusCreated, "Subscription, with its secret", webhookDto.SubscriptionResponse{}, false),
+				errorReplies(statusBadRequest, statusInternal), invalidBody),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/webhooks", ID: "listWebhooks", Tag: "webhooks",
+			Summary: "List subscriptions",
+			Params:  pagination,
+			Responses: replies(reply(http.StatusOK, "Page of subscriptions", webhookDto.SubscriptionListResponse{}, false),
+				errorReplies(statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/webhooks/:id", ID: "getWebhook", Tag: "webhooks",
+			Summary: "Get a subscription",
+			Responses: replies(reply(http.StatusOK, "Subscription", webhookDto.SubscriptionResponse{}, false),
+				errorReplies(statusBadRequest, statusNotFound, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodDelete, Path: "/v1/webhooks/:id", ID: "deleteWebhook", Tag: "webhooks",
+			Summary:   "Delete a subscription and its delivery log",
+			Responses: replies(noContent(), errorReplies(statusBadRequest, statusNotFound, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodGet, Path: "/v1/webhooks/:id/deliveries", ID: "listWebhookDeliveries", Tag: "webhooks",
+			Summary: "Delivery log with every attempt",
+			Params:  pagination,
+			Responses: replies(reply(http.StatusOK, "Page of deliveries", webhookDto.DeliveryListResponse{}, false),
+				errorReplies(statusBadRequest, statusNotFound, statusInternal)),
+		},
+		openapi.Route{
+			Method: fiber.MethodPost, Path: "/v1/webhooks/:id/deliveries/:deliveryId/redeliver", ID: "redeliverWebhook", Tag: "webhooks",
+			Summary: "Send a past delivery again",
+			Responses: replies(reply(http.StatusAccepted, "Delivery queued", webhookDto.DeliveryResponse{}, false),
+				errorReplies(statusBadRequest, statusNotFound, statusInternal)),
+		},
+	)
+
+	return b.Document()
+}
+
+var openAPIDocument = sync.OnceValues(func() ([]byte, error) {
+	return json.Marshal(OpenAPI())
+})
+
+func (s *Server) openAPI(c *fiber.Ctx) error {
+	document, err := openAPIDocument()
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(document)
+}
+
+func (s *Server) docs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(docsPage)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/BlackRRR/Irtea-test/interfaces/http/middleware"
+	"github.com/BlackRRR/Irtea-test/pkg/openapi"
+)
+
+const specPath = "../../api/openapi.json"
+
+var update = flag.Bool("update", false, "rewrite api/openapi.json")
+
+func TestOpenAPI_CoversRoutes(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := NewServer(Config{}, logger, middleware.NewMiddleware(logger, nil), nil, nil, nil, nil, nil, nil)
+	s.setupRoutes()
+
+	var registered []string
+	for _, route := range s.app.GetRoutes(true) {
+		if route.Method == "HEAD" {
+			continue
+		}
+		path := strings.TrimSuffix(route.Path, "/")
+		registered = append(registered, route.Method+" "+openapi.PathTemplate(path))
+	}
+
+	var documented []string
+	for path, item := range OpenAPI().Paths {
+		for method := range *item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+	assert.Equal(t, registered, documented)
+}
+
+func TestOpenAPI_Golden(t *testing.T) {
+	document, err := json.MarshalIndent(OpenAPI(), "", "  ")
+	require.NoError(t, err)
+	document = append(document, '\n')
+
+	if *update {
+		require.NoError(t, os.WriteFile(specPath, document, 0o644))
+	}
+
+	golden, err := os.ReadFile(specPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(document), "api/openapi.json is stale; run go test ./interfaces/http -update")
+}
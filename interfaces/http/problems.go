@@ -0,0 +1,126 @@
+package http
+
+import (
+	"net/http"
+
+	orderDomain "github.com/BlackRRR/Irtea-test/internal/order/domain"
+	orderHandler "github.com/BlackRRR/Irtea-test/internal/order/interfaces/http"
+	productApp "github.com/BlackRRR/Irtea-test/internal/product/app"
+	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
+	productHandler "github.com/BlackRRR/Irtea-test/internal/product/interfaces/http"
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+	userHandler "github.com/BlackRRR/Irtea-test/internal/user/interfaces/http"
+	webhookDomain "github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+	webhookHandler "github.com/BlackRRR/Irtea-test/internal/webhook/interfaces/http"
+	"github.com/BlackRRR/Irtea-test/interfaces/http/middleware"
+	"github.com/BlackRRR/Irtea-test/pkg/etag"
+	"github.com/BlackRRR/Irtea-test/pkg/problem"
+)
+
+// ProblemTypeBase prefixes the error codes of the registered problem types.
+const ProblemTypeBase = "urn:irtea:problem:"
+
+// problems maps coded errors to problem details; titles come from messages.
+// Errors that are not listed become a 500 without their message.
+var problems = problem.NewRegistry(ProblemTypeBase,
+	problem.Mapping{Err: userDomain.ErrUserNotFound, Status: http.StatusNotFound},
+	problem.Mapping{Err: productDomain.ErrProductNotFound, Status: http.StatusNotFound},
+	problem.Mapping{Err: productDomain.ErrVariantNotFound, Status: http.StatusNotFound},
+	problem.Mapping{Err: productDomain.ErrImageNotFound, Status: http.StatusNotFound},
+	problem.Mapping{Err: orderDomain.ErrOrderNotFound, Status: http.StatusNotFound},
+	problem.Mapping{Err: webhookDomain.ErrSubscriptionNotFound, Status: http.StatusNotFound},
+	problem.Mapping{Err: webhookDomain.ErrDeliveryNotFound, Status: http.StatusNotFound},
+
+	// Lost optimistic-locking races. They become 412 when the client sent
+	// If-Match, see etag.ConflictStatus.
+	problem.Mapping{Err: userDomain.ErrUserVersionConflict, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrProductVersionConflict, Status: http.StatusConflict},
+	problem.Mapping{Err: orderDomain.ErrOrderVersionConflict, Status: http.StatusConflict},
+	problem.Mapping{Err: etag.ErrWeak, Status: http.StatusPreconditionFailed},
+	problem.Mapping{Err: etag.ErrMalformed, Status: http.StatusBadRequest},
+
+	problem.Mapping{Err: userDomain.ErrUserAlreadyExists, Status: http.StatusConflict},
+	problem.Mapping{Err: userDomain.ErrUserAlreadyBlocked, Status: http.StatusConflict},
+	problem.Mapping{Err: userDomain.ErrUserNotBlocked, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrDuplicateSKU, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrDuplicateVariantOptions, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrOptionsLockedByVariants, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrProductArchived, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrProductAlreadyArchived, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrProductNotArchived, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrProductHasOrders, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrTooManyImages, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrInsufficientStock, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrBackordersNotAllowed, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrBackorderLimitExceeded, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrReservationNotActive, Status: http.StatusConflict},
+	problem.Mapping{Err: productDomain.ErrReservationExpired, Status: http.StatusConflict},
+	problem.Mapping{Err: orderDomain.ErrInvalidOrderStatus, Status: http.StatusConflict},
+	problem.Mapping{Err: orderDomain.ErrOrderCannotBeModified, Status: http.StatusConflict},
+
+	problem.Mapping{Err: orderDomain.ErrCustomerNotFound, Status: http.StatusUnprocessableEntity},
+	problem.Mapping{Err: orderDomain.ErrCustomerBlocked, Status: http.StatusUnprocessableEntity},
+	problem.Mapping{Err: orderDomain.ErrOrderLimitExceeded, Status: http.StatusUnprocessableEntity},
+	problem.Mapping{Err: orderDomain.ErrUserLimitExceeded, Status: http.StatusUnprocessableEntity},
+
+	problem.Mapping{Err: userDomain.ErrInvalidCredentials, Status: http.StatusUnauthorized},
+	problem.Mapping{Err: ErrAdminUnauthorized, Status: http.StatusUnauthorized},
+	problem.Mapping{Err: ErrAdminDisabled, Status: http.StatusNotFound},
+	problem.Mapping{Err: middleware.ErrRateLimited, Status: http.StatusTooManyRequests},
+	problem.Mapping{Err: productDomain.ErrUnsupportedImageType, Status: http.StatusUnsupportedMediaType},
+	problem.Mapping{Err: productDomain.ErrImageTooLarge, Status: http.StatusRequestEntityTooLarge},
+
+	problem.Mapping{Err: userDomain.ErrUserTooYoung, Status: http.StatusBadRequest},
+	problem.Mapping{Err: userDomain.ErrInvalidPassword, Status: http.StatusBadRequest},
+	problem.Mapping{Err: userDomain.ErrFirstNameEmpty, Status: http.StatusBadRequest},
+	problem.Mapping{Err: userDomain.ErrLastNameEmpty, Status: http.StatusBadRequest},
+	problem.Mapping{Err: orderDomain.ErrEmptyOrder, Status: http.StatusBadRequest},
+	problem.Mapping{Err: orderDomain.ErrInvalidItemQuantity, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrVariantRequired, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrQuantityToAddMustBePositive, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrQuantityToAddMustBe, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrInvalidQuantity, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrInventoryQuantityCannotBeNeg, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrMoneyCannotBeNeg, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrInvalidPrice, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrProductDescCannotBeEmpty, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrVariantSKUCannotBeEmpty, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrInvalidVariantOptions, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrOptionNameCannotBeEmpty, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrOptionValuesCannotBeEmpty, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrDuplicateOptionName, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrInvalidReorderThreshold, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrInvalidBackorderLimit, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrInvalidPurchaseLimits, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productDomain.ErrInvalidImageOrder, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productApp.ErrInvalidImportMode, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productApp.ErrInvalidAlertStatus, Status: http.StatusBadRequest},
+	problem.Mapping{Err: webhookDomain.ErrInvalidWebhookURL, Status: http.StatusBadRequest},
+	problem.Mapping{Err: webhookDomain.ErrNoEventTypes, Status: http.StatusBadRequest},
+	problem.Mapping{Err: webhookDomain.ErrUnknownEventType, Status: http.StatusBadRequest},
+	problem.Mapping{Err: webhookDomain.ErrSecretTooShort, Status: http.StatusBadRequest},
+
+	// Path, header and form values rejected by the handlers.
+	problem.Mapping{Err: userHandler.ErrInvalidUserID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: orderHandler.ErrInvalidOrderID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: orderHandler.ErrInvalidUserID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: orderHandler.ErrInvalidProductID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: orderHandler.ErrInvalidVariantID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: orderHandler.ErrInvalidLastEventID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productHandler.ErrInvalidProductID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productHandler.ErrInvalidVariantID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productHandler.ErrInvalidImageID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productHandler.ErrInvalidPurchaseWindow, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productHandler.ErrImageRequired, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productHandler.ErrInvalidImageUpload, Status: http.StatusBadRequest},
+	problem.Mapping{Err: productHandler.ErrUnknownCatalogFormat, Status: http.StatusBadRequest},
+	problem.Mapping{Err: webhookHandler.ErrInvalidWebhookID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: webhookHandler.ErrInvalidDeliveryID, Status: http.StatusBadRequest},
+	problem.Mapping{Err: ErrLogLevelRequired, Status: http.StatusBadRequest},
+)
+
+var versionConflicts = []error{
+	userDomain.ErrUserVersionConflict,
+	productDomain.ErrProductVersionConflict,
+	orderDomain.ErrOrderVersionConflict,
+}
@@ -2,22 +2,60 @@ package http
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/valyala/fasthttp"
 	orderHandler "github.com/BlackRRR/Irtea-test/internal/order/interfaces/http"
 	productHandler "github.com/BlackRRR/Irtea-test/internal/product/interfaces/http"
 	userHandler "github.com/BlackRRR/Irtea-test/internal/user/interfaces/http"
+	webhookHandler "github.com/BlackRRR/Irtea-test/internal/webhook/interfaces/http"
 	"log/slog"
 	"github.com/BlackRRR/Irtea-test/interfaces/http/middleware"
+	"github.com/BlackRRR/Irtea-test/pkg/ratelimit"
+	"github.com/BlackRRR/Irtea-test/pkg/health"
+	obs "github.com/BlackRRR/Irtea-test/pkg/observability/logger"
+	"github.com/BlackRRR/Irtea-test/pkg/requestctx"
 )
 
 type Config struct {
 	Port         string        `env:"PORT" envDefault:"8080"`
 	ReadTimeout  time.Duration `env:"READ_TIMEOUT" envDefault:"10s"`
 	WriteTimeout time.Duration `env:"WRITE_TIMEOUT" envDefault:"10s"`
+	// Large enough for image uploads and catalog imports.
+	BodyLimit int `env:"BODY_LIMIT" envDefault:"16777216"`
+	// Replaces WriteTimeout for event streams. It must exceed their maximum
+	// duration.
+	StreamWriteTimeout time.Duration `env:"STREAM_WRITE_TIMEOUT" envDefault:"1h"`
+	// Bearer token of the /admin endpoints; they are off when it is empty.
+	AdminToken string `env:"ADMIN_TOKEN"`
+
+	RateLimit RateLimitConfig `envPrefix:"RATE_LIMIT_"`
+}
+
+// RateLimitConfig holds token-bucket policies written as "<limit>/<period>",
+// e.g. "5/1m", or "off".
+type RateLimitConfig struct {
+	// values: memory, postgres. Replicas only share limits with postgres.
+	Store string `env:"STORE" envDefault:"memory" validate:"oneof=memory postgres"`
+	// How often the postgres store deletes refilled buckets
+	SweepInterval time.Duration `env:"SWEEP_INTERVAL" envDefault:"5m" validate:"gt=0"`
+
+	// Registration, per IP
+	Register ratelimit.Policy `env:"REGISTER" envDefault:"5/1m"`
+	// Placing, confirming and cancelling orders, per IP
+	Orders ratelimit.Policy `env:"ORDERS" envDefault:"30/1m"`
+}
+
+// staticMount serves files from a local directory, e.g. uploaded media.
+type staticMount struct {
+	prefix string
+	root   string
 }
 
 type Server struct {
@@ -25,48 +63,83 @@ type Server struct {
 	config          Config
 	logger          *slog.Logger
 	middleware      *middleware.Middleware
+	health          *health.Registry
+	logLevels       *obs.LevelController
 	usersHandler    *userHandler.UsersHandler
 	productsHandler *productHandler.ProductsHandler
 	ordersHandler   *orderHandler.OrdersHandler
+	webhooksHandler *webhookHandler.WebhooksHandler
+	staticMounts    []staticMount
+	metrics         http.Handler
 }
 
 func NewServer(
 	config Config,
 	logger *slog.Logger,
 	middleware *middleware.Middleware,
+	health *health.Registry,
+	logLevels *obs.LevelController,
 	usersHandler *userHandler.UsersHandler,
 	productsHandler *productHandler.ProductsHandler,
 	ordersHandler *orderHandler.OrdersHandler,
+	webhooksHandler *webhookHandler.WebhooksHandler,
 ) *Server {
 	errHandler := ErrorHandler{logger: logger}
 
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  config.ReadTimeout,
 		WriteTimeout: config.WriteTimeout,
+		BodyLimit:    config.BodyLimit,
 		ErrorHandler: errHandler.Init()},
 	)
 
+	// fasthttp applies WriteTimeout to the whole response, which would cut
+	// event streams short.
+	app.Server().HeaderReceived = func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+		if strings.Contains(string(header.Peek(fiber.HeaderAccept)), "text/event-stream") {
+			return fasthttp.RequestConfig{WriteTimeout: config.StreamWriteTimeout}
+		}
+		return fasthttp.RequestConfig{}
+	}
+
 	return &Server{
 		app:             app,
 		config:          config,
 		logger:          logger,
 		middleware:      middleware,
+		health:          health,
+		logLevels:       logLevels,
 		usersHandler:    usersHandler,
 		productsHandler: productsHandler,
 		ordersHandler:   ordersHandler,
+		webhooksHandler: webhooksHandler,
 	}
 }
 
+// ServeStatic mounts a directory under prefix. Must be called before Start.
+func (s *Server) ServeStatic(prefix, root string) {
+	s.staticMounts = append(s.staticMounts, staticMount{prefix: prefix, root: root})
+}
+
+// ServeMetrics serves Prometheus metrics on /metrics. Must be called before
+// Start.
+func (s *Server) ServeMetrics(handler http.Handler) {
+	s.metrics = handler
+}
+
 func (s *Server) setupMiddleware() {
-	s.app.Use(requestid.New())
+	// The ID is stored under the requestctx key, so logs written with the
+	// request context carry it.
+	s.app.Use(requestid.New(requestid.Config{ContextKey: requestctx.RequestIDKey}))
 
 	// Use our custom recovery middleware with Sentry integration
 	s.app.Use(s.middleware.RecoveryMiddleware())
 
 	s.app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
+		AllowOrigins:  "*",
+		AllowHeaders:  "Origin, Content-Type, Accept, Accept-Language, Authorization, If-Match, Last-Event-ID",
+		AllowMethods:  "GET, POST, PUT, PATCH, DELETE, OPTIONS",
+		ExposeHeaders: "ETag, Content-Language, Retry-After, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy",
 	}))
 
 	s.app.Use(s.middleware.LoggingMiddleware())
@@ -76,12 +149,20 @@ func (s *Server) setupMiddleware() {
 func (s *Server) setupRoutes() {
 	api := s.app.Group("/v1")
 
-	api.Get("/health", s.healthCheck)
+	// Deprecated alias of /health/live
+	api.Get("/health", s.liveness)
+	api.Get("/health/live", s.liveness)
+	api.Get("/health/ready", s.readiness)
+	api.Get("/openapi.json", s.openAPI)
+	api.Get("/docs", s.docs)
 
 	{
 		users := api.Group("/users")
-		users.Post("/register", s.usersHandler.Register)
+		limitRegister := s.middleware.RateLimit("register", s.config.RateLimit.Register, middleware.KeyByIP)
+		users.Post("/register", limitRegister, s.usersHandler.Register)
 		users.Get("/:id", s.usersHandler.GetByID)
+		users.Put("/:id/block", s.usersHandler.BlockUser)
+		users.Put("/:id/unblock", s.usersHandler.UnblockUser)
 	}
 
 	products := api.Group("/products")
@@ -89,31 +170,97 @@ func (s *Server) setupRoutes() {
 	{
 		products.Post("/", s.productsHandler.CreateProduct)
 		products.Get("/", s.productsHandler.GetProducts)
+		products.Post("/import", s.productsHandler.ImportProducts)
+		products.Get("/export", s.productsHandler.ExportProducts)
 		products.Get("/:id", s.productsHandler.GetProduct)
+		products.Patch("/:id", s.productsHandler.PatchProduct)
 		products.Put("/:id/price", s.productsHandler.UpdatePrice)
 		products.Put("/:id/stock", s.productsHandler.AdjustStock)
+		products.Put("/:id/purchase-limits", s.productsHandler.SetPurchaseLimits)
+		products.Delete("/:id/purchase-limits", s.productsHandler.ClearPurchaseLimits)
+		products.Delete("/:id", s.productsHandler.DeleteProduct)
+		products.Post("/:id/restore", s.productsHandler.RestoreProduct)
+		products.Post("/:id/variants", s.productsHandler.AddVariant)
+		products.Put("/:id/variants/:variantId/price", s.productsHandler.UpdateVariantPrice)
+		products.Put("/:id/variants/:variantId/stock", s.productsHandler.AdjustVariantStock)
+		products.Post("/:id/images", s.productsHandler.UploadImage)
+		products.Put("/:id/images/order", s.productsHandler.ReorderImages)
+		products.Put("/:id/images/:imageId/primary", s.productsHandler.SetPrimaryImage)
+		products.Delete("/:id/images/:imageId", s.productsHandler.DeleteImage)
+	}
+
+	inventory := api.Group("/inventory")
+
+	{
+		inventory.Get("/alerts", s.productsHandler.ListStockAlerts)
 	}
 
 	orders := api.Group("/orders")
 
 	{
-		orders.Post("/", s.ordersHandler.PlaceOrder)
+		// Order writes share one bucket per client IP. The user_id in an order
+		// is not authenticated, so keying on it would let callers dodge the
+		// limit by changing it.
+		limitOrders := s.middleware.RateLimit("orders", s.config.RateLimit.Orders, middleware.KeyByIP)
+		orders.Post("/", limitOrders, s.ordersHandler.PlaceOrder)
 		orders.Get("/:id", s.ordersHandler.GetOrder)
-		orders.Put("/:id/confirm", s.ordersHandler.ConfirmOrder)
-		orders.Put("/:id/cancel", s.ordersHandler.CancelOrder)
+		orders.Get("/:id/events", s.ordersHandler.OrderEvents)
+		orders.Put("/:id/confirm", limitOrders, s.ordersHandler.ConfirmOrder)
+		orders.Put("/:id/cancel", limitOrders, s.ordersHandler.CancelOrder)
 		orders.Get("/users/:userId", s.ordersHandler.GetUserOrders)
+		orders.Get("/users/:userId/events", s.ordersHandler.UserOrderEvents)
+	}
+
+	webhooks := api.Group("/webhooks")
+
+	{
+		webhooks.Post("/", s.webhooksHandler.CreateSubscription)
+		webhooks.Get("/", s.webhooksHandler.GetSubscriptions)
+		webhooks.Get("/:id", s.webhooksHandler.GetSubscription)
+		webhooks.Delete("/:id", s.webhooksHandler.DeleteSubscription)
+		webhooks.Get("/:id/deliveries", s.webhooksHandler.GetDeliveries)
+		webhooks.Post("/:id/deliveries/:deliveryId/redeliver", s.webhooksHandler.Redeliver)
+	}
+
+	admin := s.app.Group("/admin", s.adminOnly)
+
+	{
+		admin.Get("/log-level", s.getLogLevel)
+		admin.Put("/log-level", s.setLogLevel)
+	}
+
+	for _, mount := range s.staticMounts {
+		// Blob keys are never reused, so the files can be cached for good.
+		s.app.Static(mount.prefix, mount.root, fiber.Static{MaxAge: 365 * 24 * 60 * 60})
 	}
 }
 
-func (s *Server) healthCheck(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{
-		"status":    "ok",
-		"timestamp": time.Now().Format(time.RFC3339),
-		"service":   "irtea-api",
+// liveness only tells that the process serves requests, so orchestrators do
+// not restart it because a dependency is down.
+func (s *Server) liveness(c *fiber.Ctx) error {
+	return c.JSON(HealthResponse{
+		Status:    "ok",
+		Timestamp: time.Now().Format(time.RFC3339),
+		Service:   "irtea-api",
 	})
 }
 
+func (s *Server) readiness(c *fiber.Ctx) error {
+	report := s.health.Ready(c.UserContext())
+	if !report.Ready() {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+
+	return c.JSON(report)
+}
+
 func (s *Server) Start() error {
+	// Registered ahead of the middleware so scrapes are not logged, traced
+	// or counted in the HTTP metrics.
+	if s.metrics != nil {
+		s.app.Get("/metrics", adaptor.HTTPHandler(s.metrics))
+	}
+
 	s.setupMiddleware()
 	s.setupRoutes()
 
@@ -123,6 +270,6 @@ func (s *Server) Start() error {
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
-	s.logger.Info("Shutting down HTTP server")
+	s.logger.InfoContext(ctx, "Shutting down HTTP server")
 	return s.app.Shutdown()
 }
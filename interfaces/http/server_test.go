@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/BlackRRR/Irtea-test/interfaces/http/middleware"
+	"github.com/BlackRRR/Irtea-test/pkg/health"
+	obs "github.com/BlackRRR/Irtea-test/pkg/observability/logger"
+	"github.com/BlackRRR/Irtea-test/pkg/validator"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestReadiness(t *testing.T) {
+	var dbErr error
+	registry := health.NewRegistry(health.Config{Timeout: time.Second})
+	registry.Register(health.Check{Name: "postgres", Checker: health.CheckerFunc(func(context.Context) error {
+		return dbErr
+	})})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := NewServer(Config{}, logger, middleware.NewMiddleware(logger, nil), registry, nil, nil, nil, nil, nil)
+	s.setupRoutes()
+
+	probe := func(path string) int {
+		resp, err := s.app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, probe("/v1/health/ready"))
+
+	dbErr = errors.New("down")
+	assert.Equal(t, fiber.StatusServiceUnavailable, probe("/v1/health/ready"))
+	assert.Equal(t, fiber.StatusOK, probe("/v1/health/live"))
+
+	dbErr = nil
+	registry.Drain()
+	assert.Equal(t, fiber.StatusServiceUnavailable, probe("/v1/health/ready"))
+}
+
+func TestAdminLogLevel(t *testing.T) {
+	validator.Init()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	levels := obs.NewLevelController(obs.InfoLevel)
+	s := NewServer(Config{AdminToken: "secret"}, logger, middleware.NewMiddleware(logger, nil), nil, levels, nil, nil, nil, nil)
+	s.setupRoutes()
+
+	call := func(method, token, body string) (int, LogLevelResponse) {
+		req := httptest.NewRequest(method, "/admin/log-level", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if token != "" {
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		}
+		resp, err := s.app.Test(req)
+		require.NoError(t, err)
+
+		var levels LogLevelResponse
+		if resp.StatusCode == fiber.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&levels))
+		}
+		return resp.StatusCode, levels
+	}
+
+	status, _ := call(fiber.MethodGet, "", "")
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+	status, _ = call(fiber.MethodGet, "wrong", "")
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+
+	status, resp := call(fiber.MethodPut, "secret", `{"logger": "order", "level": "debug", "revert_after": "15m"}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "info", resp.Level)
+	assert.Equal(t, "debug", resp.Loggers["order"].Level)
+	assert.NotEmpty(t, resp.Loggers["order"].RevertAt)
+	assert.True(t, levels.Enabled("order.events", zapcore.DebugLevel))
+
+	status, _ = call(fiber.MethodPut, "secret", `{"level": "verbose", "revert_after": "soon"}`)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	status, _ = call(fiber.MethodPut, "secret", `{}`)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, resp = call(fiber.MethodPut, "secret", `{"logger": "order"}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, resp.Loggers)
+
+	disabled := NewServer(Config{}, logger, middleware.NewMiddleware(logger, nil), nil, levels, nil, nil, nil, nil)
+	disabled.setupRoutes()
+	r, err := disabled.app.Test(httptest.NewRequest(fiber.MethodGet, "/admin/log-level", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, r.StatusCode)
+}
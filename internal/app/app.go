@@ -5,18 +5,35 @@ import (
 	obs "github.com/BlackRRR/Irtea-test/pkg/observability/logger"
 	"log"
 	"github.com/BlackRRR/Irtea-test/interfaces/http"
+	"github.com/BlackRRR/Irtea-test/interfaces/grpc"
 	uHandler "github.com/BlackRRR/Irtea-test/internal/user/interfaces/http"
+	uGrpc "github.com/BlackRRR/Irtea-test/internal/user/interfaces/grpc"
 	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+	"github.com/BlackRRR/Irtea-test/infrastructure/blob"
+	"strings"
 	uRepo "github.com/BlackRRR/Irtea-test/internal/user/infra/postgres"
 	"github.com/BlackRRR/Irtea-test/internal/user/infra/security"
 	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
 	pRepo "github.com/BlackRRR/Irtea-test/internal/product/infra/postgres"
 	pHandler "github.com/BlackRRR/Irtea-test/internal/product/interfaces/http"
+	pGrpc "github.com/BlackRRR/Irtea-test/internal/product/interfaces/grpc"
+	"github.com/BlackRRR/Irtea-test/internal/product/infra/notify"
+	"github.com/BlackRRR/Irtea-test/pkg/schedule"
 
 	oService "github.com/BlackRRR/Irtea-test/internal/order/app"
 	oRepo "github.com/BlackRRR/Irtea-test/internal/order/infra/postgres"
+	oCustomer "github.com/BlackRRR/Irtea-test/internal/order/infra/customer"
+	oInventory "github.com/BlackRRR/Irtea-test/internal/order/infra/inventory"
 	oHandler "github.com/BlackRRR/Irtea-test/internal/order/interfaces/http"
+	oGrpc "github.com/BlackRRR/Irtea-test/internal/order/interfaces/grpc"
 	uService "github.com/BlackRRR/Irtea-test/internal/user/app"
+	outboxService "github.com/BlackRRR/Irtea-test/internal/outbox/app"
+	outboxRepo "github.com/BlackRRR/Irtea-test/internal/outbox/infra/postgres"
+	outboxPublisher "github.com/BlackRRR/Irtea-test/internal/outbox/infra/publisher"
+	wService "github.com/BlackRRR/Irtea-test/internal/webhook/app"
+	wRepo "github.com/BlackRRR/Irtea-test/internal/webhook/infra/postgres"
+	wSender "github.com/BlackRRR/Irtea-test/internal/webhook/infra/sender"
+	wHandler "github.com/BlackRRR/Irtea-test/internal/webhook/interfaces/http"
 	"os/signal"
 	"syscall"
 	"log/slog"
@@ -25,11 +42,21 @@ import (
 	"github.com/BlackRRR/Irtea-test/interfaces/http/middleware"
 	sentryPkg "github.com/BlackRRR/Irtea-test/pkg/observability/sentry"
 	"github.com/BlackRRR/Irtea-test/pkg/validator"
+	"github.com/BlackRRR/Irtea-test/pkg/ratelimit"
+	"github.com/BlackRRR/Irtea-test/pkg/health"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"github.com/BlackRRR/Irtea-test/pkg/observability/metrics"
 )
 
 type App struct {
-	http   *http.Server
-	logger *slog.Logger
+	http       *http.Server
+	grpc       *grpc.Server
+	logger     *slog.Logger
+	workers    []func(ctx context.Context)
+	health     *health.Registry
+	drainDelay time.Duration
+	tp         *sdktrace.TracerProvider
+	metrics    *metrics.Exporter
 }
 
 func InternalInit() {
@@ -37,19 +64,31 @@ func InternalInit() {
 }
 
 func Init(ctx context.Context, cfg *Config) App {
-	logger, err := obs.NewZapLogger(cfg.LogLevel, cfg.AppEnv, cfg.LogFormat)
+	logger, logLevels, err := obs.NewZapLogger(cfg.LogLevel, cfg.AppEnv, cfg.LogFormat)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	pgxConfig, err := postgres.NewPgxPoolConfig(cfg.Postgres)
+	// Loggers named by bounded context, so each can be given its own level
+	// through the admin API.
+	var (
+		dbLogger      = obs.Named(logger, "postgres")
+		httpLogger    = obs.Named(logger, "http")
+		grpcLogger    = obs.Named(logger, "grpc")
+		outboxLogger  = obs.Named(logger, "outbox")
+		webhookLogger = obs.Named(logger, "webhook")
+		productLogger = obs.Named(logger, "product")
+		orderLogger   = obs.Named(logger, "order")
+	)
+
+	pgxConfig, err := postgres.NewPgxPoolConfig(cfg.Postgres, dbLogger)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	dbOpts := postgres.NewDBOptions().SetRetryInterval(time.Second * 2)
 
-	db, err := postgres.Connect(ctx, logger, pgxConfig, dbOpts)
+	db, err := postgres.Connect(ctx, dbLogger, pgxConfig, dbOpts)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -59,18 +98,54 @@ func Init(ctx context.Context, cfg *Config) App {
 	}
 
 	tp := tracer.InitTracer(cfg.AppName, cfg.OtelURL)
-	defer func() {
-		if tp != nil {
-			if err = tp.Shutdown(ctx); err != nil {
-				logger.ErrorContext(ctx, "Error shutting down tracer provider", slog.Any("error", err))
-			}
+
+	metricsExporter, err := metrics.NewExporter(cfg.AppName, cfg.Metrics)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := db.RegisterMetrics(); err != nil {
+		log.Fatal(err)
+	}
+
+	checks := health.NewRegistry(cfg.Health)
+	checks.Register(
+		health.Check{Name: "postgres", Checker: health.CheckerFunc(db.Health)},
+		health.Check{Name: "migrations", Checker: health.CheckerFunc(db.CheckSchema)},
+	)
+	if tp != nil {
+		// Losing traces must not take the service out of rotation.
+		checks.Register(health.Check{Name: "otel-exporter", Checker: health.CheckerFunc(tracer.Health), Optional: true})
+	}
+
+	// every runs fn on a schedule and reports the worker stuck after three
+	// missed runs.
+	every := func(name string, interval time.Duration, fn func(ctx context.Context)) func(ctx context.Context) {
+		heartbeat := health.NewHeartbeat(3 * interval)
+		checks.Register(health.Check{Name: "worker:" + name, Checker: heartbeat, Optional: true})
+
+		return func(ctx context.Context) {
+			schedule.Every(ctx, interval, func(ctx context.Context) {
+				fn(ctx)
+				heartbeat.Beat()
+			})
 		}
-		// Close Sentry
-		sentryPkg.Close()
-	}()
+	}
 
 	txManager := postgres.NewTxManager(db.Pool())
 
+	// webhooks
+	webhookService := wService.NewWebhookService(
+		wRepo.NewSubscriptionRepo(db.Pool()),
+		wRepo.NewDeliveryRepo(db.Pool()),
+		wSender.NewHTTPSender(cfg.Webhooks.Timeout),
+		txManager,
+		cfg.Webhooks,
+	)
+	webhookHandler := wHandler.NewWebhooksHandler(webhookService)
+
+	// outbox
+	outboxStore := outboxRepo.NewStore(db.Pool())
+
 	// user
 	userRepo := uRepo.NewUserRepo(db.Pool())
 	userService := uService.NewUserService(userRepo, security.NewPasswordHasher(), txManager)
@@ -78,19 +153,142 @@ func Init(ctx context.Context, cfg *Config) App {
 
 	// Product
 	productRepo := pRepo.NewProductRepo(db.Pool())
-	productService := pService.NewProductService(productRepo, txManager)
-	productHandler := pHandler.NewProductsHandler(productService)
+	notifier, err := notify.New(cfg.Notifier, productLogger)
+	if err != nil {
+		log.Fatal(err)
+	}
+	stockAlertRepo := pRepo.NewStockAlertRepo(db.Pool())
+	inventoryService := pService.NewInventoryService(productRepo, stockAlertRepo, notifier, outboxStore)
+	reservationRepo := pRepo.NewReservationRepo(db.Pool())
+	reservationService := pService.NewReservationService(reservationRepo, productRepo, txManager, inventoryService, cfg.StockReservation)
+	productService := pService.NewProductService(productRepo, txManager, reservationService, outboxStore, productLogger)
+
+	blobStore, err := blob.New(cfg.Blob)
+	if err != nil {
+		log.Fatal(err)
+	}
+	imageService := pService.NewImageService(productRepo, txManager, blobStore, cfg.ProductImages)
+	productHandler := pHandler.NewProductsHandler(productService, imageService, inventoryService, productLogger)
+
+	// outbox relay, wired last since stock alerts are sent through it
+	publisher := outboxPublisher.NewFanout(
+		outboxPublisher.NewLogPublisher(outboxLogger),
+		outboxPublisher.NewWebhookPublisher(webhookService),
+		outboxPublisher.NewStockAlertPublisher(inventoryService),
+	)
+	relay := outboxService.NewRelay(outboxStore, publisher, txManager, cfg.Outbox)
 
 	// order
 	orderRepo := oRepo.NewOrderRepo(db.Pool())
-	orderService := oService.NewOrderService(orderRepo, productRepo, txManager)
-	orderHandler := oHandler.NewOrdersHandler(orderService)
+	stockReserver := oInventory.NewStockReserver(reservationService)
+	userDirectory := oCustomer.NewUserDirectory(userService)
+	statusLog := oRepo.NewStatusLog(db.Pool())
+	statusHub := oService.NewStatusHub()
+	orderService := oService.NewOrderService(orderRepo, productRepo, stockReserver, userDirectory, outboxStore, statusLog, txManager)
+	statusStream := oService.NewStatusStreamService(statusLog, statusHub)
+	orderHandler := oHandler.NewOrdersHandler(orderService, statusStream, cfg.OrderEvents)
+
+	// Replicas only share limits through postgres.
+	var rateLimits ratelimit.Store = ratelimit.NewMemoryStore()
+	var rateLimitStore *postgres.RateLimitStore
+	if cfg.HttpServer.RateLimit.Store == "postgres" {
+		rateLimitStore = postgres.NewRateLimitStore(db.Pool())
+		rateLimits = rateLimitStore
+	}
 
-	mw := middleware.NewMiddleware(logger)
+	mw := middleware.NewMiddleware(httpLogger, rateLimits)
 
-	server := http.NewServer(cfg.HttpServer, logger, mw, userHandler, productHandler, orderHandler)
+	server := http.NewServer(cfg.HttpServer, httpLogger, mw, checks, logLevels, userHandler, productHandler, orderHandler, webhookHandler)
 
-	return App{http: server, logger: logger}
+	grpcServer := grpc.NewServer(
+		cfg.GrpcServer,
+		grpcLogger,
+		uGrpc.NewUsersServer(userService),
+		pGrpc.NewProductsServer(productService),
+		oGrpc.NewOrdersServer(orderService, statusStream),
+	)
+
+	server.ServeMetrics(metricsExporter.Handler())
+
+	if localStore, ok := blobStore.(*blob.LocalStore); ok && strings.HasPrefix(localStore.PublicURL(), "/") {
+		server.ServeStatic(localStore.PublicURL(), localStore.Root())
+	}
+
+	workers := []func(ctx context.Context){
+		func(ctx context.Context) {
+			statusLog.Listen(ctx, statusHub, orderLogger)
+		},
+		every("reservation-expiry", cfg.StockReservationSweepInterval, func(ctx context.Context) {
+			expired, err := orderService.ExpireReservations(ctx)
+			if err != nil {
+				orderLogger.ErrorContext(ctx, "Failed to expire stock reservations", slog.Any("error", err))
+			}
+			if expired > 0 {
+				orderLogger.InfoContext(ctx, "Cancelled orders with expired stock reservations", slog.Int("count", expired))
+			}
+		}),
+		every("backorder-allocation", cfg.StockReservationSweepInterval, func(ctx context.Context) {
+			allocated, err := reservationService.AllocateWaiting(ctx)
+			if err != nil {
+				productLogger.ErrorContext(ctx, "Failed to allocate stock to backorders", slog.Any("error", err))
+			}
+			if allocated > 0 {
+				productLogger.InfoContext(ctx, "Allocated stock to backorders and pre-orders", slog.Int("count", allocated))
+			}
+		}),
+		every("outbox", cfg.Outbox.PollInterval, func(ctx context.Context) {
+			delivered, err := relay.Drain(ctx)
+			if err != nil {
+				outboxLogger.ErrorContext(ctx, "Failed to relay outbox messages", slog.Any("error", err))
+			}
+			if delivered > 0 {
+				outboxLogger.DebugContext(ctx, "Relayed outbox messages", slog.Int("count", delivered))
+			}
+		}),
+		every("webhooks", cfg.Webhooks.PollInterval, func(ctx context.Context) {
+			delivered, err := webhookService.DeliverDue(ctx)
+			if err != nil {
+				webhookLogger.ErrorContext(ctx, "Failed to deliver webhooks", slog.Any("error", err))
+			}
+			if delivered > 0 {
+				webhookLogger.DebugContext(ctx, "Delivered webhooks", slog.Int("count", delivered))
+			}
+		}),
+	}
+
+	if cfg.InventoryDigestAt != "" {
+		digestAt, err := schedule.ParseTimeOfDay(cfg.InventoryDigestAt)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		workers = append(workers, func(ctx context.Context) {
+			schedule.Daily(ctx, digestAt, func(ctx context.Context) {
+				if err := inventoryService.SendDigest(ctx); err != nil {
+					productLogger.ErrorContext(ctx, "Failed to send low-stock digest", slog.Any("error", err))
+				}
+			})
+		})
+	}
+
+	if rateLimitStore != nil {
+		workers = append(workers, every("rate-limit-sweep", cfg.HttpServer.RateLimit.SweepInterval, func(ctx context.Context) {
+			if _, err := rateLimitStore.Sweep(ctx); err != nil {
+				httpLogger.ErrorContext(ctx, "Failed to sweep rate limit buckets", slog.Any("error", err))
+			}
+		}))
+	}
+
+	return App{
+		http:       server,
+		grpc:       grpcServer,
+		logger:     logger,
+		workers:    workers,
+		health:     checks,
+		drainDelay: cfg.Health.DrainDelay,
+		tp:         tp,
+		metrics:    metricsExporter,
+	}
 }
 
 func (a App) Run(ctx context.Context) {
@@ -113,8 +311,20 @@ func (a App) Run(ctx context.Context) {
 		}
 	}()
 
+	go func() {
+		if err := a.grpc.Start(); err != nil {
+			a.logger.ErrorContext(ctx, "Error starting gRPC server:", slog.Any("error", err))
+			return
+		}
+	}()
+
 	a.logger.InfoContext(ctx, "Server started successfully")
 
+	// Background workers stop with ctx.
+	for _, worker := range a.workers {
+		go worker(ctx)
+	}
+
 	// Graceful shutdown.
 	go func() {
 		select {
@@ -128,11 +338,29 @@ func (a App) Run(ctx context.Context) {
 	// Block until we receive our signal
 	a.logger.InfoContext(ctx, "Shutting down server...")
 
-	// Shutdown server gracefully
+	// Fail readiness first so load balancers stop sending new requests.
+	a.health.Drain()
+	time.Sleep(a.drainDelay)
+
+	// Shutdown servers gracefully
+	if err := a.grpc.Shutdown(ctx); err != nil {
+		a.logger.ErrorContext(ctx, "Error shutting down gRPC server", slog.Any("error", err))
+	}
+
 	if err := a.http.Shutdown(ctx); err != nil {
 		a.logger.ErrorContext(ctx, "Error shutting down server", slog.Any("error", err))
-		return
 	}
 
+	// ctx is already cancelled; flushing spans needs a fresh one.
+	if a.tp != nil {
+		if err := a.tp.Shutdown(context.Background()); err != nil {
+			a.logger.ErrorContext(ctx, "Error shutting down tracer provider", slog.Any("error", err))
+		}
+	}
+	if err := a.metrics.Shutdown(context.Background()); err != nil {
+		a.logger.ErrorContext(ctx, "Error shutting down meter provider", slog.Any("error", err))
+	}
+	sentryPkg.Close()
+
 	a.logger.InfoContext(ctx, "Server stopped")
 }
@@ -1,18 +1,30 @@
 package app
 
 import (
+	"time"
+
 	"github.com/BlackRRR/Irtea-test/pkg/environment"
 	"github.com/BlackRRR/Irtea-test/pkg/observability/logger"
 	"github.com/caarlos0/env/v11"
 	"github.com/go-playground/validator/v10"
 	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
 	"github.com/BlackRRR/Irtea-test/interfaces/http"
+	"github.com/BlackRRR/Irtea-test/interfaces/grpc"
+	"github.com/BlackRRR/Irtea-test/infrastructure/blob"
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+	oService "github.com/BlackRRR/Irtea-test/internal/order/app"
+	"github.com/BlackRRR/Irtea-test/internal/product/infra/notify"
+	outboxService "github.com/BlackRRR/Irtea-test/internal/outbox/app"
+	wService "github.com/BlackRRR/Irtea-test/internal/webhook/app"
+	"github.com/BlackRRR/Irtea-test/pkg/health"
+	"github.com/BlackRRR/Irtea-test/pkg/observability/metrics"
 )
 
 type Config struct {
 	AppName string `env:"APP_NAME" envDefault:"IrteaTest"`
 
 	HttpServer http.Config `envPrefix:"HTTP_SERVER_" validate:"required"`
+	GrpcServer grpc.Config `envPrefix:"GRPC_SERVER_" validate:"required"`
 
 	// App mode: local, develop, test, production
 	AppEnv environment.AppEnv `env:"APP_ENV" envDefault:"develop" validate:"required"`
@@ -23,8 +35,36 @@ type Config struct {
 
 	Postgres postgres.Config `envPrefix:"DB_CONFIG_"`
 
+	// Storage for product images
+	Blob blob.Config `envPrefix:"BLOB_"`
+
+	ProductImages pService.ImageConfig `envPrefix:"PRODUCT_IMAGES_"`
+
+	// Where low-stock alerts and digests are sent
+	Notifier notify.Config `envPrefix:"NOTIFIER_"`
+	// Daily low-stock digest time, HH:MM in server local time. Empty disables it.
+	InventoryDigestAt string `env:"INVENTORY_DIGEST_AT" envDefault:"08:00"`
+
+	// How long placed orders hold their stock before they are cancelled
+	StockReservation pService.ReservationConfig `envPrefix:"STOCK_RESERVATION_"`
+	// Server-sent event streams of order status changes
+	OrderEvents oService.StatusStreamConfig `envPrefix:"ORDER_EVENTS_"`
+	// How often expired reservations are swept
+	StockReservationSweepInterval time.Duration `env:"STOCK_RESERVATION_SWEEP_INTERVAL" envDefault:"1m" validate:"gt=0"`
+
+	// Delivery of domain events from the outbox table
+	Outbox outboxService.RelayConfig `envPrefix:"OUTBOX_"`
+	// Delivery of webhooks to partner endpoints
+	Webhooks wService.DeliveryConfig `envPrefix:"WEBHOOKS_"`
+
+	// Readiness checks of dependencies and workers
+	Health health.Config `envPrefix:"HEALTH_"`
+
 	OtelURL string `env:"OTEL_URL"`
 
+	// Prometheus metrics on /metrics
+	Metrics metrics.Config `envPrefix:"METRICS_"`
+
 	// Sentry DSN (optional)
 	SentryDSN string `env:"SENTRY_DSN"`
 }
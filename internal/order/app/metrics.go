@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/observability/metrics"
+)
+
+const (
+	cancelReasonCustomer = "customer"
+	cancelReasonExpired  = "expired"
+)
+
+var (
+	ordersPlaced    metric.Int64Counter
+	ordersCancelled metric.Int64Counter
+	// revenue counts confirmed orders and refunds the confirmed ones that
+	// are cancelled later, so net revenue is their difference.
+	revenue metric.Float64Counter
+	refunds metric.Float64Counter
+)
+
+func init() {
+	meter := otel.Meter("github.com/BlackRRR/Irtea-test/internal/order/app")
+
+	var err error
+	ordersPlaced, err = meter.Int64Counter("orders.placed", metric.WithDescription("Orders placed"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	ordersCancelled, err = meter.Int64Counter("orders.cancelled", metric.WithDescription("Orders cancelled, by reason"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	revenue, err = meter.Float64Counter("orders.revenue", metric.WithDescription("Total price of confirmed orders"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	refunds, err = meter.Float64Counter("orders.refunds", metric.WithDescription("Total price of cancelled confirmed orders"))
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+func recordConfirmed(ctx context.Context, order *domain.Order) {
+	revenue.Add(ctx, order.TotalPrice.Amount().InexactFloat64(), metric.WithAttributes(metrics.Currency()))
+}
+
+func recordCancelled(ctx context.Context, order *domain.Order, previous domain.OrderStatus, reason string) {
+	ordersCancelled.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+
+	if previous == domain.OrderStatusConfirmed {
+		refunds.Add(ctx, order.TotalPrice.Amount().InexactFloat64(), metric.WithAttributes(metrics.Currency()))
+	}
+}
@@ -1,16 +1,31 @@
 package app
 
 import (
+	"time"
+
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
 	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
 	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
 )
 
 type OrderItemInput struct {
-	ProductID productDomain.ProductID `json:"product_id"`
-	Quantity  int                     `json:"quantity"`
+	ProductID productDomain.ProductID  `json:"product_id"`
+	VariantID *productDomain.VariantID `json:"variant_id"`
+	Quantity  int                      `json:"quantity"`
 }
 
 type PlaceOrderInput struct {
 	UserID userDomain.UserID `json:"user_id"`
 	Items  []OrderItemInput  `json:"items"`
 }
+
+type UpdateOrderStatusInput struct {
+	OrderID         domain.OrderID `json:"order_id"`
+	ExpectedVersion *int           `json:"expected_version"`
+}
+
+// StockAllocation tells how a reserved order line will be fulfilled.
+type StockAllocation struct {
+	Fulfillment domain.Fulfillment
+	ExpectedAt  *time.Time
+}
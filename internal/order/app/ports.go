@@ -2,10 +2,12 @@ package app
 
 import (
 	"context"
+	"time"
 
 	"github.com/BlackRRR/Irtea-test/internal/order/domain"
 	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
 	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
 )
 
 type OrderRepo interface {
@@ -14,11 +16,59 @@ type OrderRepo interface {
 	GetByUserID(ctx context.Context, userID userDomain.UserID, limit, offset int) ([]*domain.Order, error)
 	Update(ctx context.Context, order *domain.Order) error
 	Delete(ctx context.Context, id domain.OrderID) error
+	// LockAndSumPurchases serializes the orders of a user until the
+	// transaction in ctx ends and returns the units of the product ordered
+	// since the given time. It fails outside a transaction.
+	LockAndSumPurchases(
+		ctx context.Context,
+		userID userDomain.UserID,
+		productID productDomain.ProductID,
+		since time.Time,
+	) (int, error)
 }
 
 type ProductRepo interface {
 	GetByID(ctx context.Context, id productDomain.ProductID) (*productDomain.Product, error)
-	ReserveStock(ctx context.Context, id productDomain.ProductID, quantity int) error
+}
+
+// UserDirectory checks customers against the user context. EnsureCanOrder
+// fails with ErrCustomerNotFound for unknown or deleted users and with
+// ErrCustomerBlocked for blocked ones.
+type UserDirectory interface {
+	EnsureCanOrder(ctx context.Context, userID userDomain.UserID) error
+}
+
+// Outbox stores domain events in the caller's transaction for later
+// delivery.
+type Outbox interface {
+	Save(ctx context.Context, events ...event.Event) error
+}
+
+// StatusLog keeps the history of order status changes and announces every
+// appended change to all replicas once the transaction commits.
+type StatusLog interface {
+	Append(ctx context.Context, change *domain.StatusChange) error
+	// After returns up to limit changes matching filter with an ID above
+	// afterID, oldest first.
+	After(ctx context.Context, filter StatusFilter, afterID int64, limit int) ([]*domain.StatusChange, error)
+}
+
+// StockReserver holds stock for pending orders, or queues backorders and
+// pre-orders until stock arrives. Reservations are committed when the order
+// is confirmed, released when it is cancelled and expire when it is left
+// pending for too long.
+type StockReserver interface {
+	Reserve(
+		ctx context.Context,
+		orderID domain.OrderID,
+		productID productDomain.ProductID,
+		variantID *productDomain.VariantID,
+		quantity int,
+	) (*StockAllocation, error)
+	Commit(ctx context.Context, orderID domain.OrderID) error
+	Release(ctx context.Context, orderID domain.OrderID) error
+	Expire(ctx context.Context, orderID domain.OrderID) error
+	ExpiredOrders(ctx context.Context, limit int) ([]domain.OrderID, error)
 }
 
 type TxManager interface {
@@ -2,41 +2,93 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/BlackRRR/Irtea-test/internal/order/domain"
 	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
 	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
 )
 
+const expireBatchSize = 100
+
 type OrderService struct {
-	orderRepo   OrderRepo
-	productRepo ProductRepo
-	txManager   TxManager
+	orderRepo     OrderRepo
+	productRepo   ProductRepo
+	stockReserver StockReserver
+	userDirectory UserDirectory
+	outbox        Outbox
+	statusLog     StatusLog
+	txManager     TxManager
 }
 
-func NewOrderService(orderRepo OrderRepo, productRepo ProductRepo, txManager TxManager) *OrderService {
+func NewOrderService(
+	orderRepo OrderRepo,
+	productRepo ProductRepo,
+	stockReserver StockReserver,
+	userDirectory UserDirectory,
+	outbox Outbox,
+	statusLog StatusLog,
+	txManager TxManager,
+) *OrderService {
 	return &OrderService{
-		orderRepo:   orderRepo,
-		productRepo: productRepo,
-		txManager:   txManager,
+		orderRepo:     orderRepo,
+		productRepo:   productRepo,
+		stockReserver: stockReserver,
+		userDirectory: userDirectory,
+		outbox:        outbox,
+		statusLog:     statusLog,
+		txManager:     txManager,
 	}
 }
 
 func (s *OrderService) PlaceOrder(ctx context.Context, input PlaceOrderInput) (*domain.Order, error) {
+	// Checked before any stock is reserved for the order.
+	if err := s.userDirectory.EnsureCanOrder(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+
 	var createdOrder *domain.Order
 
 	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
 		orderID := domain.NewOrderID()
 		orderItems := make([]domain.OrderItem, 0, len(input.Items))
 
+		products := make(map[productDomain.ProductID]*productDomain.Product, len(input.Items))
 		for _, itemInput := range input.Items {
 			product, err := s.productRepo.GetByID(txCtx, itemInput.ProductID)
 			if err != nil {
 				return err
 			}
 
-			if !product.IsAvailable(itemInput.Quantity) {
-				return productDomain.ErrInsufficientStock
+			if product.IsArchived() {
+				return productDomain.ErrProductArchived
+			}
+
+			products[product.ID] = product
+		}
+
+		err := s.checkPurchaseLimits(txCtx, input.UserID, input.Items, products)
+		if err != nil {
+			return err
+		}
+
+		for _, itemInput := range input.Items {
+			product := products[itemInput.ProductID]
+
+			if itemInput.VariantID != nil {
+				orderItem, err := s.placeVariantItem(txCtx, orderID, product, *itemInput.VariantID, itemInput.Quantity)
+				if err != nil {
+					return err
+				}
+
+				orderItems = append(orderItems, *orderItem)
+				continue
+			}
+
+			if product.HasVariants() {
+				return productDomain.ErrVariantRequired
 			}
 
 			orderItem, err := domain.NewOrderItem(
@@ -50,26 +102,29 @@ func (s *OrderService) PlaceOrder(ctx context.Context, input PlaceOrderInput) (*
 				return err
 			}
 
-			orderItems = append(orderItems, *orderItem)
-
-			err = s.productRepo.ReserveStock(txCtx, itemInput.ProductID, itemInput.Quantity)
+			err = s.reserve(txCtx, orderItem)
 			if err != nil {
 				return err
 			}
+
+			orderItems = append(orderItems, *orderItem)
 		}
 
-		order, err := domain.NewOrder(input.UserID, orderItems)
+		order, err := domain.NewOrder(orderID, input.UserID, orderItems)
 		if err != nil {
 			return err
 		}
 
-		order.ID = orderID
-
 		err = s.orderRepo.Create(txCtx, order)
 		if err != nil {
 			return err
 		}
 
+		err = s.outbox.Save(txCtx, order.PullEvents()...)
+		if err != nil {
+			return err
+		}
+
 		createdOrder = order
 		return nil
 	})
@@ -78,9 +133,90 @@ func (s *OrderService) PlaceOrder(ctx context.Context, input PlaceOrderInput) (*
 		return nil, err
 	}
 
+	ordersPlaced.Add(ctx, 1)
+
 	return createdOrder, nil
 }
 
+func (s *OrderService) placeVariantItem(
+	ctx context.Context,
+	orderID domain.OrderID,
+	product *productDomain.Product,
+	variantID productDomain.VariantID,
+	quantity int,
+) (*domain.OrderItem, error) {
+	variant, err := product.Variant(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderItem, err := domain.NewOrderItem(orderID, product.ID, product.Description, variant.Price, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	orderItem.AttachVariant(variant)
+
+	err = s.reserve(ctx, orderItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return orderItem, nil
+}
+
+// checkPurchaseLimits enforces the purchase limits of every ordered product
+// against the whole order and the user's recent orders.
+func (s *OrderService) checkPurchaseLimits(
+	ctx context.Context,
+	userID userDomain.UserID,
+	items []OrderItemInput,
+	products map[productDomain.ProductID]*productDomain.Product,
+) error {
+	ordered := make(map[productDomain.ProductID]int, len(products))
+	for _, item := range items {
+		ordered[item.ProductID] += item.Quantity
+	}
+
+	now := time.Now()
+	for productID, quantity := range ordered {
+		limits := products[productID].PurchaseLimits
+		if !limits.AllowsOrder(quantity) {
+			return fmt.Errorf("%w: product %s", domain.ErrOrderLimitExceeded, productID)
+		}
+
+		if limits.MaxPerUser == nil {
+			continue
+		}
+
+		purchased, err := s.orderRepo.LockAndSumPurchases(ctx, userID, productID, now.Add(-limits.Window))
+		if err != nil {
+			return err
+		}
+
+		if !limits.AllowsUser(purchased, quantity) {
+			return fmt.Errorf("%w: product %s", domain.ErrUserLimitExceeded, productID)
+		}
+	}
+
+	return nil
+}
+
+// reserve takes stock for the line, or flags it as a backorder or pre-order
+// when the product context queues it instead.
+func (s *OrderService) reserve(ctx context.Context, item *domain.OrderItem) error {
+	allocation, err := s.stockReserver.Reserve(ctx, item.OrderID, item.ProductID, item.VariantID, item.Quantity)
+	if err != nil {
+		return err
+	}
+
+	if allocation.Fulfillment != domain.FulfillmentInStock {
+		item.MarkWaiting(allocation.Fulfillment, allocation.ExpectedAt)
+	}
+
+	return nil
+}
+
 func (s *OrderService) GetOrder(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
 	return s.orderRepo.GetByID(ctx, id)
 }
@@ -89,15 +225,21 @@ func (s *OrderService) GetUserOrders(ctx context.Context, userID userDomain.User
 	return s.orderRepo.GetByUserID(ctx, userID, limit, offset)
 }
 
-func (s *OrderService) ConfirmOrder(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+func (s *OrderService) ConfirmOrder(ctx context.Context, input UpdateOrderStatusInput) (*domain.Order, error) {
 	var updatedOrder *domain.Order
 
 	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
-		order, err := s.orderRepo.GetByID(txCtx, id)
+		order, err := s.orderRepo.GetByID(txCtx, input.OrderID)
+		if err != nil {
+			return err
+		}
+
+		err = order.CheckVersion(input.ExpectedVersion)
 		if err != nil {
 			return err
 		}
 
+		previous := order.Status
 		err = order.Confirm()
 		if err != nil {
 			return err
@@ -108,6 +250,21 @@ func (s *OrderService) ConfirmOrder(ctx context.Context, id domain.OrderID) (*do
 			return err
 		}
 
+		err = s.outbox.Save(txCtx, order.PullEvents()...)
+		if err != nil {
+			return err
+		}
+
+		err = s.statusLog.Append(txCtx, domain.NewStatusChange(order, previous))
+		if err != nil {
+			return err
+		}
+
+		err = s.stockReserver.Commit(txCtx, order.ID)
+		if err != nil {
+			return err
+		}
+
 		updatedOrder = order
 		return nil
 	})
@@ -116,18 +273,27 @@ func (s *OrderService) ConfirmOrder(ctx context.Context, id domain.OrderID) (*do
 		return nil, err
 	}
 
+	recordConfirmed(ctx, updatedOrder)
+
 	return updatedOrder, nil
 }
 
-func (s *OrderService) CancelOrder(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+func (s *OrderService) CancelOrder(ctx context.Context, input UpdateOrderStatusInput) (*domain.Order, error) {
 	var cancelledOrder *domain.Order
+	var previous domain.OrderStatus
 
 	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
-		order, err := s.orderRepo.GetByID(txCtx, id)
+		order, err := s.orderRepo.GetByID(txCtx, input.OrderID)
 		if err != nil {
 			return err
 		}
 
+		err = order.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		previous = order.Status
 		err = order.Cancel()
 		if err != nil {
 			return err
@@ -138,6 +304,21 @@ func (s *OrderService) CancelOrder(ctx context.Context, id domain.OrderID) (*dom
 			return err
 		}
 
+		err = s.outbox.Save(txCtx, order.PullEvents()...)
+		if err != nil {
+			return err
+		}
+
+		err = s.statusLog.Append(txCtx, domain.NewStatusChange(order, previous))
+		if err != nil {
+			return err
+		}
+
+		err = s.stockReserver.Release(txCtx, order.ID)
+		if err != nil {
+			return err
+		}
+
 		cancelledOrder = order
 		return nil
 	})
@@ -146,5 +327,65 @@ func (s *OrderService) CancelOrder(ctx context.Context, id domain.OrderID) (*dom
 		return nil, err
 	}
 
+	recordCancelled(ctx, cancelledOrder, previous, cancelReasonCustomer)
+
 	return cancelledOrder, nil
 }
+
+// ExpireReservations cancels pending orders whose stock reservations ran
+// out and releases the stock. Each order is handled in its own transaction;
+// failures are collected and do not stop the sweep.
+func (s *OrderService) ExpireReservations(ctx context.Context) (int, error) {
+	orderIDs, err := s.stockReserver.ExpiredOrders(ctx, expireBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var expired int
+	var errs []error
+	for _, orderID := range orderIDs {
+		var cancelled *domain.Order
+		err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+			order, err := s.orderRepo.GetByID(txCtx, orderID)
+			if errors.Is(err, domain.ErrOrderNotFound) {
+				return s.stockReserver.Expire(txCtx, orderID)
+			}
+			if err != nil {
+				return err
+			}
+
+			if order.Status == domain.OrderStatusPending {
+				if err := order.Cancel(); err != nil {
+					return err
+				}
+
+				if err := s.orderRepo.Update(txCtx, order); err != nil {
+					return err
+				}
+
+				if err := s.outbox.Save(txCtx, order.PullEvents()...); err != nil {
+					return err
+				}
+
+				change := domain.NewStatusChange(order, domain.OrderStatusPending)
+				if err := s.statusLog.Append(txCtx, change); err != nil {
+					return err
+				}
+				cancelled = order
+			}
+
+			return s.stockReserver.Expire(txCtx, orderID)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", orderID, err))
+			continue
+		}
+
+		if cancelled != nil {
+			recordCancelled(ctx, cancelled, domain.OrderStatusPending, cancelReasonExpired)
+		}
+		expired++
+	}
+
+	return expired, errors.Join(errs...)
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -10,6 +11,7 @@ import (
 	"github.com/BlackRRR/Irtea-test/internal/order/domain"
 	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
 	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
 )
 
 type MockOrderRepo struct {
@@ -47,6 +49,16 @@ func (m *MockOrderRepo) Delete(ctx context.Context, id domain.OrderID) error {
 	return args.Error(0)
 }
 
+func (m *MockOrderRepo) LockAndSumPurchases(
+	ctx context.Context,
+	userID userDomain.UserID,
+	productID productDomain.ProductID,
+	since time.Time,
+) (int, error) {
+	args := m.Called(ctx, userID, productID, since)
+	return args.Int(0), args.Error(1)
+}
+
 type MockProductRepo struct {
 	mock.Mock
 }
@@ -59,11 +71,102 @@ func (m *MockProductRepo) GetByID(ctx context.Context, id productDomain.ProductI
 	return args.Get(0).(*productDomain.Product), args.Error(1)
 }
 
-func (m *MockProductRepo) ReserveStock(ctx context.Context, id productDomain.ProductID, quantity int) error {
-	args := m.Called(ctx, id, quantity)
+type MockUserDirectory struct {
+	mock.Mock
+}
+
+func (m *MockUserDirectory) EnsureCanOrder(ctx context.Context, userID userDomain.UserID) error {
+	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
+func newActiveUserDirectory() *MockUserDirectory {
+	users := new(MockUserDirectory)
+	users.On("EnsureCanOrder", mock.Anything, mock.Anything).Return(nil)
+	return users
+}
+
+type MockOutbox struct {
+	mock.Mock
+}
+
+func (m *MockOutbox) Save(ctx context.Context, events ...event.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func newTestOutbox() *MockOutbox {
+	outbox := new(MockOutbox)
+	outbox.On("Save", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return outbox
+}
+
+type MockStatusLog struct {
+	mock.Mock
+}
+
+func (m *MockStatusLog) Append(ctx context.Context, change *domain.StatusChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockStatusLog) After(ctx context.Context, filter StatusFilter, afterID int64, limit int) ([]*domain.StatusChange, error) {
+	args := m.Called(ctx, filter, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.StatusChange), args.Error(1)
+}
+
+func newTestStatusLog() *MockStatusLog {
+	statusLog := new(MockStatusLog)
+	statusLog.On("Append", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return statusLog
+}
+
+var inStock = &StockAllocation{Fulfillment: domain.FulfillmentInStock}
+
+type MockStockReserver struct {
+	mock.Mock
+}
+
+func (m *MockStockReserver) Reserve(
+	ctx context.Context,
+	orderID domain.OrderID,
+	productID productDomain.ProductID,
+	variantID *productDomain.VariantID,
+	quantity int,
+) (*StockAllocation, error) {
+	args := m.Called(ctx, orderID, productID, variantID, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*StockAllocation), args.Error(1)
+}
+
+func (m *MockStockReserver) Commit(ctx context.Context, orderID domain.OrderID) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockStockReserver) Release(ctx context.Context, orderID domain.OrderID) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockStockReserver) Expire(ctx context.Context, orderID domain.OrderID) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockStockReserver) ExpiredOrders(ctx context.Context, limit int) ([]domain.OrderID, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrderID), args.Error(1)
+}
+
 type MockOrderTxManager struct {
 	mock.Mock
 }
@@ -79,9 +182,10 @@ func (m *MockOrderTxManager) WithTx(ctx context.Context, fn func(ctx context.Con
 func TestOrderService_PlaceOrder_Success(t *testing.T) {
 	mockOrderRepo := new(MockOrderRepo)
 	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
 	mockTx := new(MockOrderTxManager)
 
-	service := NewOrderService(mockOrderRepo, mockProductRepo, mockTx)
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
 
 	userID := userDomain.NewUserID()
 	productID := productDomain.NewProductID()
@@ -103,7 +207,7 @@ func TestOrderService_PlaceOrder_Success(t *testing.T) {
 
 	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
 	mockProductRepo.On("GetByID", mock.Anything, productID).Return(product, nil)
-	mockProductRepo.On("ReserveStock", mock.Anything, productID, 2).Return(nil)
+	mockReserver.On("Reserve", mock.Anything, mock.AnythingOfType("domain.OrderID"), productID, (*productDomain.VariantID)(nil), 2).Return(inStock, nil)
 	mockOrderRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Order")).Return(nil)
 
 	order, err := service.PlaceOrder(context.Background(), input)
@@ -121,15 +225,17 @@ func TestOrderService_PlaceOrder_Success(t *testing.T) {
 
 	mockOrderRepo.AssertExpectations(t)
 	mockProductRepo.AssertExpectations(t)
+	mockReserver.AssertExpectations(t)
 	mockTx.AssertExpectations(t)
 }
 
 func TestOrderService_PlaceOrder_InsufficientStock(t *testing.T) {
 	mockOrderRepo := new(MockOrderRepo)
 	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
 	mockTx := new(MockOrderTxManager)
 
-	service := NewOrderService(mockOrderRepo, mockProductRepo, mockTx)
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
 
 	userID := userDomain.NewUserID()
 	productID := productDomain.NewProductID()
@@ -151,6 +257,8 @@ func TestOrderService_PlaceOrder_InsufficientStock(t *testing.T) {
 
 	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
 	mockProductRepo.On("GetByID", mock.Anything, productID).Return(product, nil)
+	mockReserver.On("Reserve", mock.Anything, mock.AnythingOfType("domain.OrderID"), productID, (*productDomain.VariantID)(nil), 5).
+		Return(nil, productDomain.ErrInsufficientStock)
 
 	order, err := service.PlaceOrder(context.Background(), input)
 
@@ -159,15 +267,17 @@ func TestOrderService_PlaceOrder_InsufficientStock(t *testing.T) {
 	assert.Equal(t, productDomain.ErrInsufficientStock, err)
 
 	mockProductRepo.AssertExpectations(t)
+	mockReserver.AssertExpectations(t)
 	mockOrderRepo.AssertNotCalled(t, "Create")
 }
 
 func TestOrderService_PlaceOrder_ProductNotFound(t *testing.T) {
 	mockOrderRepo := new(MockOrderRepo)
 	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
 	mockTx := new(MockOrderTxManager)
 
-	service := NewOrderService(mockOrderRepo, mockProductRepo, mockTx)
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
 
 	userID := userDomain.NewUserID()
 	productID := productDomain.NewProductID()
@@ -193,4 +303,443 @@ func TestOrderService_PlaceOrder_ProductNotFound(t *testing.T) {
 
 	mockProductRepo.AssertExpectations(t)
 	mockOrderRepo.AssertNotCalled(t, "Create")
-}
\ No newline at end of file
+}
+
+func TestOrderService_PlaceOrder_Variant(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	userID := userDomain.NewUserID()
+
+	price, _ := productDomain.NewMoney(decimal.NewFromFloat(10.00))
+	inventory, _ := productDomain.NewInventory(0)
+	product, _ := productDomain.NewProduct("T-shirt", []string{"apparel"}, price, inventory)
+	size, _ := productDomain.NewOptionAxis("size", []string{"S", "M"})
+	_ = product.DefineOptions([]productDomain.OptionAxis{size})
+
+	variantPrice, _ := productDomain.NewMoney(decimal.NewFromFloat(12.50))
+	variantInventory, _ := productDomain.NewInventory(10)
+	variant, _ := productDomain.NewVariant("TS-M", "", map[string]string{"size": "M"}, variantPrice, variantInventory)
+	_ = product.AddVariant(variant)
+
+	input := PlaceOrderInput{
+		UserID: userID,
+		Items: []OrderItemInput{
+			{
+				ProductID: product.ID,
+				VariantID: &variant.ID,
+				Quantity:  2,
+			},
+		},
+	}
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockProductRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockReserver.On("Reserve", mock.Anything, mock.AnythingOfType("domain.OrderID"), product.ID, &variant.ID, 2).Return(inStock, nil)
+	mockOrderRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.Len(t, order.Items, 1)
+	assert.Equal(t, variant.ID, *order.Items[0].VariantID)
+	assert.Equal(t, "TS-M", order.Items[0].VariantSKU)
+	assert.Equal(t, map[string]string{"size": "M"}, order.Items[0].VariantOptions)
+	assert.True(t, decimal.NewFromFloat(25.00).Equal(order.TotalPrice.Amount()))
+
+	mockReserver.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestOrderService_PlaceOrder_VariantRequired(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	price, _ := productDomain.NewMoney(decimal.NewFromFloat(10.00))
+	inventory, _ := productDomain.NewInventory(100)
+	product, _ := productDomain.NewProduct("T-shirt", nil, price, inventory)
+	variant, _ := productDomain.NewVariant("TS", "", nil, price, inventory)
+	_ = product.AddVariant(variant)
+
+	input := PlaceOrderInput{
+		UserID: userDomain.NewUserID(),
+		Items:  []OrderItemInput{{ProductID: product.ID, Quantity: 1}},
+	}
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockProductRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.Nil(t, order)
+	assert.Equal(t, productDomain.ErrVariantRequired, err)
+	mockOrderRepo.AssertNotCalled(t, "Create")
+}
+
+func TestOrderService_PlaceOrder_ArchivedProduct(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	price, _ := productDomain.NewMoney(decimal.NewFromFloat(10.50))
+	inventory, _ := productDomain.NewInventory(100)
+	product, _ := productDomain.NewProduct("Test Product", []string{"tag1"}, price, inventory)
+	_ = product.Archive()
+
+	input := PlaceOrderInput{
+		UserID: userDomain.NewUserID(),
+		Items:  []OrderItemInput{{ProductID: product.ID, Quantity: 1}},
+	}
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockProductRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.Nil(t, order)
+	assert.Equal(t, productDomain.ErrProductArchived, err)
+	mockReserver.AssertNotCalled(t, "Reserve")
+	mockOrderRepo.AssertNotCalled(t, "Create")
+}
+
+func TestOrderService_ConfirmOrder_VersionConflict(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, new(MockUserDirectory), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	price, _ := productDomain.NewMoney(decimal.NewFromFloat(10.50))
+	item, _ := domain.NewOrderItem(domain.NewOrderID(), productDomain.NewProductID(), "Test Product", price, 1)
+	order, _ := domain.NewOrder(domain.NewOrderID(), userDomain.NewUserID(), []domain.OrderItem{*item})
+	order.Version = 3
+
+	staleVersion := 2
+	input := UpdateOrderStatusInput{
+		OrderID:         order.ID,
+		ExpectedVersion: &staleVersion,
+	}
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockOrderRepo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+
+	confirmed, err := service.ConfirmOrder(context.Background(), input)
+
+	assert.Nil(t, confirmed)
+	assert.Equal(t, domain.ErrOrderVersionConflict, err)
+	assert.Equal(t, domain.OrderStatusPending, order.Status)
+	mockOrderRepo.AssertNotCalled(t, "Update")
+}
+
+func TestOrderService_PlaceOrder_Backorder(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	price, _ := productDomain.NewMoney(decimal.NewFromFloat(10.50))
+	inventory, _ := productDomain.RestoreInventory(5, 4) // 4 of 5 held by pending orders
+	product, _ := productDomain.NewProduct("Test Product", nil, price, inventory)
+	restockAt := time.Now().Add(72 * time.Hour)
+
+	input := PlaceOrderInput{
+		UserID: userDomain.NewUserID(),
+		Items:  []OrderItemInput{{ProductID: product.ID, Quantity: 2}},
+	}
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockProductRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockReserver.On("Reserve", mock.Anything, mock.AnythingOfType("domain.OrderID"), product.ID, (*productDomain.VariantID)(nil), 2).
+		Return(&StockAllocation{Fulfillment: domain.FulfillmentBackorder, ExpectedAt: &restockAt}, nil)
+	mockOrderRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FulfillmentBackorder, order.Items[0].Fulfillment)
+	assert.True(t, order.Items[0].IsWaiting())
+	assert.Equal(t, &restockAt, order.ExpectedAt())
+	mockReserver.AssertExpectations(t)
+}
+
+func newPendingTestOrder() *domain.Order {
+	price, _ := productDomain.NewMoney(decimal.NewFromFloat(10.50))
+	item, _ := domain.NewOrderItem(domain.NewOrderID(), productDomain.NewProductID(), "Test Product", price, 1)
+	order, _ := domain.NewOrder(domain.NewOrderID(), userDomain.NewUserID(), []domain.OrderItem{*item})
+	order.PullEvents()
+	return order
+}
+
+func TestOrderService_ConfirmOrder_CommitsReservations(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockReserver := new(MockStockReserver)
+	mockOutbox := new(MockOutbox)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, new(MockProductRepo), mockReserver, new(MockUserDirectory), mockOutbox, newTestStatusLog(), mockTx)
+
+	order := newPendingTestOrder()
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockOrderRepo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+	mockOrderRepo.On("Update", mock.Anything, order).Return(nil)
+	mockReserver.On("Commit", mock.Anything, order.ID).Return(nil)
+	mockOutbox.On("Save", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		return len(events) == 1 && events[0].EventName() == domain.EventOrderConfirmed &&
+			events[0].AggregateID() == order.ID.String()
+	})).Return(nil)
+
+	confirmed, err := service.ConfirmOrder(context.Background(), UpdateOrderStatusInput{OrderID: order.ID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusConfirmed, confirmed.Status)
+	mockReserver.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestOrderService_ConfirmOrder_ReservationExpired(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, new(MockProductRepo), mockReserver, new(MockUserDirectory), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	order := newPendingTestOrder()
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockOrderRepo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+	mockOrderRepo.On("Update", mock.Anything, order).Return(nil)
+	mockReserver.On("Commit", mock.Anything, order.ID).Return(productDomain.ErrReservationExpired)
+
+	confirmed, err := service.ConfirmOrder(context.Background(), UpdateOrderStatusInput{OrderID: order.ID})
+
+	assert.Nil(t, confirmed)
+	assert.ErrorIs(t, err, productDomain.ErrReservationExpired)
+}
+
+func TestOrderService_CancelOrder_ReleasesReservations(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockReserver := new(MockStockReserver)
+	mockStatusLog := new(MockStatusLog)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, new(MockProductRepo), mockReserver, new(MockUserDirectory), newTestOutbox(), mockStatusLog, mockTx)
+
+	order := newPendingTestOrder()
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockOrderRepo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+	mockOrderRepo.On("Update", mock.Anything, order).Return(nil)
+	mockReserver.On("Release", mock.Anything, order.ID).Return(nil)
+	mockStatusLog.On("Append", mock.Anything, mock.MatchedBy(func(change *domain.StatusChange) bool {
+		return change.OrderID == order.ID &&
+			change.Status == domain.OrderStatusCancelled &&
+			change.PreviousStatus == domain.OrderStatusPending
+	})).Return(nil)
+
+	cancelled, err := service.CancelOrder(context.Background(), UpdateOrderStatusInput{OrderID: order.ID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusCancelled, cancelled.Status)
+	mockReserver.AssertExpectations(t)
+	mockStatusLog.AssertExpectations(t)
+}
+
+func TestOrderService_ExpireReservations(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, new(MockProductRepo), mockReserver, new(MockUserDirectory), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	pending := newPendingTestOrder()
+	confirmed := newPendingTestOrder()
+	_ = confirmed.Confirm()
+	missing := domain.NewOrderID()
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockReserver.On("ExpiredOrders", mock.Anything, expireBatchSize).
+		Return([]domain.OrderID{pending.ID, confirmed.ID, missing}, nil)
+	mockOrderRepo.On("GetByID", mock.Anything, pending.ID).Return(pending, nil)
+	mockOrderRepo.On("GetByID", mock.Anything, confirmed.ID).Return(confirmed, nil)
+	mockOrderRepo.On("GetByID", mock.Anything, missing).Return(nil, domain.ErrOrderNotFound)
+	mockOrderRepo.On("Update", mock.Anything, pending).Return(nil)
+	mockReserver.On("Expire", mock.Anything, mock.AnythingOfType("domain.OrderID")).Return(nil)
+
+	expired, err := service.ExpireReservations(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+	assert.Equal(t, domain.OrderStatusCancelled, pending.Status)
+	assert.Equal(t, domain.OrderStatusConfirmed, confirmed.Status)
+	mockOrderRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockReserver.AssertNumberOfCalls(t, "Expire", 3)
+}
+
+func TestOrderService_PlaceOrder_BlockedCustomer(t *testing.T) {
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockUsers := new(MockUserDirectory)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(new(MockOrderRepo), mockProductRepo, mockReserver, mockUsers, newTestOutbox(), newTestStatusLog(), mockTx)
+
+	userID := userDomain.NewUserID()
+	input := PlaceOrderInput{
+		UserID: userID,
+		Items:  []OrderItemInput{{ProductID: productDomain.NewProductID(), Quantity: 1}},
+	}
+
+	mockUsers.On("EnsureCanOrder", mock.Anything, userID).Return(domain.ErrCustomerBlocked)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.Nil(t, order)
+	assert.ErrorIs(t, err, domain.ErrCustomerBlocked)
+	mockTx.AssertNotCalled(t, "WithTx", mock.Anything, mock.Anything)
+	mockProductRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	mockReserver.AssertNotCalled(t, "Reserve")
+}
+
+func TestOrderService_PlaceOrder_UnknownCustomer(t *testing.T) {
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockUsers := new(MockUserDirectory)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(new(MockOrderRepo), mockProductRepo, mockReserver, mockUsers, newTestOutbox(), newTestStatusLog(), mockTx)
+
+	userID := userDomain.NewUserID()
+	input := PlaceOrderInput{
+		UserID: userID,
+		Items:  []OrderItemInput{{ProductID: productDomain.NewProductID(), Quantity: 1}},
+	}
+
+	mockUsers.On("EnsureCanOrder", mock.Anything, userID).Return(domain.ErrCustomerNotFound)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.Nil(t, order)
+	assert.ErrorIs(t, err, domain.ErrCustomerNotFound)
+	mockTx.AssertNotCalled(t, "WithTx", mock.Anything, mock.Anything)
+	mockProductRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	mockReserver.AssertNotCalled(t, "Reserve")
+}
+
+func newLimitedTestProduct(maxPerOrder, maxPerUser *int, window time.Duration) *productDomain.Product {
+	price, _ := productDomain.NewMoney(decimal.NewFromFloat(10.50))
+	inventory, _ := productDomain.NewInventory(100)
+	product, _ := productDomain.NewProduct("Limited Drop", nil, price, inventory)
+	limits, _ := productDomain.NewPurchaseLimits(maxPerOrder, maxPerUser, window)
+	product.SetPurchaseLimits(limits)
+	return product
+}
+
+func TestOrderService_PlaceOrder_OrderLimitExceeded(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	maxPerOrder := 2
+	product := newLimitedTestProduct(&maxPerOrder, nil, 0)
+
+	// Two lines of one product count together.
+	input := PlaceOrderInput{
+		UserID: userDomain.NewUserID(),
+		Items: []OrderItemInput{
+			{ProductID: product.ID, Quantity: 2},
+			{ProductID: product.ID, Quantity: 1},
+		},
+	}
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockProductRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.Nil(t, order)
+	assert.ErrorIs(t, err, domain.ErrOrderLimitExceeded)
+	mockReserver.AssertNotCalled(t, "Reserve")
+	mockOrderRepo.AssertNotCalled(t, "LockAndSumPurchases")
+}
+
+func TestOrderService_PlaceOrder_UserLimitExceeded(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	maxPerUser := 3
+	product := newLimitedTestProduct(nil, &maxPerUser, 24*time.Hour)
+	userID := userDomain.NewUserID()
+
+	input := PlaceOrderInput{
+		UserID: userID,
+		Items:  []OrderItemInput{{ProductID: product.ID, Quantity: 2}},
+	}
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockProductRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockOrderRepo.On("LockAndSumPurchases", mock.Anything, userID, product.ID, mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) >= 24*time.Hour && time.Since(since) < 25*time.Hour
+	})).Return(2, nil)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.Nil(t, order)
+	assert.ErrorIs(t, err, domain.ErrUserLimitExceeded)
+	mockOrderRepo.AssertExpectations(t)
+	mockReserver.AssertNotCalled(t, "Reserve")
+}
+
+func TestOrderService_PlaceOrder_WithinUserLimit(t *testing.T) {
+	mockOrderRepo := new(MockOrderRepo)
+	mockProductRepo := new(MockProductRepo)
+	mockReserver := new(MockStockReserver)
+	mockTx := new(MockOrderTxManager)
+
+	service := NewOrderService(mockOrderRepo, mockProductRepo, mockReserver, newActiveUserDirectory(), newTestOutbox(), newTestStatusLog(), mockTx)
+
+	maxPerUser := 3
+	product := newLimitedTestProduct(nil, &maxPerUser, time.Hour)
+	userID := userDomain.NewUserID()
+
+	input := PlaceOrderInput{
+		UserID: userID,
+		Items:  []OrderItemInput{{ProductID: product.ID, Quantity: 2}},
+	}
+
+	mockTx.On("WithTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockProductRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockOrderRepo.On("LockAndSumPurchases", mock.Anything, userID, product.ID, mock.Anything).Return(1, nil)
+	mockReserver.On("Reserve", mock.Anything, mock.AnythingOfType("domain.OrderID"), product.ID, (*productDomain.VariantID)(nil), 2).
+		Return(inStock, nil)
+	mockOrderRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Order")).Return(nil)
+
+	order, err := service.PlaceOrder(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, order)
+	mockOrderRepo.AssertExpectations(t)
+	mockReserver.AssertExpectations(t)
+}
@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+)
+
+const (
+	statusBacklogPageSize  = 100
+	statusSubscriberBuffer = 64
+)
+
+type StatusStreamConfig struct {
+	HeartbeatInterval time.Duration `env:"HEARTBEAT_INTERVAL" envDefault:"15s" validate:"gt=0"`
+	// Streams are closed after MaxDuration and resumed by the client with
+	// Last-Event-ID.
+	MaxDuration time.Duration `env:"MAX_DURATION" envDefault:"30m" validate:"gt=0"`
+}
+
+// StatusFilter selects the changes of one order or of all orders of one
+// user.
+type StatusFilter struct {
+	OrderID *domain.OrderID
+	UserID  *userDomain.UserID
+}
+
+func (f StatusFilter) Matches(change *domain.StatusChange) bool {
+	if f.OrderID != nil && *f.OrderID != change.OrderID {
+		return false
+	}
+	if f.UserID != nil && *f.UserID != change.UserID {
+		return false
+	}
+	return true
+}
+
+// StatusHub fans status changes announced by any replica out to the streams
+// open on this one.
+type StatusHub struct {
+	mu          sync.Mutex
+	subscribers map[*statusSubscriber]struct{}
+	closed      bool
+}
+
+type statusSubscriber struct {
+	filter  StatusFilter
+	changes chan *domain.StatusChange
+}
+
+func NewStatusHub() *StatusHub {
+	return &StatusHub{subscribers: make(map[*statusSubscriber]struct{})}
+}
+
+// Subscribe returns a channel of matching changes and a function that ends
+// the subscription. The channel is closed when the subscription ends, when
+// the hub is closed or when the subscriber falls too far behind.
+func (h *StatusHub) Subscribe(filter StatusFilter) (<-chan *domain.StatusChange, func()) {
+	subscriber := &statusSubscriber{
+		filter:  filter,
+		changes: make(chan *domain.StatusChange, statusSubscriberBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(subscriber.changes)
+		return subscriber.changes, func() {}
+	}
+
+	h.subscribers[subscriber] = struct{}{}
+	return subscriber.changes, func() { h.remove(subscriber) }
+}
+
+func (h *StatusHub) Publish(change *domain.StatusChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscriber := range h.subscribers {
+		if !subscriber.filter.Matches(change) {
+			continue
+		}
+
+		select {
+		case subscriber.changes <- change:
+		default:
+			// A slow client is dropped rather than holding up the others;
+			// it resumes from the status log when it reconnects.
+			delete(h.subscribers, subscriber)
+			close(subscriber.changes)
+		}
+	}
+}
+
+// Close ends all subscriptions. Later ones end right away.
+func (h *StatusHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for subscriber := range h.subscribers {
+		delete(h.subscribers, subscriber)
+		close(subscriber.changes)
+	}
+}
+
+func (h *StatusHub) remove(subscriber *statusSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[subscriber]; ok {
+		delete(h.subscribers, subscriber)
+		close(subscriber.changes)
+	}
+}
+
+type StatusStreamService struct {
+	statusLog StatusLog
+	hub       *StatusHub
+}
+
+func NewStatusStreamService(statusLog StatusLog, hub *StatusHub) *StatusStreamService {
+	return &StatusStreamService{
+		statusLog: statusLog,
+		hub:       hub,
+	}
+}
+
+// Watch streams the changes matching filter that follow afterID: first the
+// ones already in the status log, then live ones. The channel is closed when
+// ctx is done or the live subscription ends.
+func (s *StatusStreamService) Watch(ctx context.Context, filter StatusFilter, afterID int64) (<-chan *domain.StatusChange, error) {
+	// Subscribing before reading the log means no change falls in between;
+	// changes seen in both are skipped by ID.
+	live, unsubscribe := s.hub.Subscribe(filter)
+
+	backlog, err := s.statusLog.After(ctx, filter, afterID, statusBacklogPageSize)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	changes := make(chan *domain.StatusChange)
+	go func() {
+		defer close(changes)
+		defer unsubscribe()
+
+		lastID := afterID
+		send := func(change *domain.StatusChange) bool {
+			if change.ID <= lastID {
+				return true
+			}
+			select {
+			case changes <- change:
+				lastID = change.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for len(backlog) > 0 {
+			for _, change := range backlog {
+				if !send(change) {
+					return
+				}
+			}
+			if len(backlog) < statusBacklogPageSize {
+				break
+			}
+
+			backlog, err = s.statusLog.After(ctx, filter, lastID, statusBacklogPageSize)
+			if err != nil {
+				return
+			}
+		}
+
+		for {
+			select {
+			case change, ok := <-live:
+				if !ok || !send(change) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+)
+
+func newTestStatusChange(id int64, orderID domain.OrderID, status domain.OrderStatus) *domain.StatusChange {
+	return &domain.StatusChange{ID: id, OrderID: orderID, UserID: userDomain.NewUserID(), Status: status}
+}
+
+func receive(t *testing.T, changes <-chan *domain.StatusChange) *domain.StatusChange {
+	t.Helper()
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("no status change received")
+		return nil
+	}
+}
+
+func TestStatusHub_PublishesMatchingChanges(t *testing.T) {
+	hub := NewStatusHub()
+	orderID := domain.NewOrderID()
+
+	changes, unsubscribe := hub.Subscribe(StatusFilter{OrderID: &orderID})
+	defer unsubscribe()
+
+	hub.Publish(newTestStatusChange(1, domain.NewOrderID(), domain.OrderStatusConfirmed))
+	hub.Publish(newTestStatusChange(2, orderID, domain.OrderStatusConfirmed))
+
+	assert.Equal(t, int64(2), receive(t, changes).ID)
+	assert.Empty(t, changes)
+}
+
+func TestStatusHub_DropsSlowSubscribers(t *testing.T) {
+	hub := NewStatusHub()
+	orderID := domain.NewOrderID()
+
+	changes, unsubscribe := hub.Subscribe(StatusFilter{})
+	defer unsubscribe()
+
+	for i := range statusSubscriberBuffer + 1 {
+		hub.Publish(newTestStatusChange(int64(i+1), orderID, domain.OrderStatusConfirmed))
+	}
+
+	var received int
+	for range changes {
+		received++
+	}
+	assert.Equal(t, statusSubscriberBuffer, received)
+}
+
+func TestStatusStreamService_Watch_ResumesThenStreamsLive(t *testing.T) {
+	statusLog := new(MockStatusLog)
+	hub := NewStatusHub()
+	service := NewStatusStreamService(statusLog, hub)
+
+	orderID := domain.NewOrderID()
+	filter := StatusFilter{OrderID: &orderID}
+	confirmed := newTestStatusChange(5, orderID, domain.OrderStatusConfirmed)
+	statusLog.On("After", mock.Anything, filter, int64(4), statusBacklogPageSize).
+		Return([]*domain.StatusChange{confirmed}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := service.Watch(ctx, filter, 4)
+	require.NoError(t, err)
+
+	assert.Equal(t, confirmed, receive(t, changes))
+
+	// Already sent from the log, so it is not repeated.
+	hub.Publish(confirmed)
+	cancelled := newTestStatusChange(6, orderID, domain.OrderStatusCancelled)
+	hub.Publish(cancelled)
+
+	assert.Equal(t, cancelled, receive(t, changes))
+
+	cancel()
+	_, ok := <-changes
+	assert.False(t, ok)
+}
+
+func TestStatusStreamService_Watch_EndsWhenHubCloses(t *testing.T) {
+	statusLog := new(MockStatusLog)
+	hub := NewStatusHub()
+	service := NewStatusStreamService(statusLog, hub)
+
+	statusLog.On("After", mock.Anything, StatusFilter{}, int64(0), statusBacklogPageSize).
+		Return([]*domain.StatusChange{}, nil)
+
+	changes, err := service.Watch(context.Background(), StatusFilter{}, 0)
+	require.NoError(t, err)
+
+	hub.Close()
+
+	select {
+	case _, ok := <-changes:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed")
+	}
+}
@@ -1,13 +1,13 @@
 package domain
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
 	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
 )
 
 type OrderItemID uuid.UUID
@@ -48,6 +48,15 @@ func (s OrderStatus) IsValid() bool {
 	}
 }
 
+// Fulfillment tells whether an order line ships from stock or waits for it.
+type Fulfillment string
+
+const (
+	FulfillmentInStock   Fulfillment = "in_stock"
+	FulfillmentBackorder Fulfillment = "backorder"
+	FulfillmentPreorder  Fulfillment = "preorder"
+)
+
 type OrderItem struct {
 	ID                 OrderItemID
 	OrderID            OrderID
@@ -55,12 +64,19 @@ type OrderItem struct {
 	ProductDescription string
 	ProductPrice       productDomain.Money
 	Quantity           int
-	CreatedAt          time.Time
+	VariantID          *productDomain.VariantID
+	VariantSKU         string
+	VariantOptions     map[string]string
+	Fulfillment        Fulfillment
+	// ExpectedAt is when a backordered or pre-ordered line is expected to
+	// become available, if known.
+	ExpectedAt *time.Time
+	CreatedAt  time.Time
 }
 
 func NewOrderItem(orderID OrderID, productID productDomain.ProductID, description string, price productDomain.Money, quantity int) (*OrderItem, error) {
 	if quantity <= 0 {
-		return nil, errors.New("order item quantity must be positive")
+		return nil, ErrInvalidItemQuantity
 	}
 
 	return &OrderItem{
@@ -70,10 +86,34 @@ func NewOrderItem(orderID OrderID, productID productDomain.ProductID, descriptio
 		ProductDescription: description,
 		ProductPrice:       price,
 		Quantity:           quantity,
+		Fulfillment:        FulfillmentInStock,
 		CreatedAt:          time.Now(),
 	}, nil
 }
 
+// MarkWaiting flags the line as a backorder or pre-order.
+func (oi *OrderItem) MarkWaiting(fulfillment Fulfillment, expectedAt *time.Time) {
+	oi.Fulfillment = fulfillment
+	oi.ExpectedAt = expectedAt
+}
+
+func (oi *OrderItem) IsWaiting() bool {
+	return oi.Fulfillment != FulfillmentInStock
+}
+
+// AttachVariant records which variant was ordered, snapshotting its SKU and
+// options so the order history survives later catalog changes.
+func (oi *OrderItem) AttachVariant(variant *productDomain.Variant) {
+	variantID := variant.ID
+	oi.VariantID = &variantID
+	oi.VariantSKU = variant.SKU
+
+	oi.VariantOptions = make(map[string]string, len(variant.Options))
+	for name, value := range variant.Options {
+		oi.VariantOptions[name] = value
+	}
+}
+
 func (oi *OrderItem) TotalPrice() productDomain.Money {
 	totalAmount := oi.ProductPrice.Amount().Mul(decimal.NewFromInt(int64(oi.Quantity)))
 	money, _ := productDomain.NewMoney(totalAmount)
@@ -81,16 +121,19 @@ func (oi *OrderItem) TotalPrice() productDomain.Money {
 }
 
 type Order struct {
+	event.Recorder
+
 	ID         OrderID
 	UserID     userDomain.UserID
 	Items      []OrderItem
 	Status     OrderStatus
 	TotalPrice productDomain.Money
+	Version    int
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
 
-func NewOrder(userID userDomain.UserID, items []OrderItem) (*Order, error) {
+func NewOrder(id OrderID, userID userDomain.UserID, items []OrderItem) (*Order, error) {
 	if len(items) == 0 {
 		return nil, ErrEmptyOrder
 	}
@@ -106,15 +149,62 @@ func NewOrder(userID userDomain.UserID, items []OrderItem) (*Order, error) {
 		return nil, err
 	}
 
-	return &Order{
-		ID:         NewOrderID(),
+	now := time.Now()
+	order := &Order{
+		ID:         id,
 		UserID:     userID,
 		Items:      items,
 		Status:     OrderStatusPending,
 		TotalPrice: totalPrice,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
-	}, nil
+		Version:    1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	placedItems := make([]OrderPlacedItem, 0, len(items))
+	for _, item := range items {
+		placedItem := OrderPlacedItem{
+			ProductID: uuid.UUID(item.ProductID),
+			Quantity:  item.Quantity,
+			Price:     item.ProductPrice.Amount(),
+		}
+		if item.VariantID != nil {
+			variantID := uuid.UUID(*item.VariantID)
+			placedItem.VariantID = &variantID
+		}
+		placedItems = append(placedItems, placedItem)
+	}
+
+	order.Record(OrderPlaced{
+		OrderID:    uuid.UUID(id),
+		UserID:     uuid.UUID(userID),
+		Items:      placedItems,
+		TotalPrice: totalPrice.Amount(),
+		OccurredAt: now,
+	})
+
+	return order, nil
+}
+
+// CheckVersion fails when the caller expects a different version than the
+// one loaded. A nil expectation always passes.
+func (o *Order) CheckVersion(expected *int) error {
+	if expected != nil && *expected != o.Version {
+		return ErrOrderVersionConflict
+	}
+	return nil
+}
+
+// ExpectedAt is the latest expected availability among waiting lines, nil
+// when every line ships from stock or no date is known.
+func (o *Order) ExpectedAt() *time.Time {
+	var expectedAt *time.Time
+	for _, item := range o.Items {
+		if item.ExpectedAt != nil && (expectedAt == nil || item.ExpectedAt.After(*expectedAt)) {
+			expectedAt = item.ExpectedAt
+		}
+	}
+	return expectedAt
 }
 
 func (o *Order) Confirm() error {
@@ -123,6 +213,11 @@ func (o *Order) Confirm() error {
 	}
 	o.Status = OrderStatusConfirmed
 	o.UpdatedAt = time.Now()
+	o.Record(OrderConfirmed{
+		OrderID:    uuid.UUID(o.ID),
+		UserID:     uuid.UUID(o.UserID),
+		OccurredAt: o.UpdatedAt,
+	})
 	return nil
 }
 
@@ -130,8 +225,15 @@ func (o *Order) Cancel() error {
 	if o.Status == OrderStatusCompleted || o.Status == OrderStatusCancelled {
 		return ErrInvalidOrderStatus
 	}
+	previous := o.Status
 	o.Status = OrderStatusCancelled
 	o.UpdatedAt = time.Now()
+	o.Record(OrderCancelled{
+		OrderID:        uuid.UUID(o.ID),
+		UserID:         uuid.UUID(o.UserID),
+		PreviousStatus: previous,
+		OccurredAt:     o.UpdatedAt,
+	})
 	return nil
 }
 
@@ -146,4 +248,4 @@ func (o *Order) Complete() error {
 
 func (o *Order) CanBeModified() bool {
 	return o.Status == OrderStatusPending
-}
\ No newline at end of file
+}
@@ -1,10 +1,16 @@
 package domain
 
-import "errors"
+import "github.com/BlackRRR/Irtea-test/pkg/errcode"
 
 var (
-	ErrOrderNotFound       = errors.New("order not found")
-	ErrEmptyOrder          = errors.New("order cannot be empty")
-	ErrInvalidOrderStatus  = errors.New("invalid order status transition")
-	ErrOrderCannotBeModified = errors.New("order cannot be modified in current status")
-)
\ No newline at end of file
+	ErrOrderNotFound         = errcode.New("order-not-found", "order not found")
+	ErrEmptyOrder            = errcode.New("empty-order", "order cannot be empty")
+	ErrInvalidItemQuantity   = errcode.New("invalid-item-quantity", "order item quantity must be positive")
+	ErrInvalidOrderStatus    = errcode.New("invalid-order-status", "invalid order status transition")
+	ErrOrderCannotBeModified = errcode.New("order-not-modifiable", "order cannot be modified in current status")
+	ErrOrderVersionConflict  = errcode.New("version-conflict", "order was modified concurrently")
+	ErrCustomerNotFound      = errcode.New("customer-not-found", "customer not found")
+	ErrCustomerBlocked       = errcode.New("customer-blocked", "customer is blocked")
+	ErrOrderLimitExceeded    = errcode.New("order-limit-exceeded", "order exceeds the per-order limit of a product")
+	ErrUserLimitExceeded     = errcode.New("user-limit-exceeded", "order exceeds the per-customer limit of a product")
+)
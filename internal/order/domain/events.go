@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	EventOrderPlaced    = "order.placed"
+	EventOrderConfirmed = "order.confirmed"
+	EventOrderCancelled = "order.cancelled"
+)
+
+type OrderPlacedItem struct {
+	ProductID uuid.UUID       `json:"product_id"`
+	VariantID *uuid.UUID      `json:"variant_id,omitempty"`
+	Quantity  int             `json:"quantity"`
+	Price     decimal.Decimal `json:"price"`
+}
+
+type OrderPlaced struct {
+	OrderID    uuid.UUID         `json:"order_id"`
+	UserID     uuid.UUID         `json:"user_id"`
+	Items      []OrderPlacedItem `json:"items"`
+	TotalPrice decimal.Decimal   `json:"total_price"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+func (e OrderPlaced) EventName() string   { return EventOrderPlaced }
+func (e OrderPlaced) AggregateID() string { return e.OrderID.String() }
+
+type OrderConfirmed struct {
+	OrderID    uuid.UUID `json:"order_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e OrderConfirmed) EventName() string   { return EventOrderConfirmed }
+func (e OrderConfirmed) AggregateID() string { return e.OrderID.String() }
+
+type OrderCancelled struct {
+	OrderID uuid.UUID `json:"order_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	// PreviousStatus tells cancelled pending orders from cancelled
+	// confirmed ones.
+	PreviousStatus OrderStatus `json:"previous_status"`
+	OccurredAt     time.Time   `json:"occurred_at"`
+}
+
+func (e OrderCancelled) EventName() string   { return EventOrderCancelled }
+func (e OrderCancelled) AggregateID() string { return e.OrderID.String() }
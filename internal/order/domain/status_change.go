@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+)
+
+// StatusChange is a status transition of an order as it is streamed to
+// clients. ID is assigned when the change is stored and increases across all
+// orders, so clients can resume a stream after the last change they saw.
+type StatusChange struct {
+	ID             int64
+	OrderID        OrderID
+	UserID         userDomain.UserID
+	Status         OrderStatus
+	PreviousStatus OrderStatus
+	Version        int
+	OccurredAt     time.Time
+}
+
+func NewStatusChange(order *Order, previous OrderStatus) *StatusChange {
+	return &StatusChange{
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		Status:         order.Status,
+		PreviousStatus: previous,
+		Version:        order.Version,
+		OccurredAt:     order.UpdatedAt,
+	}
+}
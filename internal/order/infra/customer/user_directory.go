@@ -0,0 +1,40 @@
+package customer
+
+import (
+	"context"
+	"errors"
+
+	oService "github.com/BlackRRR/Irtea-test/internal/order/app"
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	uService "github.com/BlackRRR/Irtea-test/internal/user/app"
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+)
+
+var _ oService.UserDirectory = (*UserDirectory)(nil)
+
+// UserDirectory answers the order context's customer checks from the user
+// context. Deleted users are gone from the user store, so they show up as
+// not found.
+type UserDirectory struct {
+	users *uService.UserService
+}
+
+func NewUserDirectory(users *uService.UserService) *UserDirectory {
+	return &UserDirectory{users: users}
+}
+
+func (d *UserDirectory) EnsureCanOrder(ctx context.Context, userID userDomain.UserID) error {
+	user, err := d.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, userDomain.ErrUserNotFound) {
+			return domain.ErrCustomerNotFound
+		}
+		return err
+	}
+
+	if user.IsBlocked() {
+		return domain.ErrCustomerBlocked
+	}
+
+	return nil
+}
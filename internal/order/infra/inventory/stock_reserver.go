@@ -0,0 +1,68 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	oService "github.com/BlackRRR/Irtea-test/internal/order/app"
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
+)
+
+var _ oService.StockReserver = (*StockReserver)(nil)
+
+// StockReserver backs the order context's stock reservations with the
+// product context's reservation service. Reservation kinds and order line
+// fulfillments share their values.
+type StockReserver struct {
+	reservations *pService.ReservationService
+}
+
+func NewStockReserver(reservations *pService.ReservationService) *StockReserver {
+	return &StockReserver{reservations: reservations}
+}
+
+func (r *StockReserver) Reserve(
+	ctx context.Context,
+	orderID domain.OrderID,
+	productID productDomain.ProductID,
+	variantID *productDomain.VariantID,
+	quantity int,
+) (*oService.StockAllocation, error) {
+	allocation, err := r.reservations.Reserve(ctx, uuid.UUID(orderID), productID, variantID, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oService.StockAllocation{
+		Fulfillment: domain.Fulfillment(allocation.Kind),
+		ExpectedAt:  allocation.ExpectedAt,
+	}, nil
+}
+
+func (r *StockReserver) Commit(ctx context.Context, orderID domain.OrderID) error {
+	return r.reservations.Commit(ctx, uuid.UUID(orderID))
+}
+
+func (r *StockReserver) Release(ctx context.Context, orderID domain.OrderID) error {
+	return r.reservations.Release(ctx, uuid.UUID(orderID))
+}
+
+func (r *StockReserver) Expire(ctx context.Context, orderID domain.OrderID) error {
+	return r.reservations.Expire(ctx, uuid.UUID(orderID))
+}
+
+func (r *StockReserver) ExpiredOrders(ctx context.Context, limit int) ([]domain.OrderID, error) {
+	ids, err := r.reservations.ExpiredOrders(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	orderIDs := make([]domain.OrderID, 0, len(ids))
+	for _, id := range ids {
+		orderIDs = append(orderIDs, domain.OrderID(id))
+	}
+
+	return orderIDs, nil
+}
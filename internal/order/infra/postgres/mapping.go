@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,12 +12,17 @@ import (
 )
 
 type OrderItemDB struct {
-	ID           string          `db:"id"`
-	OrderID      string          `db:"order_id"`
-	ProductID    string          `db:"product_id"`
-	Quantity     int             `db:"quantity"`
-	ProductPrice decimal.Decimal `db:"product_price"`
-	CreatedAt    time.Time       `db:"created_at"`
+	ID             string          `db:"id"`
+	OrderID        string          `db:"order_id"`
+	ProductID      string          `db:"product_id"`
+	Quantity       int             `db:"quantity"`
+	ProductPrice   decimal.Decimal `db:"product_price"`
+	VariantID      *string         `db:"variant_id"`
+	VariantSKU     *string         `db:"variant_sku"`
+	VariantOptions []byte          `db:"variant_options"`
+	Fulfillment    string          `db:"fulfillment"`
+	ExpectedAt     *time.Time      `db:"expected_at"`
+	CreatedAt      time.Time       `db:"created_at"`
 }
 
 type OrderDB struct {
@@ -24,6 +30,7 @@ type OrderDB struct {
 	UserID     string          `db:"user_id"`
 	Status     string          `db:"status"`
 	TotalPrice decimal.Decimal `db:"total_price"`
+	Version    int             `db:"version"`
 	CreatedAt  time.Time       `db:"created_at"`
 	UpdatedAt  time.Time       `db:"updated_at"`
 }
@@ -75,9 +82,30 @@ func (o *OrderWithItemsDB) ToDomain(productDescriptions map[string]string) (*dom
 			ProductDescription: description,
 			ProductPrice:       price,
 			Quantity:           itemDB.Quantity,
+			Fulfillment:        domain.Fulfillment(itemDB.Fulfillment),
+			ExpectedAt:         itemDB.ExpectedAt,
 			CreatedAt:          itemDB.CreatedAt,
 		}
 
+		if itemDB.VariantID != nil {
+			variantID, err := uuid.Parse(*itemDB.VariantID)
+			if err != nil {
+				return nil, err
+			}
+			id := productDomain.VariantID(variantID)
+			item.VariantID = &id
+		}
+
+		if itemDB.VariantSKU != nil {
+			item.VariantSKU = *itemDB.VariantSKU
+		}
+
+		if len(itemDB.VariantOptions) > 0 {
+			if err := json.Unmarshal(itemDB.VariantOptions, &item.VariantOptions); err != nil {
+				return nil, err
+			}
+		}
+
 		items = append(items, item)
 	}
 
@@ -92,6 +120,7 @@ func (o *OrderWithItemsDB) ToDomain(productDescriptions map[string]string) (*dom
 		Items:      items,
 		Status:     domain.OrderStatus(o.Status),
 		TotalPrice: totalPrice,
+		Version:    o.Version,
 		CreatedAt:  o.CreatedAt,
 		UpdatedAt:  o.UpdatedAt,
 	}, nil
@@ -106,8 +135,23 @@ func FromDomain(order *domain.Order) (*OrderWithItemsDB, error) {
 			ProductID:    item.ProductID.String(),
 			Quantity:     item.Quantity,
 			ProductPrice: item.ProductPrice.Amount(),
+			Fulfillment:  string(item.Fulfillment),
+			ExpectedAt:   item.ExpectedAt,
 			CreatedAt:    item.CreatedAt,
 		}
+
+		if item.VariantID != nil {
+			variantID := item.VariantID.String()
+			variantSKU := item.VariantSKU
+			variantOptions, err := json.Marshal(item.VariantOptions)
+			if err != nil {
+				return nil, err
+			}
+
+			itemDB.VariantID = &variantID
+			itemDB.VariantSKU = &variantSKU
+			itemDB.VariantOptions = variantOptions
+		}
 		itemsDB = append(itemsDB, itemDB)
 	}
 
@@ -117,9 +161,10 @@ func FromDomain(order *domain.Order) (*OrderWithItemsDB, error) {
 			UserID:     order.UserID.String(),
 			Status:     string(order.Status),
 			TotalPrice: order.TotalPrice.Amount(),
+			Version:    order.Version,
 			CreatedAt:  order.CreatedAt,
 			UpdatedAt:  order.UpdatedAt,
 		},
 		Items: itemsDB,
 	}, nil
-}
\ No newline at end of file
+}
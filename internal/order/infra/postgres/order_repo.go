@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
 	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
 	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
 	"github.com/shopspring/decimal"
 	"time"
@@ -34,8 +35,8 @@ func (r *OrderRepo) Create(ctx context.Context, order *domain.Order) error {
 	q := postgres.GetQuerier(ctx, r.pool)
 
 	orderQuery := `
-		INSERT INTO orders."order" (id, user_id, status, total_price, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO orders."order" (id, user_id, status, total_price, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
 	_, err = q.Exec(ctx, orderQuery,
@@ -43,6 +44,7 @@ func (r *OrderRepo) Create(ctx context.Context, order *domain.Order) error {
 		orderDB.UserID,
 		orderDB.Status,
 		orderDB.TotalPrice,
+		orderDB.Version,
 		orderDB.CreatedAt,
 		orderDB.UpdatedAt,
 	)
@@ -62,8 +64,8 @@ func (r *OrderRepo) GetByID(ctx context.Context, id domain.OrderID) (*domain.Ord
 	q := postgres.GetQuerier(ctx, r.pool)
 
 	orderQuery := `
-		SELECT id, user_id, status, total_price, created_at, updated_at
-		FROM orders
+		SELECT id, user_id, status, total_price, version, created_at, updated_at
+		FROM orders.order
 		WHERE id = $1
 	`
 
@@ -73,6 +75,7 @@ func (r *OrderRepo) GetByID(ctx context.Context, id domain.OrderID) (*domain.Ord
 		&orderDB.UserID,
 		&orderDB.Status,
 		&orderDB.TotalPrice,
+		&orderDB.Version,
 		&orderDB.CreatedAt,
 		&orderDB.UpdatedAt,
 	)
@@ -85,10 +88,11 @@ func (r *OrderRepo) GetByID(ctx context.Context, id domain.OrderID) (*domain.Ord
 	}
 
 	itemsQuery := `
-		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.product_price, oi.created_at,
+		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.product_price,
+		       oi.variant_id, oi.variant_sku, oi.variant_options, oi.fulfillment, oi.expected_at, oi.created_at,
 		       p.description
 		FROM orders.order_items oi
-		JOIN products.products p ON oi.product_id = p.id
+		JOIN products.product p ON oi.product_id = p.id
 		WHERE oi.order_id = $1
 		ORDER BY oi.created_at
 	`
@@ -111,6 +115,11 @@ func (r *OrderRepo) GetByID(ctx context.Context, id domain.OrderID) (*domain.Ord
 			&item.ProductID,
 			&item.Quantity,
 			&item.ProductPrice,
+			&item.VariantID,
+			&item.VariantSKU,
+			&item.VariantOptions,
+			&item.Fulfillment,
+			&item.ExpectedAt,
 			&item.CreatedAt,
 			&description,
 		)
@@ -138,7 +147,7 @@ func (r *OrderRepo) GetByUserID(ctx context.Context, userID userDomain.UserID, l
 	q := postgres.GetQuerier(ctx, r.pool)
 
 	ordersQuery := `
-		SELECT id, user_id, status, total_price, created_at, updated_at
+		SELECT id, user_id, status, total_price, version, created_at, updated_at
 		FROM orders.order
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -161,6 +170,7 @@ func (r *OrderRepo) GetByUserID(ctx context.Context, userID userDomain.UserID, l
 			&orderDB.UserID,
 			&orderDB.Status,
 			&orderDB.TotalPrice,
+			&orderDB.Version,
 			&orderDB.CreatedAt,
 			&orderDB.UpdatedAt,
 		)
@@ -181,10 +191,11 @@ func (r *OrderRepo) GetByUserID(ctx context.Context, userID userDomain.UserID, l
 	}
 
 	itemsQuery := `
-		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.product_price, oi.created_at,
+		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.product_price,
+		       oi.variant_id, oi.variant_sku, oi.variant_options, oi.fulfillment, oi.expected_at, oi.created_at,
 		       p.description
 		FROM orders.order_items oi
-		LEFT JOIN products.products p ON oi.product_id = p.id
+		LEFT JOIN products.product p ON oi.product_id = p.id
 		WHERE oi.order_id = ANY($1)
 		ORDER BY oi.order_id, oi.created_at
 	`
@@ -208,6 +219,11 @@ func (r *OrderRepo) GetByUserID(ctx context.Context, userID userDomain.UserID, l
 			&item.ProductID,
 			&item.Quantity,
 			&item.ProductPrice,
+			&item.VariantID,
+			&item.VariantSKU,
+			&item.VariantOptions,
+			&item.Fulfillment,
+			&item.ExpectedAt,
 			&item.CreatedAt,
 			&description,
 		)
@@ -251,8 +267,8 @@ func (r *OrderRepo) Update(ctx context.Context, order *domain.Order) error {
 
 	updateOrderQuery := `
 		UPDATE orders.order
-		SET status = $2, total_price = $3, updated_at = $4
-		WHERE id = $1
+		SET status = $2, total_price = $3, updated_at = $4, version = version + 1
+		WHERE id = $1 AND version = $5
 	`
 
 	result, err := q.Exec(ctx, updateOrderQuery,
@@ -260,6 +276,7 @@ func (r *OrderRepo) Update(ctx context.Context, order *domain.Order) error {
 		orderDB.Status,
 		orderDB.TotalPrice,
 		orderDB.UpdatedAt,
+		orderDB.Version,
 	)
 
 	if err != nil {
@@ -267,9 +284,11 @@ func (r *OrderRepo) Update(ctx context.Context, order *domain.Order) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return domain.ErrOrderNotFound
+		return postgres.MissingOrConflict(ctx, q, `orders."order"`, orderDB.ID, domain.ErrOrderNotFound, domain.ErrOrderVersionConflict)
 	}
 
+	order.Version++
+
 	deleteItemsQuery := `DELETE FROM orders.order_items WHERE order_id = $1`
 	_, err = q.Exec(ctx, deleteItemsQuery, orderDB.ID)
 	if err != nil {
@@ -300,12 +319,54 @@ func (r *OrderRepo) Delete(ctx context.Context, id domain.OrderID) error {
 	return nil
 }
 
+// LockAndSumPurchases locks the user's purchases until the transaction ends,
+// so concurrent orders of the same user are counted one after the other,
+// then sums the units of a product the user ordered since the given time,
+// leaving cancelled orders out. It must run in a transaction: outside one the
+// lock would be released right away.
+func (r *OrderRepo) LockAndSumPurchases(
+	ctx context.Context,
+	userID userDomain.UserID,
+	productID productDomain.ProductID,
+	since time.Time,
+) (int, error) {
+	q, ok := postgres.GetTx(ctx)
+	if !ok {
+		return 0, errors.New("locking user purchases requires a transaction")
+	}
+
+	lockQuery := `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`
+	if _, err := q.Exec(ctx, lockQuery, userID.String()); err != nil {
+		return 0, fmt.Errorf("failed to lock user purchases: %w", err)
+	}
+
+	query := `
+		SELECT COALESCE(SUM(oi.quantity), 0)
+		FROM orders.order_items oi
+		JOIN orders."order" o ON o.id = oi.order_id
+		WHERE o.user_id = $1 AND oi.product_id = $2 AND o.created_at >= $3 AND o.status <> 'cancelled'
+	`
+
+	var purchased int
+	err := q.QueryRow(ctx, query, userID.String(), productID.String(), since).Scan(&purchased)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum purchased quantity: %w", err)
+	}
+
+	return purchased, nil
+}
+
 func (r *OrderRepo) batchInsert(ctx context.Context, orderItems []OrderItemDB, q postgres.Querier) error {
 	ids := make([]string, 0, len(orderItems))
 	orderIDs := make([]string, 0, len(orderItems))
 	productIDs := make([]string, 0, len(orderItems))
 	quantities := make([]int, 0, len(orderItems))
 	prices := make([]decimal.Decimal, 0, len(orderItems))
+	variantIDs := make([]*string, 0, len(orderItems))
+	variantSKUs := make([]*string, 0, len(orderItems))
+	variantOptions := make([]*string, 0, len(orderItems))
+	fulfillments := make([]string, 0, len(orderItems))
+	expectedAts := make([]*time.Time, 0, len(orderItems))
 	createdAts := make([]time.Time, 0, len(orderItems))
 
 	for _, item := range orderItems {
@@ -314,22 +375,39 @@ func (r *OrderRepo) batchInsert(ctx context.Context, orderItems []OrderItemDB, q
 		productIDs = append(productIDs, item.ProductID)
 		quantities = append(quantities, item.Quantity)
 		prices = append(prices, item.ProductPrice)
+		variantIDs = append(variantIDs, item.VariantID)
+		variantSKUs = append(variantSKUs, item.VariantSKU)
+		fulfillments = append(fulfillments, item.Fulfillment)
+		expectedAts = append(expectedAts, item.ExpectedAt)
 		createdAts = append(createdAts, item.CreatedAt)
+
+		if item.VariantOptions != nil {
+			options := string(item.VariantOptions)
+			variantOptions = append(variantOptions, &options)
+		} else {
+			variantOptions = append(variantOptions, nil)
+		}
 	}
 
 	query := `
-	INSERT INTO orders.order_items (id, order_id, product_id, quantity, product_price, created_at)
+	INSERT INTO orders.order_items (id, order_id, product_id, quantity, product_price,
+	                                variant_id, variant_sku, variant_options, fulfillment, expected_at, created_at)
 	SELECT
 		UNNEST($1::uuid[]),
 		UNNEST($2::uuid[]),
 		UNNEST($3::uuid[]),
 		UNNEST($4::int[]),
 		UNNEST($5::numeric[]),
-		UNNEST($6::timestamptz[])
+		UNNEST($6::uuid[]),
+		UNNEST($7::varchar[]),
+		UNNEST($8::jsonb[]),
+		UNNEST($9::varchar[]),
+		UNNEST($10::timestamptz[]),
+		UNNEST($11::timestamptz[])
 `
 
 	if _, err := q.Exec(ctx, query,
-		ids, orderIDs, productIDs, quantities, prices, createdAts,
+		ids, orderIDs, productIDs, quantities, prices, variantIDs, variantSKUs, variantOptions, fulfillments, expectedAts, createdAts,
 	); err != nil {
 		return fmt.Errorf("failed to create order items batch: %w", err)
 	}
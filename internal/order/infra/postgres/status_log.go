@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+	oService "github.com/BlackRRR/Irtea-test/internal/order/app"
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+)
+
+// statusChannel is the LISTEN/NOTIFY channel status changes are announced
+// on.
+const statusChannel = "order_status_changed"
+
+var _ oService.StatusLog = (*StatusLog)(nil)
+
+type StatusLog struct {
+	pool *pgxpool.Pool
+}
+
+func NewStatusLog(pool *pgxpool.Pool) *StatusLog {
+	return &StatusLog{pool: pool}
+}
+
+// StatusChangeDB doubles as the NOTIFY payload.
+type StatusChangeDB struct {
+	ID             int64     `db:"id" json:"id"`
+	OrderID        string    `db:"order_id" json:"order_id"`
+	UserID         string    `db:"user_id" json:"user_id"`
+	Status         string    `db:"status" json:"status"`
+	PreviousStatus string    `db:"previous_status" json:"previous_status"`
+	Version        int       `db:"version" json:"version"`
+	OccurredAt     time.Time `db:"occurred_at" json:"occurred_at"`
+}
+
+func (c *StatusChangeDB) ToDomain() (*domain.StatusChange, error) {
+	orderID, err := uuid.Parse(c.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(c.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.StatusChange{
+		ID:             c.ID,
+		OrderID:        domain.OrderID(orderID),
+		UserID:         userDomain.UserID(userID),
+		Status:         domain.OrderStatus(c.Status),
+		PreviousStatus: domain.OrderStatus(c.PreviousStatus),
+		Version:        c.Version,
+		OccurredAt:     c.OccurredAt,
+	}, nil
+}
+
+// Append stores the change and queues a notification, which Postgres only
+// sends once the surrounding transaction commits.
+func (l *StatusLog) Append(ctx context.Context, change *domain.StatusChange) error {
+	changeDB := StatusChangeDB{
+		OrderID:        change.OrderID.String(),
+		UserID:         change.UserID.String(),
+		Status:         string(change.Status),
+		PreviousStatus: string(change.PreviousStatus),
+		Version:        change.Version,
+		OccurredAt:     change.OccurredAt,
+	}
+
+	query := `
+		INSERT INTO orders.status_change (order_id, user_id, status, previous_status, version, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	q := postgres.GetQuerier(ctx, l.pool)
+	err := q.QueryRow(ctx, query,
+		changeDB.OrderID,
+		changeDB.UserID,
+		changeDB.Status,
+		changeDB.PreviousStatus,
+		changeDB.Version,
+		changeDB.OccurredAt,
+	).Scan(&changeDB.ID)
+	if err != nil {
+		return fmt.Errorf("failed to append order status change: %w", err)
+	}
+
+	payload, err := json.Marshal(changeDB)
+	if err != nil {
+		return fmt.Errorf("failed to encode order status change: %w", err)
+	}
+
+	if _, err := q.Exec(ctx, `SELECT pg_notify($1, $2)`, statusChannel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify order status change: %w", err)
+	}
+
+	change.ID = changeDB.ID
+	return nil
+}
+
+func (l *StatusLog) After(ctx context.Context, filter oService.StatusFilter, afterID int64, limit int) ([]*domain.StatusChange, error) {
+	conditions := []string{"id > $1"}
+	args := []any{afterID}
+
+	if filter.OrderID != nil {
+		args = append(args, filter.OrderID.String())
+		conditions = append(conditions, fmt.Sprintf("order_id = $%d", len(args)))
+	}
+
+	if filter.UserID != nil {
+		args = append(args, filter.UserID.String())
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+
+	args = append(args, limit)
+	query := `
+		SELECT id, order_id, user_id, status, previous_status, version, occurred_at
+		FROM orders.status_change
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY id
+		LIMIT $` + fmt.Sprint(len(args))
+
+	q := postgres.GetQuerier(ctx, l.pool)
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]*domain.StatusChange, 0)
+	for rows.Next() {
+		var changeDB StatusChangeDB
+		err := rows.Scan(
+			&changeDB.ID,
+			&changeDB.OrderID,
+			&changeDB.UserID,
+			&changeDB.Status,
+			&changeDB.PreviousStatus,
+			&changeDB.Version,
+			&changeDB.OccurredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order status change: %w", err)
+		}
+
+		change, err := changeDB.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return changes, nil
+}
+
+// Listen feeds status changes announced by any replica into hub until ctx
+// is done, then closes the hub. It holds one pool connection and reconnects
+// after errors.
+func (l *StatusLog) Listen(ctx context.Context, hub *oService.StatusHub, logger *slog.Logger) {
+	defer hub.Close()
+
+	for {
+		err := l.listen(ctx, hub, logger)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.ErrorContext(ctx, "Order status listener failed, reconnecting", slog.Any("error", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (l *StatusLog) listen(ctx context.Context, hub *oService.StatusHub, logger *slog.Logger) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	// A listening connection must not go back to the pool.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+statusChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var changeDB StatusChangeDB
+		if err := json.Unmarshal([]byte(notification.Payload), &changeDB); err != nil {
+			logger.WarnContext(ctx, "Malformed order status notification", slog.Any("error", err))
+			continue
+		}
+
+		change, err := changeDB.ToDomain()
+		if err != nil {
+			logger.WarnContext(ctx, "Malformed order status notification", slog.Any("error", err))
+			continue
+		}
+
+		hub.Publish(change)
+	}
+}
@@ -0,0 +1,301 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/internal/order/app"
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
+	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
+	orderv1 "github.com/BlackRRR/Irtea-test/pkg/api/irtea/order/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultListLimit = 10
+
+var orderStatuses = map[domain.OrderStatus]orderv1.OrderStatus{
+	domain.OrderStatusPending:   orderv1.OrderStatus_ORDER_STATUS_PENDING,
+	domain.OrderStatusConfirmed: orderv1.OrderStatus_ORDER_STATUS_CONFIRMED,
+	domain.OrderStatusCancelled: orderv1.OrderStatus_ORDER_STATUS_CANCELLED,
+	domain.OrderStatusCompleted: orderv1.OrderStatus_ORDER_STATUS_COMPLETED,
+}
+
+var fulfillments = map[domain.Fulfillment]orderv1.Fulfillment{
+	domain.FulfillmentInStock:   orderv1.Fulfillment_FULFILLMENT_IN_STOCK,
+	domain.FulfillmentBackorder: orderv1.Fulfillment_FULFILLMENT_BACKORDER,
+	domain.FulfillmentPreorder:  orderv1.Fulfillment_FULFILLMENT_PREORDER,
+}
+
+// OrdersServer serves orderv1.OrderService. Domain errors are returned as
+// they are and mapped to status codes by the server's interceptor.
+type OrdersServer struct {
+	orderv1.UnimplementedOrderServiceServer
+
+	orderService *app.OrderService
+	statusStream *app.StatusStreamService
+}
+
+func NewOrdersServer(orderService *app.OrderService, statusStream *app.StatusStreamService) *OrdersServer {
+	return &OrdersServer{
+		orderService: orderService,
+		statusStream: statusStream,
+	}
+}
+
+func (s *OrdersServer) PlaceOrder(ctx context.Context, req *orderv1.PlaceOrderRequest) (*orderv1.PlaceOrderResponse, error) {
+	userID, err := s.parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]app.OrderItemInput, 0, len(req.GetItems()))
+	for _, itemReq := range req.GetItems() {
+		productID, err := uuid.Parse(itemReq.GetProductId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid product ID format")
+		}
+
+		item := app.OrderItemInput{
+			ProductID: productDomain.ProductID(productID),
+			Quantity:  int(itemReq.GetQuantity()),
+		}
+
+		if itemReq.GetVariantId() != "" {
+			variantID, err := uuid.Parse(itemReq.GetVariantId())
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "invalid variant ID format")
+			}
+			id := productDomain.VariantID(variantID)
+			item.VariantID = &id
+		}
+
+		items = append(items, item)
+	}
+
+	input := app.PlaceOrderInput{
+		UserID: userID,
+		Items:  items,
+	}
+
+	order, err := s.orderService.PlaceOrder(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderv1.PlaceOrderResponse{Order: s.mapOrder(order)}, nil
+}
+
+func (s *OrdersServer) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
+	orderID, err := s.parseOrderID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderService.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderv1.GetOrderResponse{Order: s.mapOrder(order)}, nil
+}
+
+func (s *OrdersServer) ListUserOrders(ctx context.Context, req *orderv1.ListUserOrdersRequest) (*orderv1.ListUserOrdersResponse, error) {
+	userID, err := s.parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	offset := int(req.GetOffset())
+	if offset < 0 {
+		offset = 0
+	}
+
+	orders, err := s.orderService.GetUserOrders(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &orderv1.ListUserOrdersResponse{
+		Orders: make([]*orderv1.Order, 0, len(orders)),
+	}
+	for _, order := range orders {
+		response.Orders = append(response.Orders, s.mapOrder(order))
+	}
+
+	return response, nil
+}
+
+func (s *OrdersServer) ConfirmOrder(ctx context.Context, req *orderv1.ConfirmOrderRequest) (*orderv1.ConfirmOrderResponse, error) {
+	orderID, err := s.parseOrderID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	input := app.UpdateOrderStatusInput{
+		OrderID:         orderID,
+		ExpectedVersion: intPtr(req.ExpectedVersion),
+	}
+
+	order, err := s.orderService.ConfirmOrder(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderv1.ConfirmOrderResponse{Order: s.mapOrder(order)}, nil
+}
+
+func (s *OrdersServer) CancelOrder(ctx context.Context, req *orderv1.CancelOrderRequest) (*orderv1.CancelOrderResponse, error) {
+	orderID, err := s.parseOrderID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	input := app.UpdateOrderStatusInput{
+		OrderID:         orderID,
+		ExpectedVersion: intPtr(req.ExpectedVersion),
+	}
+
+	order, err := s.orderService.CancelOrder(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderv1.CancelOrderResponse{Order: s.mapOrder(order)}, nil
+}
+
+// WatchOrderStatus is the gRPC counterpart of the SSE endpoints. When the
+// stream is cut on the server side it ends with UNAVAILABLE and the client
+// resumes with the ID of the last change it received as after_id.
+func (s *OrdersServer) WatchOrderStatus(req *orderv1.WatchOrderStatusRequest, stream orderv1.OrderService_WatchOrderStatusServer) error {
+	ctx := stream.Context()
+
+	var filter app.StatusFilter
+	switch target := req.GetTarget().(type) {
+	case *orderv1.WatchOrderStatusRequest_OrderId:
+		orderID, err := s.parseOrderID(target.OrderId)
+		if err != nil {
+			return err
+		}
+		if _, err := s.orderService.GetOrder(ctx, orderID); err != nil {
+			return err
+		}
+		filter.OrderID = &orderID
+	case *orderv1.WatchOrderStatusRequest_UserId:
+		userID, err := s.parseUserID(target.UserId)
+		if err != nil {
+			return err
+		}
+		filter.UserID = &userID
+	default:
+		return status.Error(codes.InvalidArgument, "order_id or user_id is required")
+	}
+
+	if req.GetAfterId() < 0 {
+		return status.Error(codes.InvalidArgument, "invalid after_id")
+	}
+
+	changes, err := s.statusStream.Watch(ctx, filter, req.GetAfterId())
+	if err != nil {
+		return err
+	}
+
+	for change := range changes {
+		err := stream.Send(&orderv1.WatchOrderStatusResponse{
+			Id:             change.ID,
+			OrderId:        change.OrderID.String(),
+			UserId:         change.UserID.String(),
+			Status:         orderStatuses[change.Status],
+			PreviousStatus: orderStatuses[change.PreviousStatus],
+			Version:        int32(change.Version),
+			OccurredAt:     timestamppb.New(change.OccurredAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return status.Error(codes.Unavailable, "status stream closed, resume with after_id")
+}
+
+func (s *OrdersServer) mapOrder(order *domain.Order) *orderv1.Order {
+	items := make([]*orderv1.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		itemResponse := &orderv1.OrderItem{
+			ProductId:          item.ProductID.String(),
+			ProductDescription: item.ProductDescription,
+			ProductPrice:       item.ProductPrice.Amount().String(),
+			VariantSku:         item.VariantSKU,
+			VariantOptions:     item.VariantOptions,
+			Quantity:           int32(item.Quantity),
+			TotalPrice:         item.TotalPrice().Amount().String(),
+			Fulfillment:        fulfillments[item.Fulfillment],
+			ExpectedAt:         timestamp(item.ExpectedAt),
+		}
+
+		if item.VariantID != nil {
+			itemResponse.VariantId = item.VariantID.String()
+		}
+
+		items = append(items, itemResponse)
+	}
+
+	return &orderv1.Order{
+		Id:         order.ID.String(),
+		UserId:     order.UserID.String(),
+		Items:      items,
+		Status:     orderStatuses[order.Status],
+		TotalPrice: order.TotalPrice.Amount().String(),
+		Version:    int32(order.Version),
+		ExpectedAt: timestamp(order.ExpectedAt()),
+		CreatedAt:  timestamppb.New(order.CreatedAt),
+		UpdatedAt:  timestamppb.New(order.UpdatedAt),
+	}
+}
+
+func (s *OrdersServer) parseOrderID(v string) (domain.OrderID, error) {
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return domain.OrderID{}, status.Error(codes.InvalidArgument, "invalid order ID format")
+	}
+
+	return domain.OrderID(id), nil
+}
+
+func (s *OrdersServer) parseUserID(v string) (userDomain.UserID, error) {
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return userDomain.UserID{}, status.Error(codes.InvalidArgument, "invalid user ID format")
+	}
+
+	return userDomain.UserID(id), nil
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+
+	i := int(*v)
+	return &i
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
@@ -3,21 +3,27 @@ package dto
 import "github.com/shopspring/decimal"
 
 type OrderItemRequest struct {
-	ProductID string `json:"product_id" validate:"required"`
+	ProductID string `json:"product_id" validate:"required,uuid"`
+	VariantID string `json:"variant_id" validate:"omitempty,uuid"`
 	Quantity  int    `json:"quantity" validate:"required,min=1"`
 }
 
 type PlaceOrderRequest struct {
-	UserID string             `json:"user_id" validate:"required"`
-	Items  []OrderItemRequest `json:"items" validate:"required,min=1"`
+	UserID string             `json:"user_id" validate:"required,uuid"`
+	Items  []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
 }
 
 type OrderItemResponse struct {
-	ProductID          string          `json:"product_id"`
-	ProductDescription string          `json:"product_description"`
-	ProductPrice       decimal.Decimal `json:"product_price"`
-	Quantity           int             `json:"quantity"`
-	TotalPrice         decimal.Decimal `json:"total_price"`
+	ProductID          string            `json:"product_id"`
+	ProductDescription string            `json:"product_description"`
+	ProductPrice       decimal.Decimal   `json:"product_price"`
+	VariantID          string            `json:"variant_id,omitempty"`
+	VariantSKU         string            `json:"variant_sku,omitempty"`
+	VariantOptions     map[string]string `json:"variant_options,omitempty"`
+	Quantity           int               `json:"quantity"`
+	TotalPrice         decimal.Decimal   `json:"total_price"`
+	Fulfillment        string            `json:"fulfillment"`
+	ExpectedAt         string            `json:"expected_at,omitempty"`
 }
 
 type OrderResponse struct {
@@ -26,6 +32,24 @@ type OrderResponse struct {
 	Items      []OrderItemResponse `json:"items"`
 	Status     string              `json:"status"`
 	TotalPrice decimal.Decimal     `json:"total_price"`
+	Version    int                 `json:"version"`
+	ExpectedAt string              `json:"expected_at,omitempty"`
 	CreatedAt  string              `json:"created_at"`
 	UpdatedAt  string              `json:"updated_at"`
 }
+
+type OrderListResponse struct {
+	Orders []OrderResponse `json:"orders"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// OrderStatusEvent is the data of a "status" server-sent event.
+type OrderStatusEvent struct {
+	OrderID        string `json:"order_id"`
+	UserID         string `json:"user_id"`
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status"`
+	Version        int    `json:"version"`
+	OccurredAt     string `json:"occurred_at"`
+}
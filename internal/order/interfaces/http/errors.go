@@ -0,0 +1,11 @@
+package http
+
+import "github.com/BlackRRR/Irtea-test/pkg/errcode"
+
+var (
+	ErrInvalidOrderID     = errcode.New("invalid-order-id", "invalid order ID format")
+	ErrInvalidUserID      = errcode.New("invalid-user-id", "invalid user ID format")
+	ErrInvalidProductID   = errcode.New("invalid-product-id", "invalid product ID format")
+	ErrInvalidVariantID   = errcode.New("invalid-variant-id", "invalid variant ID format")
+	ErrInvalidLastEventID = errcode.New("invalid-last-event-id", "invalid Last-Event-ID")
+)
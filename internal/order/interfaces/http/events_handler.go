@@ -0,0 +1,127 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/BlackRRR/Irtea-test/internal/order/app"
+	"github.com/BlackRRR/Irtea-test/internal/order/domain"
+	"github.com/BlackRRR/Irtea-test/internal/order/interfaces/http/dto"
+	"github.com/BlackRRR/Irtea-test/pkg/consts"
+)
+
+// sseRetry tells clients how long to wait before reconnecting.
+const sseRetry = 3 * time.Second
+
+func (h *OrdersHandler) OrderEvents(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	orderID, err := h.parseOrderID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidOrderID
+	}
+
+	if _, err := h.orderService.GetOrder(ctx, orderID); err != nil {
+		return err
+	}
+
+	return h.streamStatusChanges(c, app.StatusFilter{OrderID: &orderID})
+}
+
+func (h *OrdersHandler) UserOrderEvents(c *fiber.Ctx) error {
+	userID, err := h.parseUserID(c.Params("userId"))
+	if err != nil {
+		return ErrInvalidUserID
+	}
+
+	return h.streamStatusChanges(c, app.StatusFilter{UserID: &userID})
+}
+
+// streamStatusChanges answers with a text/event-stream of status changes.
+// Clients resume after the last change they received by sending its ID as
+// Last-Event-ID, which EventSource does on its own when it reconnects.
+func (h *OrdersHandler) streamStatusChanges(c *fiber.Ctx, filter app.StatusFilter) error {
+	var lastEventID int64
+	if raw := c.Get("Last-Event-ID", c.Query("last_event_id")); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 0 {
+			return ErrInvalidLastEventID
+		}
+		lastEventID = id
+	}
+
+	// The stream outlives the handler, so it must not depend on the request
+	// context.
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.UserContext()))
+
+	changes, err := h.statusStream.Watch(ctx, filter, lastEventID)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		heartbeat := time.NewTicker(h.streamConfig.HeartbeatInterval)
+		defer heartbeat.Stop()
+
+		deadline := time.NewTimer(h.streamConfig.MaxDuration)
+		defer deadline.Stop()
+
+		fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds())
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				if err := writeStatusEvent(w, change); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			case <-deadline.C:
+				return
+			}
+
+			// A failed flush means the client has gone away.
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+func writeStatusEvent(w *bufio.Writer, change *domain.StatusChange) error {
+	data, err := json.Marshal(dto.OrderStatusEvent{
+		OrderID:        change.OrderID.String(),
+		UserID:         change.UserID.String(),
+		Status:         string(change.Status),
+		PreviousStatus: string(change.PreviousStatus),
+		Version:        change.Version,
+		OccurredAt:     change.OccurredAt.Format(consts.FormatTimeLayout),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: status\ndata: %s\n\n", change.ID, data)
+	return err
+}
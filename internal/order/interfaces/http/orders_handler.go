@@ -1,7 +1,6 @@
 package http
 
 import (
-	"context"
 	"net/http"
 	"strconv"
 
@@ -10,20 +9,28 @@ import (
 	"github.com/BlackRRR/Irtea-test/internal/order/domain"
 	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
 	userDomain "github.com/BlackRRR/Irtea-test/internal/user/domain"
-	"errors"
 	"github.com/BlackRRR/Irtea-test/internal/order/interfaces/http/dto"
 	"github.com/BlackRRR/Irtea-test/pkg/consts"
+	"github.com/BlackRRR/Irtea-test/pkg/etag"
 	"github.com/google/uuid"
 	"github.com/BlackRRR/Irtea-test/pkg/validator"
 )
 
 type OrdersHandler struct {
 	orderService *app.OrderService
+	statusStream *app.StatusStreamService
+	streamConfig app.StatusStreamConfig
 }
 
-func NewOrdersHandler(orderService *app.OrderService) *OrdersHandler {
+func NewOrdersHandler(
+	orderService *app.OrderService,
+	statusStream *app.StatusStreamService,
+	streamConfig app.StatusStreamConfig,
+) *OrdersHandler {
 	return &OrdersHandler{
 		orderService: orderService,
+		statusStream: statusStream,
+		streamConfig: streamConfig,
 	}
 }
 
@@ -32,31 +39,35 @@ func (h *OrdersHandler) PlaceOrder(c *fiber.Ctx) error {
 
 	var req dto.PlaceOrderRequest
 	if err := validator.ReadRequest(c, &req); err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return err
 	}
 
 	userID, err := h.parseUserID(req.UserID)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid user ID format",
-		})
+		return ErrInvalidUserID
 	}
 
 	items := make([]app.OrderItemInput, 0, len(req.Items))
 	for _, itemReq := range req.Items {
 		productID, err := h.parseProductID(itemReq.ProductID)
 		if err != nil {
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid product ID format",
-			})
+			return ErrInvalidProductID
 		}
 
-		items = append(items, app.OrderItemInput{
+		item := app.OrderItemInput{
 			ProductID: productID,
 			Quantity:  itemReq.Quantity,
-		})
+		}
+
+		if itemReq.VariantID != "" {
+			variantID, err := h.parseVariantID(itemReq.VariantID)
+			if err != nil {
+				return ErrInvalidVariantID
+			}
+			item.VariantID = &variantID
+		}
+
+		items = append(items, item)
 	}
 
 	input := app.PlaceOrderInput{
@@ -66,23 +77,11 @@ func (h *OrdersHandler) PlaceOrder(c *fiber.Ctx) error {
 
 	order, err := h.orderService.PlaceOrder(ctx, input)
 	if err != nil {
-		switch {
-		case errors.Is(err, productDomain.ErrInsufficientStock):
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-				"error": "Insufficient stock for one or more items",
-			})
-		case errors.Is(err, productDomain.ErrProductNotFound):
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-				"error": "One or more products not found",
-			})
-		default:
-			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Internal server error",
-			})
-		}
+		return err
 	}
 
 	response := h.mapOrderToResponse(order)
+	etag.Set(c, order.Version)
 	return c.Status(http.StatusCreated).JSON(response)
 }
 
@@ -91,31 +90,21 @@ func (h *OrdersHandler) GetOrder(c *fiber.Ctx) error {
 
 	idParam := c.Params("id")
 	if idParam == "" {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Order ID is required",
-		})
+		return ErrInvalidOrderID
 	}
 
 	orderID, err := h.parseOrderID(idParam)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid order ID format",
-		})
+		return ErrInvalidOrderID
 	}
 
 	order, err := h.orderService.GetOrder(ctx, orderID)
 	if err != nil {
-		if errors.Is(err, domain.ErrOrderNotFound) {
-			return c.Status(http.StatusNotFound).JSON(fiber.Map{
-				"error": "Order not found",
-			})
-		}
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Internal server error",
-		})
+		return err
 	}
 
 	response := h.mapOrderToResponse(order)
+	etag.Set(c, order.Version)
 	return c.JSON(response)
 }
 
@@ -124,16 +113,12 @@ func (h *OrdersHandler) GetUserOrders(c *fiber.Ctx) error {
 
 	userIDParam := c.Params("userId")
 	if userIDParam == "" {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "User ID is required",
-		})
+		return ErrInvalidUserID
 	}
 
 	userID, err := h.parseUserID(userIDParam)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid user ID format",
-		})
+		return ErrInvalidUserID
 	}
 
 	limitParam := c.Query("limit", "10")
@@ -151,9 +136,7 @@ func (h *OrdersHandler) GetUserOrders(c *fiber.Ctx) error {
 
 	orders, err := h.orderService.GetUserOrders(ctx, userID, limit, offset)
 	if err != nil {
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Internal server error",
-		})
+		return err
 	}
 
 	responses := make([]dto.OrderResponse, 0, len(orders))
@@ -161,46 +144,41 @@ func (h *OrdersHandler) GetUserOrders(c *fiber.Ctx) error {
 		responses = append(responses, h.mapOrderToResponse(order))
 	}
 
-	return c.JSON(fiber.Map{
-		"orders": responses,
-		"limit":  limit,
-		"offset": offset,
+	return c.JSON(dto.OrderListResponse{
+		Orders: responses,
+		Limit:  limit,
+		Offset: offset,
 	})
 }
 
 func (h *OrdersHandler) ConfirmOrder(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	if idParam == "" {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Order ID is required",
-		})
+		return ErrInvalidOrderID
 	}
 
 	orderID, err := h.parseOrderID(idParam)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid order ID format",
-		})
+		return ErrInvalidOrderID
 	}
 
-	order, err := h.orderService.ConfirmOrder(context.Background(), orderID)
+	expectedVersion, err := etag.IfMatch(c)
 	if err != nil {
-		if errors.Is(err, domain.ErrOrderNotFound) {
-			return c.Status(http.StatusNotFound).JSON(fiber.Map{
-				"error": "Order not found",
-			})
-		}
-		if errors.Is(err, domain.ErrInvalidOrderStatus) {
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-				"error": "Cannot confirm order in current status",
-			})
-		}
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Internal server error",
-		})
+		return err
+	}
+
+	input := app.UpdateOrderStatusInput{
+		OrderID:         orderID,
+		ExpectedVersion: expectedVersion,
+	}
+
+	order, err := h.orderService.ConfirmOrder(c.UserContext(), input)
+	if err != nil {
+		return err
 	}
 
 	response := h.mapOrderToResponse(order)
+	etag.Set(c, order.Version)
 	return c.JSON(response)
 }
 
@@ -209,60 +187,75 @@ func (h *OrdersHandler) CancelOrder(c *fiber.Ctx) error {
 
 	idParam := c.Params("id")
 	if idParam == "" {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Order ID is required",
-		})
+		return ErrInvalidOrderID
 	}
 
 	orderID, err := h.parseOrderID(idParam)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid order ID format",
-		})
+		return ErrInvalidOrderID
 	}
 
-	order, err := h.orderService.CancelOrder(ctx, orderID)
+	expectedVersion, err := etag.IfMatch(c)
 	if err != nil {
-		if errors.Is(err, domain.ErrOrderNotFound) {
-			return c.Status(http.StatusNotFound).JSON(fiber.Map{
-				"error": "Order not found",
-			})
-		}
-		if errors.Is(err, domain.ErrInvalidOrderStatus) {
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-				"error": "Cannot cancel order in current status",
-			})
-		}
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Internal server error",
-		})
+		return err
+	}
+
+	input := app.UpdateOrderStatusInput{
+		OrderID:         orderID,
+		ExpectedVersion: expectedVersion,
+	}
+
+	order, err := h.orderService.CancelOrder(ctx, input)
+	if err != nil {
+		return err
 	}
 
 	response := h.mapOrderToResponse(order)
+	etag.Set(c, order.Version)
 	return c.JSON(response)
 }
 
 func (h *OrdersHandler) mapOrderToResponse(order *domain.Order) dto.OrderResponse {
 	items := make([]dto.OrderItemResponse, 0, len(order.Items))
 	for _, item := range order.Items {
-		items = append(items, dto.OrderItemResponse{
+		itemResponse := dto.OrderItemResponse{
 			ProductID:          item.ProductID.String(),
 			ProductDescription: item.ProductDescription,
 			ProductPrice:       item.ProductPrice.Amount(),
+			VariantSKU:         item.VariantSKU,
+			VariantOptions:     item.VariantOptions,
 			Quantity:           item.Quantity,
 			TotalPrice:         item.TotalPrice().Amount(),
-		})
+			Fulfillment:        string(item.Fulfillment),
+		}
+
+		if item.VariantID != nil {
+			itemResponse.VariantID = item.VariantID.String()
+		}
+
+		if item.ExpectedAt != nil {
+			itemResponse.ExpectedAt = item.ExpectedAt.Format(consts.FormatTimeLayout)
+		}
+
+		items = append(items, itemResponse)
 	}
 
-	return dto.OrderResponse{
+	response := dto.OrderResponse{
 		ID:         order.ID.String(),
 		UserID:     order.UserID.String(),
 		Items:      items,
 		Status:     string(order.Status),
 		TotalPrice: order.TotalPrice.Amount(),
+		Version:    order.Version,
 		CreatedAt:  order.CreatedAt.Format(consts.FormatTimeLayout),
 		UpdatedAt:  order.UpdatedAt.Format(consts.FormatTimeLayout),
 	}
+
+	if expectedAt := order.ExpectedAt(); expectedAt != nil {
+		response.ExpectedAt = expectedAt.Format(consts.FormatTimeLayout)
+	}
+
+	return response
 }
 
 func (h *OrdersHandler) parseOrderID(s string) (domain.OrderID, error) {
@@ -288,3 +281,11 @@ func (h *OrdersHandler) parseProductID(s string) (productDomain.ProductID, error
 	}
 	return productDomain.ProductID(id), err
 }
+
+func (h *OrdersHandler) parseVariantID(s string) (productDomain.VariantID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return productDomain.VariantID{}, err
+	}
+	return productDomain.VariantID(id), err
+}
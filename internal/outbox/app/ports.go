@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/internal/outbox/domain"
+)
+
+type Store interface {
+	// FetchDue locks up to limit messages due at now, oldest first. Messages
+	// locked by another relay are skipped.
+	FetchDue(ctx context.Context, now time.Time, limit int) ([]*domain.Message, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reschedule(ctx context.Context, message *domain.Message) error
+	// DeadLetter moves a message that ran out of attempts to the dead-letter
+	// table.
+	DeadLetter(ctx context.Context, message *domain.Message) error
+}
+
+// Publisher hands a message to the outside world. It may see the same
+// message more than once.
+type Publisher interface {
+	Publish(ctx context.Context, message *domain.Message) error
+}
+
+type TxManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BlackRRR/Irtea-test/pkg/schedule"
+)
+
+type RelayConfig struct {
+	BatchSize    int           `env:"BATCH_SIZE" envDefault:"100" validate:"gt=0"`
+	PollInterval time.Duration `env:"POLL_INTERVAL" envDefault:"1s" validate:"gt=0"`
+	MaxAttempts  int           `env:"MAX_ATTEMPTS" envDefault:"10" validate:"gt=0"`
+	BaseBackoff  time.Duration `env:"BASE_BACKOFF" envDefault:"1s" validate:"gt=0"`
+	MaxBackoff   time.Duration `env:"MAX_BACKOFF" envDefault:"10m" validate:"gt=0"`
+}
+
+// Relay delivers outbox messages to the publisher at least once. A message
+// is deleted only after it has been published; failed deliveries are
+// retried with exponential backoff and dead-lettered after MaxAttempts.
+// Messages of one aggregate are delivered in order unless one of them has
+// to be retried.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	txManager TxManager
+	config    RelayConfig
+}
+
+func NewRelay(store Store, publisher Publisher, txManager TxManager, config RelayConfig) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		txManager: txManager,
+		config:    config,
+	}
+}
+
+// Drain relays batches until no due messages are left and returns how many
+// messages were delivered.
+func (r *Relay) Drain(ctx context.Context) (int, error) {
+	return schedule.Drain(ctx, r.config.BatchSize, r.relayBatch)
+}
+
+// relayBatch handles one batch in a single transaction, so the fetched
+// messages stay locked until their outcome is stored.
+func (r *Relay) relayBatch(ctx context.Context) (int, int, error) {
+	var fetched, delivered int
+	err := r.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		now := time.Now()
+		messages, err := r.store.FetchDue(txCtx, now, r.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		fetched = len(messages)
+
+		for _, message := range messages {
+			publishErr := r.publisher.Publish(txCtx, message)
+			if publishErr == nil {
+				if err := r.store.Delete(txCtx, message.ID); err != nil {
+					return err
+				}
+				delivered++
+				continue
+			}
+
+			message.Fail(publishErr, now.Add(r.backoff(message.Attempts+1)))
+			if message.Attempts >= r.config.MaxAttempts {
+				err = r.store.DeadLetter(txCtx, message)
+			} else {
+				err = r.store.Reschedule(txCtx, message)
+			}
+			if err != nil {
+				return errors.Join(fmt.Errorf("message %s: %w", message.ID, publishErr), err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fetched, 0, err
+	}
+
+	return fetched, delivered, nil
+}
+
+func (r *Relay) backoff(attempt int) time.Duration {
+	return schedule.Backoff(r.config.BaseBackoff, r.config.MaxBackoff, attempt)
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/BlackRRR/Irtea-test/internal/outbox/domain"
+)
+
+type MockStore struct {
+	mock.Mock
+}
+
+func (m *MockStore) FetchDue(ctx context.Context, now time.Time, limit int) ([]*domain.Message, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockStore) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockStore) Reschedule(ctx context.Context, message *domain.Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockStore) DeadLetter(ctx context.Context, message *domain.Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, message *domain.Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+type MockTxManager struct{}
+
+func (m *MockTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var testRelayConfig = RelayConfig{
+	BatchSize:   10,
+	MaxAttempts: 3,
+	BaseBackoff: time.Second,
+	MaxBackoff:  time.Minute,
+}
+
+func newTestMessage(attempts int) *domain.Message {
+	return &domain.Message{ID: uuid.New(), EventType: "order.placed", Attempts: attempts}
+}
+
+func TestRelay_Drain_DeletesDelivered(t *testing.T) {
+	store := new(MockStore)
+	publisher := new(MockPublisher)
+	relay := NewRelay(store, publisher, &MockTxManager{}, testRelayConfig)
+
+	message := newTestMessage(0)
+	store.On("FetchDue", mock.Anything, mock.Anything, 10).Return([]*domain.Message{message}, nil)
+	publisher.On("Publish", mock.Anything, message).Return(nil)
+	store.On("Delete", mock.Anything, message.ID).Return(nil)
+
+	delivered, err := relay.Drain(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	store.AssertExpectations(t)
+}
+
+func TestRelay_Drain_ReschedulesWithBackoff(t *testing.T) {
+	store := new(MockStore)
+	publisher := new(MockPublisher)
+	relay := NewRelay(store, publisher, &MockTxManager{}, testRelayConfig)
+
+	message := newTestMessage(1)
+	store.On("FetchDue", mock.Anything, mock.Anything, 10).Return([]*domain.Message{message}, nil)
+	publisher.On("Publish", mock.Anything, message).Return(errors.New("broker unavailable"))
+	store.On("Reschedule", mock.Anything, message).Return(nil)
+
+	before := time.Now()
+	delivered, err := relay.Drain(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, 2, message.Attempts)
+	assert.Equal(t, "broker unavailable", message.LastError)
+	assert.WithinDuration(t, before.Add(2*time.Second), message.NextAttemptAt, time.Second)
+	store.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	store.AssertNotCalled(t, "DeadLetter", mock.Anything, mock.Anything)
+}
+
+func TestRelay_Drain_DeadLettersAfterMaxAttempts(t *testing.T) {
+	store := new(MockStore)
+	publisher := new(MockPublisher)
+	relay := NewRelay(store, publisher, &MockTxManager{}, testRelayConfig)
+
+	message := newTestMessage(2)
+	store.On("FetchDue", mock.Anything, mock.Anything, 10).Return([]*domain.Message{message}, nil)
+	publisher.On("Publish", mock.Anything, message).Return(errors.New("broker unavailable"))
+	store.On("DeadLetter", mock.Anything, message).Return(nil)
+
+	_, err := relay.Drain(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, message.Attempts)
+	store.AssertExpectations(t)
+	store.AssertNotCalled(t, "Reschedule", mock.Anything, mock.Anything)
+}
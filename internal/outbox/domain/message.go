@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
+)
+
+// Message is a domain event stored in the outbox until it is delivered.
+type Message struct {
+	ID            uuid.UUID
+	EventType     string
+	AggregateID   string
+	Payload       json.RawMessage
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+func NewMessage(e event.Event) (*Message, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Message{
+		ID:            uuid.New(),
+		EventType:     e.EventName(),
+		AggregateID:   e.AggregateID(),
+		Payload:       payload,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// Fail records a failed delivery and schedules the next attempt.
+func (m *Message) Fail(err error, retryAt time.Time) {
+	m.Attempts++
+	m.LastError = err.Error()
+	m.NextAttemptAt = retryAt
+}
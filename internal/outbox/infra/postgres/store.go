@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+	oService "github.com/BlackRRR/Irtea-test/internal/order/app"
+	outboxService "github.com/BlackRRR/Irtea-test/internal/outbox/app"
+	"github.com/BlackRRR/Irtea-test/internal/outbox/domain"
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
+)
+
+var (
+	_ outboxService.Store = (*Store)(nil)
+	_ oService.Outbox     = (*Store)(nil)
+	_ pService.Outbox     = (*Store)(nil)
+)
+
+type Store struct {
+	pool *pgxpool.Pool
+}
+
+func NewStore(pool *pgxpool.Pool) *Store {
+	return &Store{pool: pool}
+}
+
+// Save writes the events with the querier of ctx, so they commit or roll
+// back together with the caller's transaction.
+func (s *Store) Save(ctx context.Context, events ...event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(events))
+	eventTypes := make([]string, 0, len(events))
+	aggregateIDs := make([]string, 0, len(events))
+	payloads := make([]string, 0, len(events))
+	createdAts := make([]time.Time, 0, len(events))
+
+	for _, e := range events {
+		message, err := domain.NewMessage(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", e.EventName(), err)
+		}
+
+		ids = append(ids, message.ID.String())
+		eventTypes = append(eventTypes, message.EventType)
+		aggregateIDs = append(aggregateIDs, message.AggregateID)
+		payloads = append(payloads, string(message.Payload))
+		createdAts = append(createdAts, message.CreatedAt)
+	}
+
+	query := `
+		INSERT INTO outbox.message (id, event_type, aggregate_id, payload, next_attempt_at, created_at)
+		SELECT id, event_type, aggregate_id, payload, created_at, created_at
+		FROM UNNEST($1::uuid[], $2::varchar[], $3::varchar[], $4::jsonb[], $5::timestamptz[])
+		    AS m(id, event_type, aggregate_id, payload, created_at)
+	`
+
+	q := postgres.GetQuerier(ctx, s.pool)
+	if _, err := q.Exec(ctx, query, ids, eventTypes, aggregateIDs, payloads, createdAts); err != nil {
+		return fmt.Errorf("failed to save outbox messages: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) FetchDue(ctx context.Context, now time.Time, limit int) ([]*domain.Message, error) {
+	query := `
+		SELECT id, event_type, aggregate_id, payload, attempts, COALESCE(last_error, ''), next_attempt_at, created_at
+		FROM outbox.message
+		WHERE next_attempt_at <= $1
+		ORDER BY created_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	q := postgres.GetQuerier(ctx, s.pool)
+	rows, err := q.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		var message domain.Message
+		err := rows.Scan(
+			&message.ID,
+			&message.EventType,
+			&message.AggregateID,
+			&message.Payload,
+			&message.Attempts,
+			&message.LastError,
+			&message.NextAttemptAt,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+
+		messages = append(messages, &message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (s *Store) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM outbox.message WHERE id = $1`
+
+	q := postgres.GetQuerier(ctx, s.pool)
+	if _, err := q.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to delete outbox message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) Reschedule(ctx context.Context, message *domain.Message) error {
+	query := `
+		UPDATE outbox.message
+		SET attempts = $2, last_error = $3, next_attempt_at = $4
+		WHERE id = $1
+	`
+
+	q := postgres.GetQuerier(ctx, s.pool)
+	_, err := q.Exec(ctx, query, message.ID, message.Attempts, message.LastError, message.NextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule outbox message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeadLetter(ctx context.Context, message *domain.Message) error {
+	query := `
+		WITH moved AS (
+			DELETE FROM outbox.message WHERE id = $1
+			RETURNING id, event_type, aggregate_id, payload, created_at
+		)
+		INSERT INTO outbox.dead_letter (id, event_type, aggregate_id, payload, attempts, last_error, created_at, failed_at)
+		SELECT id, event_type, aggregate_id, payload, $2, $3, created_at, NOW()
+		FROM moved
+	`
+
+	q := postgres.GetQuerier(ctx, s.pool)
+	if _, err := q.Exec(ctx, query, message.ID, message.Attempts, message.LastError); err != nil {
+		return fmt.Errorf("failed to dead-letter outbox message: %w", err)
+	}
+
+	return nil
+}
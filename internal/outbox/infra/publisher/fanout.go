@@ -0,0 +1,29 @@
+package publisher
+
+import (
+	"context"
+
+	outboxService "github.com/BlackRRR/Irtea-test/internal/outbox/app"
+	"github.com/BlackRRR/Irtea-test/internal/outbox/domain"
+)
+
+var _ outboxService.Publisher = (*Fanout)(nil)
+
+// Fanout hands every message to the publishers in order and stops at the
+// first failure, after which the message is retried for all of them.
+type Fanout struct {
+	publishers []outboxService.Publisher
+}
+
+func NewFanout(publishers ...outboxService.Publisher) *Fanout {
+	return &Fanout{publishers: publishers}
+}
+
+func (f *Fanout) Publish(ctx context.Context, message *domain.Message) error {
+	for _, publisher := range f.publishers {
+		if err := publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
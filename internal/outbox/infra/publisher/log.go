@@ -0,0 +1,31 @@
+package publisher
+
+import (
+	"context"
+	"log/slog"
+
+	outboxService "github.com/BlackRRR/Irtea-test/internal/outbox/app"
+	"github.com/BlackRRR/Irtea-test/internal/outbox/domain"
+)
+
+var _ outboxService.Publisher = (*LogPublisher)(nil)
+
+// LogPublisher writes every message to the log. It stands in for a message
+// broker until one is configured.
+type LogPublisher struct {
+	logger *slog.Logger
+}
+
+func NewLogPublisher(logger *slog.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, message *domain.Message) error {
+	p.logger.InfoContext(ctx, "Domain event published",
+		slog.String("id", message.ID.String()),
+		slog.String("event_type", message.EventType),
+		slog.String("aggregate_id", message.AggregateID),
+		slog.String("payload", string(message.Payload)),
+	)
+	return nil
+}
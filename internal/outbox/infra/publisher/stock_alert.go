@@ -0,0 +1,37 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	outboxService "github.com/BlackRRR/Irtea-test/internal/outbox/app"
+	"github.com/BlackRRR/Irtea-test/internal/outbox/domain"
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+	productDomain "github.com/BlackRRR/Irtea-test/internal/product/domain"
+)
+
+var _ outboxService.Publisher = (*StockAlertPublisher)(nil)
+
+// StockAlertPublisher sends low-stock alerts to the stock notifier once the
+// change that opened them has committed. Other events are ignored.
+type StockAlertPublisher struct {
+	inventoryService *pService.InventoryService
+}
+
+func NewStockAlertPublisher(inventoryService *pService.InventoryService) *StockAlertPublisher {
+	return &StockAlertPublisher{inventoryService: inventoryService}
+}
+
+func (p *StockAlertPublisher) Publish(ctx context.Context, message *domain.Message) error {
+	if message.EventType != productDomain.EventProductStockLow {
+		return nil
+	}
+
+	var alert productDomain.ProductStockLow
+	if err := json.Unmarshal(message.Payload, &alert); err != nil {
+		return fmt.Errorf("failed to decode stock alert: %w", err)
+	}
+
+	return p.inventoryService.NotifyLowStock(ctx, alert)
+}
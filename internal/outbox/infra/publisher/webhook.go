@@ -0,0 +1,30 @@
+package publisher
+
+import (
+	"context"
+
+	outboxService "github.com/BlackRRR/Irtea-test/internal/outbox/app"
+	"github.com/BlackRRR/Irtea-test/internal/outbox/domain"
+	wService "github.com/BlackRRR/Irtea-test/internal/webhook/app"
+)
+
+var _ outboxService.Publisher = (*WebhookPublisher)(nil)
+
+// WebhookPublisher queues a webhook delivery for every subscription that
+// wants the event. Deliveries are written in the relay's transaction and
+// commit together with the removal of the message from the outbox.
+type WebhookPublisher struct {
+	webhookService *wService.WebhookService
+}
+
+func NewWebhookPublisher(webhookService *wService.WebhookService) *WebhookPublisher {
+	return &WebhookPublisher{webhookService: webhookService}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, message *domain.Message) error {
+	return p.webhookService.Enqueue(ctx, wService.EnqueueInput{
+		EventID:   message.ID,
+		EventType: message.EventType,
+		Payload:   message.Payload,
+	})
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/errcode"
+)
+
+const exportPageSize = 500
+
+var (
+	errImportRejected = errors.New("import rejected")
+	errImportStore    = errcode.New("import-store-failed", "failed to store product")
+)
+
+// ImportProducts validates every row through the same rules as CreateProduct
+// and stores the valid ones according to the requested mode. Rows are consumed
+// one at a time, so only the raw file is held in memory, not every product.
+func (s *ProductService) ImportProducts(ctx context.Context, input ImportProductsInput) (*ImportReport, error) {
+	if !input.Mode.IsValid() {
+		return nil, ErrInvalidImportMode
+	}
+
+	report := &ImportReport{
+		Mode:   input.Mode,
+		DryRun: input.DryRun,
+		Errors: []ImportRowError{},
+	}
+
+	if input.DryRun {
+		for row := range input.Rows {
+			report.Total++
+			if _, err := importRowProduct(row); err != nil {
+				report.reject(row.Line, err)
+			}
+		}
+		return report, nil
+	}
+
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		for row := range input.Rows {
+			report.Total++
+
+			product, err := importRowProduct(row)
+			if err != nil {
+				report.reject(row.Line, err)
+				continue
+			}
+
+			if input.Mode == ImportModeAtomic && report.Failed > 0 {
+				// Keep validating the rest for the report, nothing will be stored.
+				continue
+			}
+
+			err = s.txManager.WithTx(txCtx, func(rowCtx context.Context) error {
+				return s.productRepo.Create(rowCtx, product)
+			})
+			if err != nil {
+				// Domain errors tell the client what to fix; anything else
+				// is ours and only logged.
+				if _, known := errcode.Of(err); !known {
+					s.logger.ErrorContext(ctx, "Failed to store imported product",
+						slog.Int("line", row.Line),
+						slog.Any("error", err),
+					)
+					err = errImportStore
+				}
+				report.reject(row.Line, err)
+				continue
+			}
+
+			report.Imported++
+		}
+
+		if input.Mode == ImportModeAtomic && report.Failed > 0 {
+			return errImportRejected
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errImportRejected) {
+		report.Imported = 0
+		return report, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// CatalogExport walks the active catalog, see ExportProducts.
+type CatalogExport struct {
+	productRepo ProductRepo
+	first       []*domain.Product
+}
+
+// ExportProducts reads the first page of the active catalog, so a failing
+// repository is reported before anything has been sent.
+func (s *ProductService) ExportProducts(ctx context.Context) (*CatalogExport, error) {
+	first, err := s.productRepo.GetAllAfter(ctx, nil, exportPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CatalogExport{productRepo: s.productRepo, first: first}, nil
+}
+
+// Each hands every product to fn page by page, stopping at the first error
+// fn or the repository returns. Each page starts after the last product of the
+// previous one, so concurrent writes neither skip nor repeat products.
+func (e *CatalogExport) Each(ctx context.Context, fn func(*domain.Product) error) error {
+	products := e.first
+	for {
+		for _, product := range products {
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+
+		if len(products) < exportPageSize {
+			return nil
+		}
+
+		last := products[len(products)-1]
+		after := &ProductCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+
+		var err error
+		if products, err = e.productRepo.GetAllAfter(ctx, after, exportPageSize); err != nil {
+			return err
+		}
+	}
+}
+
+func importRowProduct(row ImportRow) (*domain.Product, error) {
+	if row.Err != nil {
+		return nil, row.Err
+	}
+	return newProduct(row.Input)
+}
+
+func (r *ImportReport) reject(line int, err error) {
+	r.Failed++
+
+	rowErr := ImportRowError{Line: line, Error: err.Error()}
+	// Report the coded error itself, without the wrapping added on its way
+	// up from the repository.
+	var coded *errcode.Error
+	if errors.As(err, &coded) {
+		rowErr.Code = coded.Code()
+		rowErr.Error = coded.Error()
+	}
+	r.Errors = append(r.Errors, rowErr)
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
+)
+
+type MockProductRepo struct {
+	mock.Mock
+}
+
+func (m *MockProductRepo) Create(ctx context.Context, product *domain.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) GetByID(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) GetAll(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) GetAllAfter(ctx context.Context, after *ProductCursor, limit int) ([]*domain.Product, error) {
+	args := m.Called(ctx, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) GetLowStock(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) Update(ctx context.Context, product *domain.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) Delete(ctx context.Context, id domain.ProductID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockTxManager struct{}
+
+func (m *MockTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type MockOutbox struct {
+	mock.Mock
+}
+
+func (m *MockOutbox) Save(ctx context.Context, events ...event.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func newTestOutbox() *MockOutbox {
+	outbox := new(MockOutbox)
+	outbox.On("Save", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return outbox
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func importRows() []ImportRow {
+	return []ImportRow{
+		{Line: 2, Input: CreateProductInput{Description: "Mug", Price: decimal.NewFromInt(5), Quantity: 10}},
+		{Line: 3, Input: CreateProductInput{Description: "  ", Price: decimal.NewFromInt(5), Quantity: 10}},
+		{Line: 4, Err: errors.New("invalid json")},
+		{Line: 5, Input: CreateProductInput{Description: "Plate", Price: decimal.NewFromInt(7), Quantity: 3}},
+	}
+}
+
+func TestProductService_ImportProducts_DryRun(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	report, err := service.ImportProducts(context.Background(), ImportProductsInput{
+		Rows:   slices.Values(importRows()),
+		Mode:   ImportModeBestEffort,
+		DryRun: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 0, report.Imported)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, []ImportRowError{
+		{Line: 3, Code: "empty-description", Error: domain.ErrProductDescCannotBeEmpty.Error()},
+		{Line: 4, Error: "invalid json"},
+	}, report.Errors)
+	mockRepo.AssertNotCalled(t, "Create")
+}
+
+func TestProductService_ImportProducts_BestEffort(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	report, err := service.ImportProducts(context.Background(), ImportProductsInput{
+		Rows: slices.Values(importRows()),
+		Mode: ImportModeBestEffort,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, 2, report.Failed)
+	mockRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestProductService_ImportProducts_StoreErrors(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	isMug := func(p *domain.Product) bool { return p.Description == "Mug" }
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(isMug)).
+		Return(fmt.Errorf("failed to upsert variants: %w", domain.ErrDuplicateSKU))
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Product) bool { return !isMug(p) })).
+		Return(errors.New("failed to create product: connection reset"))
+
+	report, err := service.ImportProducts(context.Background(), ImportProductsInput{
+		Rows: slices.Values([]ImportRow{importRows()[0], importRows()[3]}),
+		Mode: ImportModeBestEffort,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ImportRowError{
+		{Line: 2, Code: "duplicate-sku", Error: domain.ErrDuplicateSKU.Error()},
+		{Line: 5, Code: "import-store-failed", Error: errImportStore.Error()},
+	}, report.Errors)
+}
+
+func TestProductService_ImportProducts_AtomicRejectsAll(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	report, err := service.ImportProducts(context.Background(), ImportProductsInput{
+		Rows: slices.Values(importRows()),
+		Mode: ImportModeAtomic,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 0, report.Imported)
+	assert.Equal(t, 2, report.Failed)
+}
+
+func TestProductService_ImportProducts_InvalidMode(t *testing.T) {
+	service := NewProductService(new(MockProductRepo), &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	report, err := service.ImportProducts(context.Background(), ImportProductsInput{
+		Rows: slices.Values(importRows()),
+		Mode: "partial",
+	})
+
+	assert.Nil(t, report)
+	assert.Equal(t, ErrInvalidImportMode, err)
+}
+
+func TestProductService_UpdatePrice_SavesEvent(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	outbox := new(MockOutbox)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), outbox, discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("Update", mock.Anything, product).Return(nil)
+	outbox.On("Save", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		if len(events) != 1 {
+			return false
+		}
+		changed, ok := events[0].(domain.ProductPriceChanged)
+		return ok && changed.ProductID == uuid.UUID(product.ID) && changed.VariantID == nil &&
+			changed.OldPrice.Equal(decimal.NewFromInt(3)) && changed.NewPrice.Equal(decimal.NewFromInt(4))
+	})).Return(nil)
+
+	_, err := service.UpdatePrice(context.Background(), UpdatePriceInput{ProductID: product.ID, Price: decimal.NewFromInt(4)})
+
+	assert.NoError(t, err)
+	outbox.AssertExpectations(t)
+	assert.Empty(t, product.PullEvents())
+}
+
+func TestProductService_ExportProducts(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	page := make([]*domain.Product, exportPageSize)
+	for i := range page {
+		page[i] = newInventoryTestProduct(1, 0)
+	}
+	last := page[len(page)-1]
+	mockRepo.On("GetAllAfter", mock.Anything, (*ProductCursor)(nil), exportPageSize).Return(page, nil).Once()
+	mockRepo.On("GetAllAfter", mock.Anything, &ProductCursor{CreatedAt: last.CreatedAt, ID: last.ID}, exportPageSize).
+		Return(page[:1], nil).Once()
+
+	export, err := service.ExportProducts(context.Background())
+	assert.NoError(t, err)
+
+	var exported int
+	err = export.Each(context.Background(), func(*domain.Product) error {
+		exported++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, exportPageSize+1, exported)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProductService_ExportProducts_FirstPageFails(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	errDown := errors.New("connection refused")
+	mockRepo.On("GetAllAfter", mock.Anything, (*ProductCursor)(nil), exportPageSize).Return(nil, errDown)
+
+	export, err := service.ExportProducts(context.Background())
+	assert.Nil(t, export)
+	assert.ErrorIs(t, err, errDown)
+}
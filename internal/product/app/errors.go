@@ -0,0 +1,48 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/BlackRRR/Irtea-test/pkg/errcode"
+)
+
+// FieldError ties a rejected value to the input field it came from.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// ValidationError collects every field that failed validation so clients can
+// fix them in one round trip.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Add(field string, err error) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Err: err})
+}
+
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Fields) > 0
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		parts = append(parts, field.Field+": "+field.Err.Error())
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		errs = append(errs, field.Err)
+	}
+	return errs
+}
+
+var (
+	ErrInvalidImportMode  = errcode.New("invalid-import-mode", "import mode must be atomic or best_effort")
+	ErrInvalidAlertStatus = errcode.New("invalid-alert-status", "alert status must be open, resolved or all")
+)
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+)
+
+type ImageConfig struct {
+	MaxSize       int64 `env:"MAX_SIZE" envDefault:"10485760"`
+	MaxPixels     int   `env:"MAX_PIXELS" envDefault:"40000000"`
+	ThumbnailSize int   `env:"THUMBNAIL_SIZE" envDefault:"320"`
+}
+
+type ImageService struct {
+	productRepo ProductRepo
+	txManager   TxManager
+	blobStore   BlobStore
+	config      ImageConfig
+}
+
+func NewImageService(productRepo ProductRepo, txManager TxManager, blobStore BlobStore, config ImageConfig) *ImageService {
+	return &ImageService{
+		productRepo: productRepo,
+		txManager:   txManager,
+		blobStore:   blobStore,
+		config:      config,
+	}
+}
+
+// UploadImage stores the original and its thumbnail, then attaches them to
+// the product. Blobs are written first and removed again if the product
+// update fails, so the database never points at a missing object.
+func (s *ImageService) UploadImage(ctx context.Context, input UploadImageInput) (*domain.Product, error) {
+	product, err := s.productRepo.GetByID(ctx, input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = product.CheckVersion(input.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	if len(product.Images) >= domain.MaxProductImages {
+		return nil, domain.ErrTooManyImages
+	}
+
+	data, err := io.ReadAll(io.LimitReader(input.Body, s.config.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > s.config.MaxSize {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	processed, err := processImage(data, s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &domain.Image{
+		ID:          domain.NewImageID(),
+		ContentType: processed.contentType,
+		Size:        int64(len(data)),
+		Width:       processed.width,
+		Height:      processed.height,
+		CreatedAt:   time.Now(),
+	}
+
+	prefix := "products/" + input.ProductID.String() + "/" + image.ID.String()
+	image.Key = prefix + processed.ext
+	image.ThumbnailKey = prefix + "_thumb" + processed.thumbnailExt
+
+	err = s.blobStore.Put(ctx, image.Key, bytes.NewReader(data), int64(len(data)), image.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.blobStore.Put(ctx, image.ThumbnailKey, bytes.NewReader(processed.thumbnail),
+		int64(len(processed.thumbnail)), processed.thumbnailContentType)
+	if err != nil {
+		s.deleteBlobs(ctx, image)
+		return nil, err
+	}
+
+	updatedProduct, err := s.updateProduct(ctx, input.ProductID, input.ExpectedVersion, func(product *domain.Product) error {
+		return product.AddImage(image)
+	})
+	if err != nil {
+		s.deleteBlobs(ctx, image)
+		return nil, err
+	}
+
+	return updatedProduct, nil
+}
+
+func (s *ImageService) DeleteImage(ctx context.Context, input DeleteImageInput) (*domain.Product, error) {
+	var removed *domain.Image
+	product, err := s.updateProduct(ctx, input.ProductID, input.ExpectedVersion, func(product *domain.Product) error {
+		var err error
+		removed, err = product.RemoveImage(input.ImageID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.deleteBlobs(ctx, removed)
+	return product, nil
+}
+
+func (s *ImageService) ReorderImages(ctx context.Context, input ReorderImagesInput) (*domain.Product, error) {
+	return s.updateProduct(ctx, input.ProductID, input.ExpectedVersion, func(product *domain.Product) error {
+		return product.ReorderImages(input.ImageIDs)
+	})
+}
+
+func (s *ImageService) SetPrimaryImage(ctx context.Context, input SetPrimaryImageInput) (*domain.Product, error) {
+	return s.updateProduct(ctx, input.ProductID, input.ExpectedVersion, func(product *domain.Product) error {
+		return product.SetPrimaryImage(input.ImageID)
+	})
+}
+
+func (s *ImageService) URL(key string) string {
+	return s.blobStore.URL(key)
+}
+
+func (s *ImageService) updateProduct(
+	ctx context.Context,
+	productID domain.ProductID,
+	expectedVersion *int,
+	mutate func(product *domain.Product) error,
+) (*domain.Product, error) {
+	var updatedProduct *domain.Product
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, productID)
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = mutate(product)
+		if err != nil {
+			return err
+		}
+
+		err = s.productRepo.Update(txCtx, product)
+		if err != nil {
+			return err
+		}
+
+		updatedProduct = product
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedProduct, nil
+}
+
+// deleteBlobs is best effort: an orphaned object is harmless, while failing
+// an otherwise successful request is not.
+func (s *ImageService) deleteBlobs(ctx context.Context, image *domain.Image) {
+	_ = s.blobStore.Delete(ctx, image.Key)
+	_ = s.blobStore.Delete(ctx, image.ThumbnailKey)
+}
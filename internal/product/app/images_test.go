@@ -0,0 +1,178 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+)
+
+type MockBlobStore struct {
+	mock.Mock
+	objects map[string][]byte
+}
+
+func (m *MockBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	args := m.Called(ctx, key, size, contentType)
+	if args.Error(0) == nil {
+		data, _ := io.ReadAll(body)
+		m.objects[key] = data
+	}
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	delete(m.objects, key)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) URL(key string) string {
+	return "/media/" + key
+}
+
+func newImageTestProduct() *domain.Product {
+	price, _ := domain.NewMoney(decimal.NewFromInt(10))
+	inventory, _ := domain.NewInventory(1)
+	product, _ := domain.NewProduct("Lamp", nil, price, inventory)
+	return product
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func newTestImageService(repo *MockProductRepo, blobs *MockBlobStore) *ImageService {
+	return NewImageService(repo, &MockTxManager{}, blobs, ImageConfig{
+		MaxSize:       1 << 20,
+		MaxPixels:     4_000_000,
+		ThumbnailSize: 64,
+	})
+}
+
+func TestImageService_UploadImage(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	blobs := &MockBlobStore{objects: map[string][]byte{}}
+	service := newTestImageService(mockRepo, blobs)
+
+	product := newImageTestProduct()
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("Update", mock.Anything, product).Return(nil)
+	blobs.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	result, err := service.UploadImage(context.Background(), UploadImageInput{
+		ProductID: product.ID,
+		Body:      bytes.NewReader(encodePNG(t, 200, 100)),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Images, 1)
+
+	uploaded := result.Images[0]
+	assert.True(t, uploaded.IsPrimary)
+	assert.Equal(t, "image/png", uploaded.ContentType)
+	assert.Equal(t, 200, uploaded.Width)
+	assert.Equal(t, 100, uploaded.Height)
+	assert.True(t, strings.HasPrefix(uploaded.Key, "products/"+product.ID.String()+"/"))
+	assert.True(t, strings.HasSuffix(uploaded.ThumbnailKey, "_thumb.png"))
+
+	thumb, err := png.Decode(bytes.NewReader(blobs.objects[uploaded.ThumbnailKey]))
+	require.NoError(t, err)
+	assert.Equal(t, 64, thumb.Bounds().Dx())
+	assert.Equal(t, 32, thumb.Bounds().Dy())
+}
+
+func TestImageService_UploadImage_UnsupportedType(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	blobs := &MockBlobStore{objects: map[string][]byte{}}
+	service := newTestImageService(mockRepo, blobs)
+
+	product := newImageTestProduct()
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	_, err := service.UploadImage(context.Background(), UploadImageInput{
+		ProductID: product.ID,
+		Body:      strings.NewReader("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"),
+	})
+
+	assert.Equal(t, domain.ErrUnsupportedImageType, err)
+	blobs.AssertNotCalled(t, "Put")
+}
+
+func TestImageService_UploadImage_TooLarge(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	blobs := &MockBlobStore{objects: map[string][]byte{}}
+	service := NewImageService(mockRepo, &MockTxManager{}, blobs, ImageConfig{MaxSize: 16, MaxPixels: 100, ThumbnailSize: 8})
+
+	product := newImageTestProduct()
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	_, err := service.UploadImage(context.Background(), UploadImageInput{
+		ProductID: product.ID,
+		Body:      bytes.NewReader(encodePNG(t, 4, 4)),
+	})
+
+	assert.Equal(t, domain.ErrImageTooLarge, err)
+	blobs.AssertNotCalled(t, "Put")
+}
+
+func TestImageService_UploadImage_RemovesBlobsOnFailure(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	blobs := &MockBlobStore{objects: map[string][]byte{}}
+	service := newTestImageService(mockRepo, blobs)
+
+	product := newImageTestProduct()
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("Update", mock.Anything, product).Return(errors.New("db down"))
+	blobs.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	blobs.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.UploadImage(context.Background(), UploadImageInput{
+		ProductID: product.ID,
+		Body:      bytes.NewReader(encodePNG(t, 10, 10)),
+	})
+
+	assert.Error(t, err)
+	assert.Empty(t, blobs.objects)
+	blobs.AssertNumberOfCalls(t, "Delete", 2)
+}
+
+func TestImageService_DeleteImage(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	blobs := &MockBlobStore{objects: map[string][]byte{}}
+	service := newTestImageService(mockRepo, blobs)
+
+	product := newImageTestProduct()
+	image := &domain.Image{ID: domain.NewImageID(), Key: "a.png", ThumbnailKey: "a_thumb.png"}
+	require.NoError(t, product.AddImage(image))
+
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("Update", mock.Anything, product).Return(nil)
+	blobs.On("Delete", mock.Anything, "a.png").Return(nil)
+	blobs.On("Delete", mock.Anything, "a_thumb.png").Return(nil)
+
+	result, err := service.DeleteImage(context.Background(), DeleteImageInput{
+		ProductID: product.ID,
+		ImageID:   image.ID,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Images)
+	blobs.AssertExpectations(t)
+}
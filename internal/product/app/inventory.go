@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+)
+
+const digestPageSize = 500
+
+var _ StockObserver = (*InventoryService)(nil)
+
+// InventoryService watches stock levels against reorder thresholds, keeps
+// the stock alert log and notifies about products that need restocking.
+type InventoryService struct {
+	productRepo ProductRepo
+	alertRepo   StockAlertRepo
+	notifier    Notifier
+	outbox      Outbox
+}
+
+func NewInventoryService(productRepo ProductRepo, alertRepo StockAlertRepo, notifier Notifier, outbox Outbox) *InventoryService {
+	return &InventoryService{
+		productRepo: productRepo,
+		alertRepo:   alertRepo,
+		notifier:    notifier,
+		outbox:      outbox,
+	}
+}
+
+// StockChanged opens an alert when the product has just dropped to its
+// threshold and resolves it once stock is back above. Staying low does not
+// raise a new alert. A new alert is saved to the outbox rather than sent, so
+// the notifier only hears about stock changes that commit.
+func (s *InventoryService) StockChanged(ctx context.Context, product *domain.Product, wasLow bool) error {
+	isLow := product.IsLowStock()
+
+	switch {
+	case isLow && !wasLow:
+		alert, err := domain.NewStockAlert(product)
+		if err != nil {
+			return err
+		}
+
+		err = s.alertRepo.Create(ctx, alert)
+		if errors.Is(err, domain.ErrStockAlertAlreadyOpen) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Save(ctx, domain.ProductStockLow{
+			ProductID:   uuid.UUID(alert.ProductID),
+			Description: alert.Description,
+			Quantity:    alert.Quantity,
+			Threshold:   alert.Threshold,
+			OccurredAt:  alert.CreatedAt,
+		})
+	case !isLow && wasLow:
+		return s.alertRepo.Resolve(ctx, product.ID, time.Now())
+	}
+
+	return nil
+}
+
+func (s *InventoryService) ListAlerts(ctx context.Context, status AlertStatus, limit, offset int) ([]*domain.StockAlert, error) {
+	if !status.IsValid() {
+		return nil, ErrInvalidAlertStatus
+	}
+
+	return s.alertRepo.List(ctx, status, limit, offset)
+}
+
+// SendDigest notifies about every active product at or below its threshold.
+// Nothing is sent when all products are stocked.
+func (s *InventoryService) SendDigest(ctx context.Context) error {
+	var items []LowStockItem
+	for offset := 0; ; offset += digestPageSize {
+		products, err := s.productRepo.GetLowStock(ctx, digestPageSize, offset)
+		if err != nil {
+			return err
+		}
+
+		for _, product := range products {
+			items = append(items, lowStockItem(product))
+		}
+
+		if len(products) < digestPageSize {
+			break
+		}
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	return s.notifier.Notify(ctx, Notification{
+		Event:   NotificationLowStockDigest,
+		Subject: fmt.Sprintf("Daily stock digest: %d products below reorder threshold", len(items)),
+		Items:   items,
+		SentAt:  time.Now(),
+	})
+}
+
+// NotifyLowStock sends an alert opened by StockChanged. The outbox relay
+// calls it after the stock change has committed and retries it on failure.
+func (s *InventoryService) NotifyLowStock(ctx context.Context, alert domain.ProductStockLow) error {
+	return s.notifier.Notify(ctx, Notification{
+		Event:   NotificationLowStock,
+		Subject: fmt.Sprintf("Low stock: %s (%d left)", alert.Description, alert.Quantity),
+		Items: []LowStockItem{{
+			ProductID:   alert.ProductID.String(),
+			Description: alert.Description,
+			Quantity:    alert.Quantity,
+			Threshold:   alert.Threshold,
+		}},
+		SentAt: time.Now(),
+	})
+}
+
+func lowStockItem(product *domain.Product) LowStockItem {
+	return LowStockItem{
+		ProductID:   product.ID.String(),
+		Description: product.Description,
+		Quantity:    product.Inventory.Available(),
+		Threshold:   *product.ReorderThreshold,
+	}
+}
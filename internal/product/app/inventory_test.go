@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
+)
+
+type MockStockObserver struct {
+	mock.Mock
+}
+
+func (m *MockStockObserver) StockChanged(ctx context.Context, product *domain.Product, wasLow bool) error {
+	args := m.Called(ctx, product, wasLow)
+	return args.Error(0)
+}
+
+type MockStockAlertRepo struct {
+	mock.Mock
+}
+
+func (m *MockStockAlertRepo) Create(ctx context.Context, alert *domain.StockAlert) error {
+	args := m.Called(ctx, alert)
+	return args.Error(0)
+}
+
+func (m *MockStockAlertRepo) Resolve(ctx context.Context, productID domain.ProductID, resolvedAt time.Time) error {
+	args := m.Called(ctx, productID, resolvedAt)
+	return args.Error(0)
+}
+
+func (m *MockStockAlertRepo) List(ctx context.Context, status AlertStatus, limit, offset int) ([]*domain.StockAlert, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.StockAlert), args.Error(1)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, notification Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func newInventoryTestProduct(quantity, threshold int) *domain.Product {
+	price, _ := domain.NewMoney(decimal.NewFromInt(3))
+	inventory, _ := domain.NewInventory(quantity)
+	product, _ := domain.NewProduct("Notebook", nil, price, inventory)
+	_ = product.SetReorderThreshold(&threshold)
+	return product
+}
+
+func newTestInventoryService(repo *MockProductRepo, alerts *MockStockAlertRepo, notifier *MockNotifier) *InventoryService {
+	return NewInventoryService(repo, alerts, notifier, newTestOutbox())
+}
+
+func TestInventoryService_StockChanged_SavesAlertForLater(t *testing.T) {
+	alerts := new(MockStockAlertRepo)
+	notifier := new(MockNotifier)
+	outbox := new(MockOutbox)
+	service := NewInventoryService(new(MockProductRepo), alerts, notifier, outbox)
+
+	product := newInventoryTestProduct(1, 5)
+	alerts.On("Create", mock.Anything, mock.Anything).Return(nil)
+	outbox.On("Save", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		if len(events) != 1 {
+			return false
+		}
+		low, ok := events[0].(domain.ProductStockLow)
+		return ok && low.ProductID == uuid.UUID(product.ID) && low.Quantity == 1 && low.Threshold == 5
+	})).Return(nil)
+
+	assert.NoError(t, service.StockChanged(context.Background(), product, false))
+	outbox.AssertExpectations(t)
+	notifier.AssertNotCalled(t, "Notify")
+}
+
+func TestInventoryService_NotifyLowStock(t *testing.T) {
+	notifier := new(MockNotifier)
+	service := newTestInventoryService(new(MockProductRepo), new(MockStockAlertRepo), notifier)
+
+	alert := domain.ProductStockLow{ProductID: uuid.New(), Description: "Notebook", Quantity: 1, Threshold: 5}
+	errDown := errors.New("webhook down")
+	notifier.On("Notify", mock.Anything, mock.MatchedBy(func(n Notification) bool {
+		return n.Event == NotificationLowStock && n.Subject == "Low stock: Notebook (1 left)" &&
+			len(n.Items) == 1 && n.Items[0].ProductID == alert.ProductID.String()
+	})).Return(errDown)
+
+	assert.ErrorIs(t, service.NotifyLowStock(context.Background(), alert), errDown)
+}
+
+func TestInventoryService_StockChanged_AlertAlreadyOpen(t *testing.T) {
+	alerts := new(MockStockAlertRepo)
+	notifier := new(MockNotifier)
+	service := newTestInventoryService(new(MockProductRepo), alerts, notifier)
+
+	product := newInventoryTestProduct(1, 5)
+	alerts.On("Create", mock.Anything, mock.Anything).Return(domain.ErrStockAlertAlreadyOpen)
+
+	assert.NoError(t, service.StockChanged(context.Background(), product, false))
+	notifier.AssertNotCalled(t, "Notify")
+}
+
+func TestInventoryService_StockChanged_Restocked(t *testing.T) {
+	alerts := new(MockStockAlertRepo)
+	service := newTestInventoryService(new(MockProductRepo), alerts, new(MockNotifier))
+
+	product := newInventoryTestProduct(10, 5)
+	alerts.On("Resolve", mock.Anything, product.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	assert.NoError(t, service.StockChanged(context.Background(), product, true))
+	alerts.AssertExpectations(t)
+}
+
+func TestInventoryService_SendDigest(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	notifier := new(MockNotifier)
+	service := newTestInventoryService(mockRepo, new(MockStockAlertRepo), notifier)
+
+	low := []*domain.Product{newInventoryTestProduct(0, 2), newInventoryTestProduct(1, 2)}
+	mockRepo.On("GetLowStock", mock.Anything, digestPageSize, 0).Return(low, nil)
+	notifier.On("Notify", mock.Anything, mock.MatchedBy(func(n Notification) bool {
+		return n.Event == NotificationLowStockDigest && len(n.Items) == 2
+	})).Return(nil)
+
+	assert.NoError(t, service.SendDigest(context.Background()))
+	notifier.AssertExpectations(t)
+}
+
+func TestInventoryService_SendDigest_NothingLow(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	notifier := new(MockNotifier)
+	service := newTestInventoryService(mockRepo, new(MockStockAlertRepo), notifier)
+
+	mockRepo.On("GetLowStock", mock.Anything, digestPageSize, 0).Return([]*domain.Product{}, nil)
+
+	assert.NoError(t, service.SendDigest(context.Background()))
+	notifier.AssertNotCalled(t, "Notify")
+}
+
+func TestProductService_AdjustStock_NotifiesObserver(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := NewProductService(mockRepo, &MockTxManager{}, observer, newTestOutbox(), discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("Update", mock.Anything, product).Return(nil)
+	observer.On("StockChanged", mock.Anything, product, false).Return(nil)
+
+	updated, err := service.AdjustStock(context.Background(), AdjustStockInput{ProductID: product.ID, Quantity: -6})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, updated.Inventory.Quantity())
+	observer.AssertExpectations(t)
+}
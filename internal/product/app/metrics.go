@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// stockOuts counts order lines that found too little stock on hand, either
+// rejected or queued as backorders.
+var stockOuts metric.Int64Counter
+
+func init() {
+	meter := otel.Meter("github.com/BlackRRR/Irtea-test/internal/product/app")
+
+	var err error
+	stockOuts, err = meter.Int64Counter("inventory.stockouts",
+		metric.WithDescription("Order lines that found too little stock, by outcome"))
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+func recordStockOut(ctx context.Context, outcome string) {
+	stockOuts.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+}
@@ -1,23 +1,205 @@
 package app
 
 import (
+	"io"
+	"iter"
+	"time"
+
 	"github.com/shopspring/decimal"
 	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/patch"
 )
 
+type OptionAxisInput struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
 type CreateProductInput struct {
-	Description string          `json:"description"`
-	Tags        []string        `json:"tags"`
-	Price       decimal.Decimal `json:"price"`
-	Quantity    int             `json:"quantity"`
+	Description       string            `json:"description"`
+	Tags              []string          `json:"tags"`
+	Price             decimal.Decimal   `json:"price"`
+	Quantity          int               `json:"quantity"`
+	Options           []OptionAxisInput `json:"options"`
+	ReorderThreshold  *int              `json:"reorder_threshold"`
+	BackorderLimit    *int              `json:"backorder_limit"`
+	RestockExpectedAt *time.Time        `json:"restock_expected_at"`
+	ReleaseDate       *time.Time        `json:"release_date"`
 }
 
 type UpdatePriceInput struct {
-	ProductID domain.ProductID `json:"product_id"`
-	Price     decimal.Decimal  `json:"price"`
+	ProductID       domain.ProductID `json:"product_id"`
+	Price           decimal.Decimal  `json:"price"`
+	ExpectedVersion *int             `json:"expected_version"`
+}
+
+type SetPurchaseLimitsInput struct {
+	ProductID       domain.ProductID `json:"product_id"`
+	MaxPerOrder     *int             `json:"max_per_order"`
+	MaxPerUser      *int             `json:"max_per_user"`
+	Window          time.Duration    `json:"window"`
+	ExpectedVersion *int             `json:"expected_version"`
+}
+
+// ArchiveProductInput archives or restores a product.
+type ArchiveProductInput struct {
+	ProductID       domain.ProductID `json:"product_id"`
+	ExpectedVersion *int             `json:"expected_version"`
 }
 
 type AdjustStockInput struct {
-	ProductID domain.ProductID `json:"product_id"`
-	Quantity  int              `json:"quantity"`
+	ProductID       domain.ProductID `json:"product_id"`
+	Quantity        int              `json:"quantity"`
+	ExpectedVersion *int             `json:"expected_version"`
+}
+
+type AddVariantInput struct {
+	ProductID       domain.ProductID  `json:"product_id"`
+	SKU             string            `json:"sku"`
+	Barcode         string            `json:"barcode"`
+	Options         map[string]string `json:"options"`
+	Price           decimal.Decimal   `json:"price"`
+	Quantity        int               `json:"quantity"`
+	ExpectedVersion *int              `json:"expected_version"`
+}
+
+type UpdateVariantPriceInput struct {
+	ProductID       domain.ProductID `json:"product_id"`
+	VariantID       domain.VariantID `json:"variant_id"`
+	Price           decimal.Decimal  `json:"price"`
+	ExpectedVersion *int             `json:"expected_version"`
+}
+
+type AdjustVariantStockInput struct {
+	ProductID       domain.ProductID `json:"product_id"`
+	VariantID       domain.VariantID `json:"variant_id"`
+	Quantity        int              `json:"quantity"`
+	ExpectedVersion *int             `json:"expected_version"`
+}
+
+// PatchProductInput follows JSON Merge Patch semantics: unset fields are left
+// untouched and null clears a field where that is allowed.
+type PatchProductInput struct {
+	ProductID         domain.ProductID             `json:"product_id"`
+	Description       patch.Field[string]          `json:"description"`
+	Tags              patch.Field[[]string]        `json:"tags"`
+	Price             patch.Field[decimal.Decimal] `json:"price"`
+	ReorderThreshold  patch.Field[int]             `json:"reorder_threshold"`
+	BackorderLimit    patch.Field[int]             `json:"backorder_limit"`
+	RestockExpectedAt patch.Field[time.Time]       `json:"restock_expected_at"`
+	ReleaseDate       patch.Field[time.Time]       `json:"release_date"`
+	ExpectedVersion   *int                         `json:"expected_version"`
+}
+
+type ImportMode string
+
+const (
+	// ImportModeAtomic imports every row or none of them.
+	ImportModeAtomic ImportMode = "atomic"
+	// ImportModeBestEffort imports valid rows and reports the rest.
+	ImportModeBestEffort ImportMode = "best_effort"
+)
+
+func (m ImportMode) IsValid() bool {
+	return m == ImportModeAtomic || m == ImportModeBestEffort
+}
+
+// ImportRow is one decoded record of an import file. Err carries a decoding
+// failure so it is reported against the row like any validation error.
+type ImportRow struct {
+	Line  int
+	Input CreateProductInput
+	Err   error
+}
+
+type ImportProductsInput struct {
+	Rows   iter.Seq[ImportRow]
+	Mode   ImportMode
+	DryRun bool
+}
+
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	Code  string `json:"code,omitempty"`
+	Error string `json:"error"`
+}
+
+type ImportReport struct {
+	Mode     ImportMode       `json:"mode"`
+	DryRun   bool             `json:"dry_run"`
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ProductCursor marks the last product of a catalog page; the next page starts
+// after it.
+type ProductCursor struct {
+	CreatedAt time.Time
+	ID        domain.ProductID
+}
+
+type UploadImageInput struct {
+	ProductID       domain.ProductID
+	Body            io.Reader
+	ExpectedVersion *int
+}
+
+type DeleteImageInput struct {
+	ProductID       domain.ProductID `json:"product_id"`
+	ImageID         domain.ImageID   `json:"image_id"`
+	ExpectedVersion *int             `json:"expected_version"`
+}
+
+type ReorderImagesInput struct {
+	ProductID       domain.ProductID `json:"product_id"`
+	ImageIDs        []domain.ImageID `json:"image_ids"`
+	ExpectedVersion *int             `json:"expected_version"`
+}
+
+type SetPrimaryImageInput struct {
+	ProductID       domain.ProductID `json:"product_id"`
+	ImageID         domain.ImageID   `json:"image_id"`
+	ExpectedVersion *int             `json:"expected_version"`
+}
+
+type AlertStatus string
+
+const (
+	AlertStatusOpen     AlertStatus = "open"
+	AlertStatusResolved AlertStatus = "resolved"
+	AlertStatusAll      AlertStatus = "all"
+)
+
+func (s AlertStatus) IsValid() bool {
+	return s == AlertStatusOpen || s == AlertStatusResolved || s == AlertStatusAll
+}
+
+const (
+	NotificationLowStock       = "inventory.low_stock"
+	NotificationLowStockDigest = "inventory.low_stock_digest"
+)
+
+// Notification is what notifier sinks deliver, serialized as JSON by the
+// webhook and file sinks.
+type Notification struct {
+	Event   string         `json:"event"`
+	Subject string         `json:"subject"`
+	Items   []LowStockItem `json:"items"`
+	SentAt  time.Time      `json:"sent_at"`
+}
+
+type LowStockItem struct {
+	ProductID   string `json:"product_id"`
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	Threshold   int    `json:"threshold"`
+}
+
+// StockAllocation tells how a reserved order line will be fulfilled and, for
+// backorders and pre-orders, when stock is expected.
+type StockAllocation struct {
+	Kind       domain.ReservationKind
+	ExpectedAt *time.Time
 }
@@ -2,19 +2,63 @@ package app
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
 )
 
 type ProductRepo interface {
 	Create(ctx context.Context, product *domain.Product) error
 	GetByID(ctx context.Context, id domain.ProductID) (*domain.Product, error)
 	GetAll(ctx context.Context, limit, offset int) ([]*domain.Product, error)
+	GetAllAfter(ctx context.Context, after *ProductCursor, limit int) ([]*domain.Product, error)
+	GetLowStock(ctx context.Context, limit, offset int) ([]*domain.Product, error)
 	Update(ctx context.Context, product *domain.Product) error
 	Delete(ctx context.Context, id domain.ProductID) error
-	ReserveStock(ctx context.Context, id domain.ProductID, quantity int) error
+}
+
+// ReservationRepo keeps reservation rows and the reserved counters of the
+// stock they hold in step.
+type ReservationRepo interface {
+	Create(ctx context.Context, reservation *domain.Reservation) error
+	CreateWaiting(ctx context.Context, reservation *domain.Reservation, limit *int) error
+	GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.Reservation, error)
+	GetWaiting(ctx context.Context, productID domain.ProductID) ([]*domain.Reservation, error)
+	GetExpiredOrders(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+	GetAllocatableProducts(ctx context.Context, now time.Time, limit int) ([]domain.ProductID, error)
+	Update(ctx context.Context, reservation *domain.Reservation, from domain.ReservationStatus) error
+}
+
+// Outbox stores domain events in the caller's transaction for later
+// delivery.
+type Outbox interface {
+	Save(ctx context.Context, events ...event.Event) error
 }
 
 type TxManager interface {
 	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
-}
\ No newline at end of file
+}
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+type StockAlertRepo interface {
+	Create(ctx context.Context, alert *domain.StockAlert) error
+	Resolve(ctx context.Context, productID domain.ProductID, resolvedAt time.Time) error
+	List(ctx context.Context, status AlertStatus, limit, offset int) ([]*domain.StockAlert, error)
+}
+
+// StockObserver is told about every stock or threshold change of a product,
+// inside the transaction that made it.
+type StockObserver interface {
+	StockChanged(ctx context.Context, product *domain.Product, wasLow bool) error
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
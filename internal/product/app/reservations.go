@@ -0,0 +1,368 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+)
+
+const allocateBatchSize = 100
+
+var _ StockObserver = (*ReservationService)(nil)
+
+type ReservationConfig struct {
+	TTL time.Duration `env:"TTL" envDefault:"15m"`
+}
+
+// ReservationService holds stock for orders. Reserved units stay on hand
+// but are no longer available until the order is confirmed, which commits
+// them, or cancelled or left to expire, which releases them. Backorders and
+// pre-orders wait in line and are allocated stock first come, first served
+// as it becomes available.
+type ReservationService struct {
+	reservationRepo ReservationRepo
+	productRepo     ProductRepo
+	txManager       TxManager
+	stockObserver   StockObserver
+	config          ReservationConfig
+}
+
+func NewReservationService(
+	reservationRepo ReservationRepo,
+	productRepo ProductRepo,
+	txManager TxManager,
+	stockObserver StockObserver,
+	config ReservationConfig,
+) *ReservationService {
+	return &ReservationService{
+		reservationRepo: reservationRepo,
+		productRepo:     productRepo,
+		txManager:       txManager,
+		stockObserver:   stockObserver,
+		config:          config,
+	}
+}
+
+// Reserve holds quantity units of a product, or of one of its variants, for
+// the order until the configured TTL runs out. Before the release date the
+// line becomes a pre-order, and when stock runs short a backorder if the
+// product accepts them.
+func (s *ReservationService) Reserve(
+	ctx context.Context,
+	orderID uuid.UUID,
+	productID domain.ProductID,
+	variantID *domain.VariantID,
+	quantity int,
+) (*StockAllocation, error) {
+	var allocation *StockAllocation
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, productID)
+		if err != nil {
+			return err
+		}
+
+		inventory, err := stockOf(product, variantID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case product.IsPreorderAt(time.Now()):
+			allocation = &StockAllocation{Kind: domain.ReservationPreorder, ExpectedAt: product.ReleaseDate}
+			return s.wait(txCtx, orderID, product, variantID, quantity, domain.ReservationPreorder, nil)
+		case inventory.IsAvailable(quantity):
+			allocation = &StockAllocation{Kind: domain.ReservationInStock}
+			return s.hold(txCtx, orderID, product, variantID, quantity)
+		case product.AllowsBackorders():
+			recordStockOut(ctx, "backordered")
+			allocation = &StockAllocation{Kind: domain.ReservationBackorder, ExpectedAt: product.RestockExpectedAt}
+			return s.wait(txCtx, orderID, product, variantID, quantity, domain.ReservationBackorder, product.BackorderLimit)
+		default:
+			recordStockOut(ctx, "rejected")
+			return domain.ErrInsufficientStock
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return allocation, nil
+}
+
+func (s *ReservationService) hold(
+	ctx context.Context,
+	orderID uuid.UUID,
+	product *domain.Product,
+	variantID *domain.VariantID,
+	quantity int,
+) error {
+	reservation, err := domain.NewReservation(orderID, product.ID, variantID, quantity, s.config.TTL)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reservationRepo.Create(ctx, reservation); err != nil {
+		return err
+	}
+
+	return s.availabilityChanged(ctx, reservation, -quantity)
+}
+
+func (s *ReservationService) wait(
+	ctx context.Context,
+	orderID uuid.UUID,
+	product *domain.Product,
+	variantID *domain.VariantID,
+	quantity int,
+	kind domain.ReservationKind,
+	limit *int,
+) error {
+	reservation, err := domain.NewWaitingReservation(orderID, product.ID, variantID, quantity, kind)
+	if err != nil {
+		return err
+	}
+
+	return s.reservationRepo.CreateWaiting(ctx, reservation, limit)
+}
+
+// Commit turns the active reservations of an order into stock decrements.
+// It fails with ErrReservationExpired when any of them ran out, leaving the
+// order to the expiry sweep. Waiting reservations are committed once stock
+// is allocated to them.
+func (s *ReservationService) Commit(ctx context.Context, orderID uuid.UUID) error {
+	now := time.Now()
+	return s.apply(ctx, orderID, func(reservation *domain.Reservation) (bool, error) {
+		if reservation.Status != domain.ReservationActive && reservation.Status != domain.ReservationWaiting {
+			return false, nil
+		}
+		return true, reservation.Commit(now)
+	})
+}
+
+// Release frees the active and waiting reservations of a cancelled order and
+// puts committed units back on hand.
+func (s *ReservationService) Release(ctx context.Context, orderID uuid.UUID) error {
+	now := time.Now()
+	return s.apply(ctx, orderID, func(reservation *domain.Reservation) (bool, error) {
+		switch reservation.Status {
+		case domain.ReservationActive, domain.ReservationWaiting:
+			return true, reservation.Release(now)
+		case domain.ReservationCommitted:
+			return true, reservation.Return(now)
+		default:
+			return false, nil
+		}
+	})
+}
+
+// Expire releases the reservations of an order whose TTL has run out.
+func (s *ReservationService) Expire(ctx context.Context, orderID uuid.UUID) error {
+	now := time.Now()
+	return s.apply(ctx, orderID, func(reservation *domain.Reservation) (bool, error) {
+		if !reservation.IsExpiredAt(now) {
+			return false, nil
+		}
+		return true, reservation.Expire(now)
+	})
+}
+
+// ExpiredOrders lists orders that still hold expired reservations.
+func (s *ReservationService) ExpiredOrders(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	return s.reservationRepo.GetExpiredOrders(ctx, time.Now(), limit)
+}
+
+// StockChanged allocates newly available stock to waiting reservations
+// before passing the change on. The product is refreshed in place when
+// allocation changed its stock.
+func (s *ReservationService) StockChanged(ctx context.Context, product *domain.Product, wasLow bool) error {
+	allocated, err := s.allocate(ctx, product)
+	if err != nil {
+		return err
+	}
+
+	if allocated > 0 {
+		refreshed, err := s.productRepo.GetByID(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+		*product = *refreshed
+	}
+
+	return s.stockObserver.StockChanged(ctx, product, wasLow)
+}
+
+// AllocateWaiting hands stock to waiting reservations of products that have
+// been released or restocked without a stock change triggering it, such as
+// pre-orders whose release date has passed. Every product is handled in its
+// own transaction.
+func (s *ReservationService) AllocateWaiting(ctx context.Context) (int, error) {
+	productIDs, err := s.reservationRepo.GetAllocatableProducts(ctx, time.Now(), allocateBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var allocated int
+	var errs []error
+	for _, productID := range productIDs {
+		err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+			product, err := s.productRepo.GetByID(txCtx, productID)
+			if err != nil {
+				return err
+			}
+
+			count, err := s.allocate(txCtx, product)
+			if err != nil {
+				return err
+			}
+
+			allocated += count
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("product %s: %w", productID, err))
+		}
+	}
+
+	return allocated, errors.Join(errs...)
+}
+
+// allocate walks the waiting reservations of a released product oldest
+// first. A reservation that does not fit blocks later ones for the same
+// product or variant, so nobody is overtaken by a smaller order.
+func (s *ReservationService) allocate(ctx context.Context, product *domain.Product) (int, error) {
+	now := time.Now()
+	if product.IsPreorderAt(now) {
+		return 0, nil
+	}
+
+	waiting, err := s.reservationRepo.GetWaiting(ctx, product.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	var allocated int
+	blocked := make(map[domain.VariantID]bool)
+	for _, reservation := range waiting {
+		var row domain.VariantID
+		if reservation.VariantID != nil {
+			row = *reservation.VariantID
+		}
+		if blocked[row] {
+			continue
+		}
+
+		inventory, err := stockOf(product, reservation.VariantID)
+		if err != nil || !inventory.IsAvailable(reservation.Quantity) {
+			blocked[row] = true
+			continue
+		}
+
+		if err := reservation.Allocate(now, s.config.TTL); err != nil {
+			return 0, err
+		}
+
+		if err := s.reservationRepo.Update(ctx, reservation, domain.ReservationWaiting); err != nil {
+			return 0, err
+		}
+
+		if reservation.Status == domain.ReservationCommitted {
+			err = inventory.Remove(reservation.Quantity)
+		} else {
+			err = inventory.Reserve(reservation.Quantity)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		allocated++
+	}
+
+	return allocated, nil
+}
+
+// apply runs transition on every reservation of the order and stores the
+// ones it reports as changed. Stock freed by the changes is reported once
+// all of them are stored.
+func (s *ReservationService) apply(
+	ctx context.Context,
+	orderID uuid.UUID,
+	transition func(reservation *domain.Reservation) (bool, error),
+) error {
+	return s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		reservations, err := s.reservationRepo.GetByOrder(txCtx, orderID)
+		if err != nil {
+			return err
+		}
+
+		var freed []*domain.Reservation
+		for _, reservation := range reservations {
+			previous := reservation.Status
+
+			changed, err := transition(reservation)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				continue
+			}
+
+			if err := s.reservationRepo.Update(txCtx, reservation, previous); err != nil {
+				return err
+			}
+
+			// Only reservations that held available stock free some of it.
+			if previous == domain.ReservationActive && reservation.Status != domain.ReservationCommitted ||
+				previous == domain.ReservationCommitted {
+				freed = append(freed, reservation)
+			}
+		}
+
+		for _, reservation := range freed {
+			if err := s.availabilityChanged(txCtx, reservation, reservation.Quantity); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// availabilityChanged reports a product whose available stock moved by
+// delta. Freed stock goes to waiting reservations first. Variants have no
+// reorder threshold of their own, so their changes never cross it.
+func (s *ReservationService) availabilityChanged(ctx context.Context, reservation *domain.Reservation, delta int) error {
+	product, err := s.productRepo.GetByID(ctx, reservation.ProductID)
+	if err != nil {
+		return err
+	}
+
+	wasLow := product.IsLowStock()
+	if reservation.VariantID == nil {
+		wasLow = product.IsLowStockAt(product.Inventory.Available() - delta)
+	}
+
+	if delta > 0 {
+		return s.StockChanged(ctx, product, wasLow)
+	}
+
+	return s.stockObserver.StockChanged(ctx, product, wasLow)
+}
+
+// stockOf returns the inventory that holds stock for an order line.
+func stockOf(product *domain.Product, variantID *domain.VariantID) (*domain.Inventory, error) {
+	if variantID == nil {
+		return &product.Inventory, nil
+	}
+
+	variant, err := product.Variant(*variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &variant.Inventory, nil
+}
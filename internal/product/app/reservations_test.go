@@ -0,0 +1,314 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+)
+
+type MockReservationRepo struct {
+	mock.Mock
+}
+
+func (m *MockReservationRepo) Create(ctx context.Context, reservation *domain.Reservation) error {
+	args := m.Called(ctx, reservation)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepo) CreateWaiting(ctx context.Context, reservation *domain.Reservation, limit *int) error {
+	args := m.Called(ctx, reservation, limit)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepo) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.Reservation, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepo) GetWaiting(ctx context.Context, productID domain.ProductID) ([]*domain.Reservation, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepo) GetExpiredOrders(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockReservationRepo) GetAllocatableProducts(ctx context.Context, now time.Time, limit int) ([]domain.ProductID, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProductID), args.Error(1)
+}
+
+func (m *MockReservationRepo) Update(ctx context.Context, reservation *domain.Reservation, from domain.ReservationStatus) error {
+	args := m.Called(ctx, reservation, from)
+	return args.Error(0)
+}
+
+func newTestReservationService(reservations *MockReservationRepo, products *MockProductRepo, observer *MockStockObserver) *ReservationService {
+	return NewReservationService(reservations, products, &MockTxManager{}, observer, ReservationConfig{TTL: 15 * time.Minute})
+}
+
+func newActiveReservation(orderID uuid.UUID, productID domain.ProductID, quantity int) *domain.Reservation {
+	reservation, _ := domain.NewReservation(orderID, productID, nil, quantity, time.Minute)
+	return reservation
+}
+
+func newWaitingReservation(productID domain.ProductID, quantity int) *domain.Reservation {
+	reservation, _ := domain.NewWaitingReservation(uuid.New(), productID, nil, quantity, domain.ReservationBackorder)
+	return reservation
+}
+
+func TestReservationService_Reserve_CrossesThreshold(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	products := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := newTestReservationService(reservations, products, observer)
+
+	orderID := uuid.New()
+	product := newInventoryTestProduct(8, 5)
+	reserved := *product
+	reserved.Inventory, _ = domain.RestoreInventory(8, 3)
+
+	products.On("GetByID", mock.Anything, product.ID).Return(product, nil).Once()
+	reservations.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.OrderID == orderID && r.ProductID == product.ID && r.Quantity == 3 &&
+			r.Status == domain.ReservationActive && r.ExpiresAt.After(time.Now().Add(14*time.Minute))
+	})).Return(nil)
+	products.On("GetByID", mock.Anything, product.ID).Return(&reserved, nil).Once()
+	observer.On("StockChanged", mock.Anything, &reserved, false).Return(nil)
+
+	allocation, err := service.Reserve(context.Background(), orderID, product.ID, nil, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReservationInStock, allocation.Kind)
+	assert.True(t, reserved.IsLowStock())
+	reservations.AssertExpectations(t)
+	observer.AssertExpectations(t)
+}
+
+func TestReservationService_Reserve_InsufficientStock(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	products := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := newTestReservationService(reservations, products, observer)
+
+	product := newInventoryTestProduct(2, 0)
+	products.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	_, err := service.Reserve(context.Background(), uuid.New(), product.ID, nil, 3)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	reservations.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	observer.AssertNotCalled(t, "StockChanged")
+}
+
+func TestReservationService_Reserve_Backorder(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	products := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := newTestReservationService(reservations, products, observer)
+
+	limit := 5
+	restockAt := time.Now().Add(72 * time.Hour)
+	product := newInventoryTestProduct(2, 0)
+	_ = product.SetBackorderLimit(&limit)
+	product.SetRestockExpectedAt(&restockAt)
+
+	products.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	reservations.On("CreateWaiting", mock.Anything, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.Status == domain.ReservationWaiting && r.Kind == domain.ReservationBackorder && r.ExpiresAt == nil
+	}), &limit).Return(nil)
+
+	allocation, err := service.Reserve(context.Background(), uuid.New(), product.ID, nil, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReservationBackorder, allocation.Kind)
+	assert.Equal(t, &restockAt, allocation.ExpectedAt)
+	reservations.AssertExpectations(t)
+	observer.AssertNotCalled(t, "StockChanged")
+}
+
+func TestReservationService_Reserve_Preorder(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	products := new(MockProductRepo)
+	service := newTestReservationService(reservations, products, new(MockStockObserver))
+
+	releaseDate := time.Now().Add(24 * time.Hour)
+	product := newInventoryTestProduct(10, 0)
+	product.SetReleaseDate(&releaseDate)
+
+	products.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	reservations.On("CreateWaiting", mock.Anything, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.Kind == domain.ReservationPreorder
+	}), (*int)(nil)).Return(nil)
+
+	allocation, err := service.Reserve(context.Background(), uuid.New(), product.ID, nil, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReservationPreorder, allocation.Kind)
+	assert.Equal(t, &releaseDate, allocation.ExpectedAt)
+	reservations.AssertExpectations(t)
+}
+
+func TestReservationService_Commit(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	products := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := newTestReservationService(reservations, products, observer)
+
+	orderID := uuid.New()
+	active := newActiveReservation(orderID, domain.NewProductID(), 2)
+	released := newActiveReservation(orderID, domain.NewProductID(), 1)
+	released.Status = domain.ReservationReleased
+
+	reservations.On("GetByOrder", mock.Anything, orderID).Return([]*domain.Reservation{active, released}, nil)
+	reservations.On("Update", mock.Anything, active, domain.ReservationActive).Return(nil).Once()
+
+	err := service.Commit(context.Background(), orderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReservationCommitted, active.Status)
+	assert.Equal(t, domain.ReservationReleased, released.Status)
+	reservations.AssertExpectations(t)
+	observer.AssertNotCalled(t, "StockChanged")
+}
+
+func TestReservationService_Commit_Expired(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	service := newTestReservationService(reservations, new(MockProductRepo), new(MockStockObserver))
+
+	orderID := uuid.New()
+	reservation := newActiveReservation(orderID, domain.NewProductID(), 2)
+	expiresAt := time.Now().Add(-time.Second)
+	reservation.ExpiresAt = &expiresAt
+
+	reservations.On("GetByOrder", mock.Anything, orderID).Return([]*domain.Reservation{reservation}, nil)
+
+	err := service.Commit(context.Background(), orderID)
+
+	assert.ErrorIs(t, err, domain.ErrReservationExpired)
+	assert.Equal(t, domain.ReservationActive, reservation.Status)
+	reservations.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReservationService_Release(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	products := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := newTestReservationService(reservations, products, observer)
+
+	orderID := uuid.New()
+	product := newInventoryTestProduct(10, 5)
+	active := newActiveReservation(orderID, product.ID, 6)
+	committed := newActiveReservation(orderID, product.ID, 1)
+	committed.Status = domain.ReservationCommitted
+
+	reservations.On("GetByOrder", mock.Anything, orderID).Return([]*domain.Reservation{active, committed}, nil)
+	reservations.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+	reservations.On("GetWaiting", mock.Anything, product.ID).Return([]*domain.Reservation{}, nil)
+	products.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	// Available went from 4 to 10 on release and from 9 to 10 on return.
+	observer.On("StockChanged", mock.Anything, product, true).Return(nil).Once()
+	observer.On("StockChanged", mock.Anything, product, false).Return(nil).Once()
+
+	err := service.Release(context.Background(), orderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReservationReleased, active.Status)
+	assert.Equal(t, domain.ReservationReturned, committed.Status)
+	reservations.AssertExpectations(t)
+	observer.AssertExpectations(t)
+}
+
+func TestReservationService_Expire_OnlyExpired(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	products := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := newTestReservationService(reservations, products, observer)
+
+	orderID := uuid.New()
+	product := newInventoryTestProduct(10, 2)
+	expired := newActiveReservation(orderID, product.ID, 1)
+	expiredAt := time.Now().Add(-time.Minute)
+	expired.ExpiresAt = &expiredAt
+	fresh := newActiveReservation(orderID, domain.NewProductID(), 1)
+
+	reservations.On("GetByOrder", mock.Anything, orderID).Return([]*domain.Reservation{expired, fresh}, nil)
+	reservations.On("Update", mock.Anything, expired, domain.ReservationActive).Return(nil).Once()
+	reservations.On("GetWaiting", mock.Anything, product.ID).Return([]*domain.Reservation{}, nil)
+	products.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	observer.On("StockChanged", mock.Anything, product, false).Return(nil)
+
+	err := service.Expire(context.Background(), orderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReservationExpired, expired.Status)
+	assert.Equal(t, domain.ReservationActive, fresh.Status)
+	reservations.AssertExpectations(t)
+}
+
+func TestReservationService_StockChanged_AllocatesFirstComeFirstServed(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	products := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := newTestReservationService(reservations, products, observer)
+
+	product := newInventoryTestProduct(5, 0)
+	first := newWaitingReservation(product.ID, 3)
+	first.Confirmed = true
+	second := newWaitingReservation(product.ID, 4)
+	third := newWaitingReservation(product.ID, 1)
+	refreshed := newInventoryTestProduct(2, 0)
+	refreshed.ID = product.ID
+
+	reservations.On("GetWaiting", mock.Anything, product.ID).Return([]*domain.Reservation{first, second, third}, nil)
+	reservations.On("Update", mock.Anything, first, domain.ReservationWaiting).Return(nil).Once()
+	products.On("GetByID", mock.Anything, product.ID).Return(refreshed, nil)
+	observer.On("StockChanged", mock.Anything, product, false).Return(nil)
+
+	err := service.StockChanged(context.Background(), product, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReservationCommitted, first.Status)
+	// The third one fits but must not overtake the second.
+	assert.Equal(t, domain.ReservationWaiting, second.Status)
+	assert.Equal(t, domain.ReservationWaiting, third.Status)
+	assert.Equal(t, 2, product.Inventory.Quantity())
+	reservations.AssertExpectations(t)
+	observer.AssertExpectations(t)
+}
+
+func TestReservationService_StockChanged_WaitsForReleaseDate(t *testing.T) {
+	reservations := new(MockReservationRepo)
+	observer := new(MockStockObserver)
+	service := newTestReservationService(reservations, new(MockProductRepo), observer)
+
+	releaseDate := time.Now().Add(time.Hour)
+	product := newInventoryTestProduct(5, 0)
+	product.SetReleaseDate(&releaseDate)
+	observer.On("StockChanged", mock.Anything, product, false).Return(nil)
+
+	err := service.StockChanged(context.Background(), product, false)
+
+	assert.NoError(t, err)
+	reservations.AssertNotCalled(t, "GetWaiting", mock.Anything, mock.Anything)
+	observer.AssertExpectations(t)
+}
@@ -2,40 +2,42 @@ package app
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/BlackRRR/Irtea-test/internal/product/domain"
 )
 
 type ProductService struct {
-	productRepo ProductRepo
-	txManager   TxManager
+	productRepo   ProductRepo
+	txManager     TxManager
+	stockObserver StockObserver
+	outbox        Outbox
+	logger        *slog.Logger
 }
 
-func NewProductService(productRepo ProductRepo, txManager TxManager) *ProductService {
+func NewProductService(productRepo ProductRepo, txManager TxManager, stockObserver StockObserver, outbox Outbox, logger *slog.Logger) *ProductService {
 	return &ProductService{
-		productRepo: productRepo,
-		txManager:   txManager,
+		productRepo:   productRepo,
+		txManager:     txManager,
+		stockObserver: stockObserver,
+		outbox:        outbox,
+		logger:        logger,
 	}
 }
 
 func (s *ProductService) CreateProduct(ctx context.Context, input CreateProductInput) (*domain.Product, error) {
-	price, err := domain.NewMoney(input.Price)
-	if err != nil {
-		return nil, err
-	}
-
-	inventory, err := domain.NewInventory(input.Quantity)
-	if err != nil {
-		return nil, err
-	}
-
-	product, err := domain.NewProduct(input.Description, input.Tags, price, inventory)
+	product, err := newProduct(input)
 	if err != nil {
 		return nil, err
 	}
 
 	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
-		return s.productRepo.Create(txCtx, product)
+		err := s.productRepo.Create(txCtx, product)
+		if err != nil {
+			return err
+		}
+
+		return s.stockObserver.StockChanged(txCtx, product, false)
 	})
 
 	if err != nil {
@@ -66,6 +68,11 @@ func (s *ProductService) UpdatePrice(ctx context.Context, input UpdatePriceInput
 			return err
 		}
 
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
 		product.UpdatePrice(price)
 
 		err = s.productRepo.Update(txCtx, product)
@@ -73,6 +80,49 @@ func (s *ProductService) UpdatePrice(ctx context.Context, input UpdatePriceInput
 			return err
 		}
 
+		err = s.outbox.Save(txCtx, product.PullEvents()...)
+		if err != nil {
+			return err
+		}
+
+		updatedProduct = product
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedProduct, nil
+}
+
+// SetPurchaseLimits replaces the product's purchase limits; an input
+// without caps clears them.
+func (s *ProductService) SetPurchaseLimits(ctx context.Context, input SetPurchaseLimitsInput) (*domain.Product, error) {
+	limits, err := domain.NewPurchaseLimits(input.MaxPerOrder, input.MaxPerUser, input.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedProduct *domain.Product
+	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, input.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		product.SetPurchaseLimits(limits)
+
+		err = s.productRepo.Update(txCtx, product)
+		if err != nil {
+			return err
+		}
+
 		updatedProduct = product
 		return nil
 	})
@@ -84,6 +134,96 @@ func (s *ProductService) UpdatePrice(ctx context.Context, input UpdatePriceInput
 	return updatedProduct, nil
 }
 
+func (s *ProductService) PatchProduct(ctx context.Context, input PatchProductInput) (*domain.Product, error) {
+	var patchedProduct *domain.Product
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, input.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		wasLow := product.IsLowStock()
+		validationErr := &ValidationError{}
+
+		if input.Description.Set {
+			if err := product.UpdateDescription(input.Description.Value); err != nil {
+				validationErr.Add("description", err)
+			}
+		}
+
+		if input.Tags.Set {
+			product.UpdateTags(input.Tags.Value)
+		}
+
+		if input.Price.Set {
+			if input.Price.Null {
+				validationErr.Add("price", domain.ErrInvalidPrice)
+			} else if price, err := domain.NewMoney(input.Price.Value); err != nil {
+				validationErr.Add("price", err)
+			} else {
+				product.UpdatePrice(price)
+			}
+		}
+
+		if input.ReorderThreshold.Set {
+			var threshold *int
+			if !input.ReorderThreshold.Null {
+				threshold = &input.ReorderThreshold.Value
+			}
+			if err := product.SetReorderThreshold(threshold); err != nil {
+				validationErr.Add("reorder_threshold", err)
+			}
+		}
+
+		if input.BackorderLimit.Set {
+			if err := product.SetBackorderLimit(input.BackorderLimit.Ptr()); err != nil {
+				validationErr.Add("backorder_limit", err)
+			}
+		}
+
+		if input.RestockExpectedAt.Set {
+			product.SetRestockExpectedAt(input.RestockExpectedAt.Ptr())
+		}
+
+		if input.ReleaseDate.Set {
+			product.SetReleaseDate(input.ReleaseDate.Ptr())
+		}
+
+		if validationErr.HasErrors() {
+			return validationErr
+		}
+
+		err = s.productRepo.Update(txCtx, product)
+		if err != nil {
+			return err
+		}
+
+		err = s.outbox.Save(txCtx, product.PullEvents()...)
+		if err != nil {
+			return err
+		}
+
+		err = s.stockObserver.StockChanged(txCtx, product, wasLow)
+		if err != nil {
+			return err
+		}
+
+		patchedProduct = product
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return patchedProduct, nil
+}
+
 func (s *ProductService) AdjustStock(ctx context.Context, input AdjustStockInput) (*domain.Product, error) {
 	var updatedProduct *domain.Product
 	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
@@ -92,6 +232,13 @@ func (s *ProductService) AdjustStock(ctx context.Context, input AdjustStockInput
 			return err
 		}
 
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		wasLow := product.IsLowStock()
+
 		err = product.AdjustStock(input.Quantity)
 		if err != nil {
 			return err
@@ -102,6 +249,65 @@ func (s *ProductService) AdjustStock(ctx context.Context, input AdjustStockInput
 			return err
 		}
 
+		err = s.outbox.Save(txCtx, product.PullEvents()...)
+		if err != nil {
+			return err
+		}
+
+		err = s.stockObserver.StockChanged(txCtx, product, wasLow)
+		if err != nil {
+			return err
+		}
+
+		updatedProduct = product
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedProduct, nil
+}
+
+func (s *ProductService) AddVariant(ctx context.Context, input AddVariantInput) (*domain.Product, error) {
+	price, err := domain.NewMoney(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := domain.NewInventory(input.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := domain.NewVariant(input.SKU, input.Barcode, input.Options, price, inventory)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedProduct *domain.Product
+	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, input.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = product.AddVariant(variant)
+		if err != nil {
+			return err
+		}
+
+		err = s.productRepo.Update(txCtx, product)
+		if err != nil {
+			return err
+		}
+
 		updatedProduct = product
 		return nil
 	})
@@ -113,8 +319,215 @@ func (s *ProductService) AdjustStock(ctx context.Context, input AdjustStockInput
 	return updatedProduct, nil
 }
 
-func (s *ProductService) ReserveStock(ctx context.Context, productID domain.ProductID, quantity int) error {
+func (s *ProductService) UpdateVariantPrice(ctx context.Context, input UpdateVariantPriceInput) (*domain.Product, error) {
+	price, err := domain.NewMoney(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedProduct *domain.Product
+	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, input.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = product.UpdateVariantPrice(input.VariantID, price)
+		if err != nil {
+			return err
+		}
+
+		err = s.productRepo.Update(txCtx, product)
+		if err != nil {
+			return err
+		}
+
+		err = s.outbox.Save(txCtx, product.PullEvents()...)
+		if err != nil {
+			return err
+		}
+
+		updatedProduct = product
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedProduct, nil
+}
+
+func (s *ProductService) AdjustVariantStock(ctx context.Context, input AdjustVariantStockInput) (*domain.Product, error) {
+	var updatedProduct *domain.Product
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, input.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = product.AdjustVariantStock(input.VariantID, input.Quantity)
+		if err != nil {
+			return err
+		}
+
+		err = s.productRepo.Update(txCtx, product)
+		if err != nil {
+			return err
+		}
+
+		err = s.outbox.Save(txCtx, product.PullEvents()...)
+		if err != nil {
+			return err
+		}
+
+		err = s.stockObserver.StockChanged(txCtx, product, product.IsLowStock())
+		if err != nil {
+			return err
+		}
+
+		updatedProduct = product
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedProduct, nil
+}
+
+func (s *ProductService) ArchiveProduct(ctx context.Context, input ArchiveProductInput) (*domain.Product, error) {
+	var archivedProduct *domain.Product
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, input.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = product.Archive()
+		if err != nil {
+			return err
+		}
+
+		err = s.productRepo.Update(txCtx, product)
+		if err != nil {
+			return err
+		}
+
+		archivedProduct = product
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return archivedProduct, nil
+}
+
+func (s *ProductService) RestoreProduct(ctx context.Context, input ArchiveProductInput) (*domain.Product, error) {
+	var restoredProduct *domain.Product
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		product, err := s.productRepo.GetByID(txCtx, input.ProductID)
+		if err != nil {
+			return err
+		}
+
+		err = product.CheckVersion(input.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = product.Unarchive()
+		if err != nil {
+			return err
+		}
+
+		err = s.productRepo.Update(txCtx, product)
+		if err != nil {
+			return err
+		}
+
+		restoredProduct = product
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return restoredProduct, nil
+}
+
+// DeleteProduct removes the product permanently. Products referenced by any
+// order cannot be deleted and should be archived instead.
+func (s *ProductService) DeleteProduct(ctx context.Context, id domain.ProductID) error {
 	return s.txManager.WithTx(ctx, func(txCtx context.Context) error {
-		return s.productRepo.ReserveStock(txCtx, productID, quantity)
+		return s.productRepo.Delete(txCtx, id)
 	})
 }
+
+func newProduct(input CreateProductInput) (*domain.Product, error) {
+	price, err := domain.NewMoney(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := domain.NewInventory(input.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := domain.NewProduct(input.Description, input.Tags, price, inventory)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ReorderThreshold != nil {
+		if err = product.SetReorderThreshold(input.ReorderThreshold); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.BackorderLimit != nil {
+		if err = product.SetBackorderLimit(input.BackorderLimit); err != nil {
+			return nil, err
+		}
+	}
+
+	product.RestockExpectedAt = input.RestockExpectedAt
+	product.ReleaseDate = input.ReleaseDate
+
+	if len(input.Options) > 0 {
+		axes := make([]domain.OptionAxis, 0, len(input.Options))
+		for _, optionInput := range input.Options {
+			axis, err := domain.NewOptionAxis(optionInput.Name, optionInput.Values)
+			if err != nil {
+				return nil, err
+			}
+			axes = append(axes, axis)
+		}
+
+		if err = product.DefineOptions(axes); err != nil {
+			return nil, err
+		}
+	}
+
+	return product, nil
+}
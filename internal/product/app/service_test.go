@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/patch"
+)
+
+func TestProductService_PatchProduct(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	observer := new(MockStockObserver)
+	service := NewProductService(mockRepo, &MockTxManager{}, observer, newTestOutbox(), discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("Update", mock.Anything, product).Return(nil)
+	observer.On("StockChanged", mock.Anything, product, false).Return(nil)
+
+	version := product.Version
+	patched, err := service.PatchProduct(context.Background(), PatchProductInput{
+		ProductID:        product.ID,
+		Description:      patch.Value("  Sketchbook "),
+		Price:            patch.Value(decimal.NewFromInt(8)),
+		ReorderThreshold: patch.Null[int](),
+		ExpectedVersion:  &version,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Sketchbook", patched.Description)
+	assert.True(t, patched.Price.Amount().Equal(decimal.NewFromInt(8)))
+	assert.Nil(t, patched.ReorderThreshold)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProductService_PatchProduct_NullDescription(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	patched, err := service.PatchProduct(context.Background(), PatchProductInput{
+		ProductID:   product.ID,
+		Description: patch.Null[string](),
+	})
+
+	assert.Nil(t, patched)
+	assert.Equal(t, &ValidationError{Fields: []FieldError{
+		{Field: "description", Err: domain.ErrProductDescCannotBeEmpty},
+	}}, err)
+	assert.Equal(t, "Notebook", product.Description)
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestProductService_PatchProduct_NullPrice(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	patched, err := service.PatchProduct(context.Background(), PatchProductInput{
+		ProductID: product.ID,
+		Price:     patch.Null[decimal.Decimal](),
+	})
+
+	assert.Nil(t, patched)
+	assert.ErrorIs(t, err, domain.ErrInvalidPrice)
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestProductService_PatchProduct_CollectsValidationErrors(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	_, err := service.PatchProduct(context.Background(), PatchProductInput{
+		ProductID:        product.ID,
+		Description:      patch.Value(" "),
+		Tags:             patch.Value([]string{"paper"}),
+		Price:            patch.Value(decimal.NewFromInt(-1)),
+		ReorderThreshold: patch.Value(-2),
+	})
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	fields := make([]string, 0, len(validationErr.Fields))
+	for _, field := range validationErr.Fields {
+		fields = append(fields, field.Field)
+	}
+	assert.Equal(t, []string{"description", "price", "reorder_threshold"}, fields)
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestProductService_PatchProduct_VersionConflict(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	stale := product.Version + 1
+	patched, err := service.PatchProduct(context.Background(), PatchProductInput{
+		ProductID:       product.ID,
+		Description:     patch.Value("Sketchbook"),
+		ExpectedVersion: &stale,
+	})
+
+	assert.Nil(t, patched)
+	assert.Equal(t, domain.ErrProductVersionConflict, err)
+	assert.Equal(t, "Notebook", product.Description)
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestProductService_ArchiveProduct_VersionConflict(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+
+	stale := product.Version + 1
+	archived, err := service.ArchiveProduct(context.Background(), ArchiveProductInput{ProductID: product.ID, ExpectedVersion: &stale})
+
+	assert.Nil(t, archived)
+	assert.Equal(t, domain.ErrProductVersionConflict, err)
+	assert.False(t, product.IsArchived())
+	mockRepo.AssertNotCalled(t, "Update")
+}
+
+func TestProductService_RestoreProduct(t *testing.T) {
+	mockRepo := new(MockProductRepo)
+	service := NewProductService(mockRepo, &MockTxManager{}, new(MockStockObserver), newTestOutbox(), discardLogger())
+
+	product := newInventoryTestProduct(10, 5)
+	_ = product.Archive()
+	mockRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	mockRepo.On("Update", mock.Anything, product).Return(nil)
+
+	version := product.Version
+	restored, err := service.RestoreProduct(context.Background(), ArchiveProductInput{ProductID: product.ID, ExpectedVersion: &version})
+
+	assert.NoError(t, err)
+	assert.False(t, restored.IsArchived())
+}
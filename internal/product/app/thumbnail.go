@@ -0,0 +1,111 @@
+package app
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const thumbnailJPEGQuality = 85
+
+type imageFormat struct {
+	ext string
+	// Formats that may carry transparency get PNG thumbnails.
+	transparent bool
+}
+
+var imageFormats = map[string]imageFormat{
+	"image/jpeg": {ext: ".jpg"},
+	"image/png":  {ext: ".png", transparent: true},
+	"image/gif":  {ext: ".gif", transparent: true},
+	"image/webp": {ext: ".webp", transparent: true},
+}
+
+type processedImage struct {
+	contentType          string
+	ext                  string
+	width                int
+	height               int
+	thumbnail            []byte
+	thumbnailContentType string
+	thumbnailExt         string
+}
+
+// processImage sniffs the real type of an upload, ignoring whatever the
+// client claimed, and renders its thumbnail.
+func processImage(data []byte, config ImageConfig) (*processedImage, error) {
+	contentType := http.DetectContentType(data)
+	format, ok := imageFormats[contentType]
+	if !ok {
+		return nil, domain.ErrUnsupportedImageType
+	}
+
+	// Check dimensions before decoding so a small file cannot expand into a
+	// huge bitmap.
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrUnsupportedImageType
+	}
+	if imageConfig.Width*imageConfig.Height > config.MaxPixels {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrUnsupportedImageType
+	}
+
+	processed := &processedImage{
+		contentType: contentType,
+		ext:         format.ext,
+		width:       imageConfig.Width,
+		height:      imageConfig.Height,
+	}
+
+	thumb := thumbnail(src, config.ThumbnailSize)
+
+	var buf bytes.Buffer
+	if format.transparent {
+		err = png.Encode(&buf, thumb)
+		processed.thumbnailContentType = "image/png"
+		processed.thumbnailExt = ".png"
+	} else {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: thumbnailJPEGQuality})
+		processed.thumbnailContentType = "image/jpeg"
+		processed.thumbnailExt = ".jpg"
+	}
+	if err != nil {
+		return nil, err
+	}
+	processed.thumbnail = buf.Bytes()
+
+	return processed, nil
+}
+
+// thumbnail scales src down to fit into a size x size box, keeping the aspect
+// ratio. Images that already fit are not enlarged.
+func thumbnail(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > size || height > size {
+		if width >= height {
+			height = max(1, height*size/width)
+			width = size
+		} else {
+			width = max(1, width*size/height)
+			height = size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
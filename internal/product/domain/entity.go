@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"github.com/BlackRRR/Irtea-test/pkg/event"
 )
 
 type ProductID uuid.UUID
@@ -37,8 +38,11 @@ func (m Money) IsZero() bool {
 	return m.amount.IsZero()
 }
 
+// Inventory tracks stock on hand and the part of it held by open
+// reservations. Only the rest is available for new orders.
 type Inventory struct {
 	quantity int
+	reserved int
 }
 
 func NewInventory(quantity int) (Inventory, error) {
@@ -48,15 +52,70 @@ func NewInventory(quantity int) (Inventory, error) {
 	return Inventory{quantity: quantity}, nil
 }
 
+// RestoreInventory rebuilds an inventory from stored on-hand and reserved
+// counters.
+func RestoreInventory(quantity, reserved int) (Inventory, error) {
+	if quantity < 0 || reserved < 0 {
+		return Inventory{}, ErrInventoryQuantityCannotBeNeg
+	}
+	if reserved > quantity {
+		return Inventory{}, ErrInsufficientStock
+	}
+	return Inventory{quantity: quantity, reserved: reserved}, nil
+}
+
+// Quantity is the stock on hand, including reserved units.
 func (i *Inventory) Quantity() int {
 	return i.quantity
 }
 
+func (i *Inventory) Reserved() int {
+	return i.reserved
+}
+
+func (i *Inventory) Available() int {
+	return i.quantity - i.reserved
+}
+
 func (i *Inventory) IsAvailable(requestedQuantity int) bool {
-	return i.quantity >= requestedQuantity
+	return i.Available() >= requestedQuantity
 }
 
+// Reserve holds available units for an order without removing them from
+// stock on hand.
 func (i *Inventory) Reserve(quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if !i.IsAvailable(quantity) {
+		return ErrInsufficientStock
+	}
+	i.reserved += quantity
+	return nil
+}
+
+// Release gives reserved units back to available stock.
+func (i *Inventory) Release(quantity int) error {
+	if quantity <= 0 || quantity > i.reserved {
+		return ErrInvalidQuantity
+	}
+	i.reserved -= quantity
+	return nil
+}
+
+// Commit turns reserved units into a real decrement of stock on hand.
+func (i *Inventory) Commit(quantity int) error {
+	if quantity <= 0 || quantity > i.reserved {
+		return ErrInvalidQuantity
+	}
+	i.reserved -= quantity
+	i.quantity -= quantity
+	return nil
+}
+
+// Remove takes available units out of stock on hand; reserved units cannot
+// be removed.
+func (i *Inventory) Remove(quantity int) error {
 	if !i.IsAvailable(quantity) {
 		return ErrInsufficientStock
 	}
@@ -73,21 +132,60 @@ func (i *Inventory) Add(quantity int) error {
 }
 
 type Product struct {
+	event.Recorder
+
 	ID          ProductID
 	Description string
 	Tags        []string
 	Price       Money
 	Inventory   Inventory
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Options     []OptionAxis
+	Variants    []*Variant
+	Images      []*Image
+	// ReorderThreshold is the stock level at or below which the product needs
+	// restocking. Nil disables low-stock alerts.
+	ReorderThreshold *int
+	// BackorderLimit caps the units that may be ordered while out of stock.
+	// Nil disables backorders.
+	BackorderLimit *int
+	// RestockExpectedAt is when backordered units are expected to arrive.
+	RestockExpectedAt *time.Time
+	// ReleaseDate makes every order placed before it a pre-order.
+	ReleaseDate    *time.Time
+	PurchaseLimits PurchaseLimits
+	Version        int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ArchivedAt     *time.Time
 }
 
 func NewProduct(description string, tags []string, price Money, inventory Inventory) (*Product, error) {
+	description, err := normalizeDescription(description)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Product{
+		ID:          NewProductID(),
+		Description: description,
+		Tags:        cleanTags(tags),
+		Price:       price,
+		Inventory:   inventory,
+		Version:     1,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+func normalizeDescription(description string) (string, error) {
 	description = strings.TrimSpace(description)
 	if description == "" {
-		return nil, ErrProductDescCannotBeEmpty
+		return "", ErrProductDescCannotBeEmpty
 	}
+	return description, nil
+}
 
+func cleanTags(tags []string) []string {
 	cleanedTags := make([]string, 0, len(tags))
 	for _, tag := range tags {
 		tag = strings.TrimSpace(tag)
@@ -95,33 +193,257 @@ func NewProduct(description string, tags []string, price Money, inventory Invent
 			cleanedTags = append(cleanedTags, tag)
 		}
 	}
+	return cleanedTags
+}
 
-	return &Product{
-		ID:          NewProductID(),
-		Description: description,
-		Tags:        cleanedTags,
-		Price:       price,
-		Inventory:   inventory,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}, nil
+// CheckVersion guards against lost updates: it fails when the caller expects
+// a different version than the one loaded. A nil expectation always passes.
+func (p *Product) CheckVersion(expected *int) error {
+	if expected != nil && *expected != p.Version {
+		return ErrProductVersionConflict
+	}
+	return nil
+}
+
+// UpdateDescription applies the same rules as NewProduct.
+func (p *Product) UpdateDescription(description string) error {
+	description, err := normalizeDescription(description)
+	if err != nil {
+		return err
+	}
+	p.Description = description
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateTags replaces the tags, dropping blank ones like NewProduct does.
+func (p *Product) UpdateTags(tags []string) {
+	p.Tags = cleanTags(tags)
+	p.UpdatedAt = time.Now()
 }
 
 func (p *Product) UpdatePrice(price Money) {
+	old := p.Price
 	p.Price = price
 	p.UpdatedAt = time.Now()
+	p.recordPriceChange(nil, old, price)
+}
+
+func (p *Product) UpdateVariantPrice(id VariantID, price Money) error {
+	variant, err := p.Variant(id)
+	if err != nil {
+		return err
+	}
+
+	old := variant.Price
+	variant.UpdatePrice(price)
+	p.UpdatedAt = variant.UpdatedAt
+	p.recordPriceChange(&id, old, price)
+	return nil
+}
+
+func (p *Product) recordPriceChange(variantID *VariantID, old, price Money) {
+	if old.Amount().Equal(price.Amount()) {
+		return
+	}
+
+	changed := ProductPriceChanged{
+		ProductID:  uuid.UUID(p.ID),
+		OldPrice:   old.Amount(),
+		NewPrice:   price.Amount(),
+		OccurredAt: p.UpdatedAt,
+	}
+	if variantID != nil {
+		id := uuid.UUID(*variantID)
+		changed.VariantID = &id
+	}
+	p.Record(changed)
 }
 
 func (p *Product) AdjustStock(quantity int) error {
 	if quantity > 0 {
-		return p.Inventory.Add(quantity)
+		if err := p.Inventory.Add(quantity); err != nil {
+			return err
+		}
 	} else if quantity < 0 {
-		return p.Inventory.Reserve(-quantity)
+		if err := p.Inventory.Remove(-quantity); err != nil {
+			return err
+		}
+	}
+
+	p.recordStockChange(nil, quantity, p.Inventory)
+	return nil
+}
+
+func (p *Product) AdjustVariantStock(id VariantID, quantity int) error {
+	variant, err := p.Variant(id)
+	if err != nil {
+		return err
 	}
 
+	if err := variant.AdjustStock(quantity); err != nil {
+		return err
+	}
+
+	p.recordStockChange(&id, quantity, variant.Inventory)
 	return nil
 }
 
+func (p *Product) recordStockChange(variantID *VariantID, delta int, inventory Inventory) {
+	if delta == 0 {
+		return
+	}
+
+	changed := ProductStockChanged{
+		ProductID:  uuid.UUID(p.ID),
+		Delta:      delta,
+		Quantity:   inventory.Quantity(),
+		Available:  inventory.Available(),
+		OccurredAt: time.Now(),
+	}
+	if variantID != nil {
+		id := uuid.UUID(*variantID)
+		changed.VariantID = &id
+	}
+	p.Record(changed)
+}
+
 func (p *Product) IsAvailable(quantity int) bool {
 	return p.Inventory.IsAvailable(quantity)
 }
+
+func (p *Product) SetReorderThreshold(threshold *int) error {
+	if threshold != nil && *threshold < 0 {
+		return ErrInvalidReorderThreshold
+	}
+	p.ReorderThreshold = threshold
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) SetBackorderLimit(limit *int) error {
+	if limit != nil && *limit < 0 {
+		return ErrInvalidBackorderLimit
+	}
+	p.BackorderLimit = limit
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) SetRestockExpectedAt(at *time.Time) {
+	p.RestockExpectedAt = at
+	p.UpdatedAt = time.Now()
+}
+
+func (p *Product) SetReleaseDate(at *time.Time) {
+	p.ReleaseDate = at
+	p.UpdatedAt = time.Now()
+}
+
+func (p *Product) SetPurchaseLimits(limits PurchaseLimits) {
+	p.PurchaseLimits = limits
+	p.UpdatedAt = time.Now()
+}
+
+func (p *Product) AllowsBackorders() bool {
+	return p.BackorderLimit != nil && *p.BackorderLimit > 0
+}
+
+// IsPreorderAt reports whether orders placed at now are pre-orders.
+func (p *Product) IsPreorderAt(now time.Time) bool {
+	return p.ReleaseDate != nil && now.Before(*p.ReleaseDate)
+}
+
+// IsLowStock reports whether the available quantity is at or below the
+// reorder threshold.
+func (p *Product) IsLowStock() bool {
+	return p.IsLowStockAt(p.Inventory.Available())
+}
+
+func (p *Product) IsLowStockAt(quantity int) bool {
+	return p.ReorderThreshold != nil && quantity <= *p.ReorderThreshold
+}
+
+// Archive hides the product from listings and makes it unorderable while
+// keeping it referenced by historical orders.
+func (p *Product) Archive() error {
+	if p.IsArchived() {
+		return ErrProductAlreadyArchived
+	}
+	now := time.Now()
+	p.ArchivedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+func (p *Product) Unarchive() error {
+	if !p.IsArchived() {
+		return ErrProductNotArchived
+	}
+	p.ArchivedAt = nil
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// DefineOptions sets the option axes variants are described by. Axes are
+// frozen once the product has variants.
+func (p *Product) DefineOptions(axes []OptionAxis) error {
+	if len(p.Variants) > 0 {
+		return ErrOptionsLockedByVariants
+	}
+
+	seen := make(map[string]struct{}, len(axes))
+	for _, axis := range axes {
+		if _, ok := seen[axis.Name]; ok {
+			return ErrDuplicateOptionName
+		}
+		seen[axis.Name] = struct{}{}
+	}
+
+	p.Options = axes
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) AddVariant(variant *Variant) error {
+	if len(variant.Options) != len(p.Options) {
+		return ErrInvalidVariantOptions
+	}
+	for _, axis := range p.Options {
+		value, ok := variant.Options[axis.Name]
+		if !ok || !axis.HasValue(value) {
+			return ErrInvalidVariantOptions
+		}
+	}
+
+	for _, existing := range p.Variants {
+		if existing.SKU == variant.SKU {
+			return ErrDuplicateSKU
+		}
+		if existing.sameOptions(variant.Options) {
+			return ErrDuplicateVariantOptions
+		}
+	}
+
+	variant.ProductID = p.ID
+	p.Variants = append(p.Variants, variant)
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) Variant(id VariantID) (*Variant, error) {
+	for _, variant := range p.Variants {
+		if variant.ID == id {
+			return variant, nil
+		}
+	}
+	return nil, ErrVariantNotFound
+}
+
+func (p *Product) HasVariants() bool {
+	return len(p.Variants) > 0
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -55,7 +56,10 @@ func TestInventory_Reserve_Success(t *testing.T) {
 
 	err := inventory.Reserve(3)
 	assert.NoError(t, err)
-	assert.Equal(t, 7, inventory.Quantity())
+	assert.Equal(t, 10, inventory.Quantity())
+	assert.Equal(t, 3, inventory.Reserved())
+	assert.Equal(t, 7, inventory.Available())
+	assert.False(t, inventory.IsAvailable(8))
 }
 
 func TestInventory_Reserve_InsufficientStock(t *testing.T) {
@@ -67,6 +71,39 @@ func TestInventory_Reserve_InsufficientStock(t *testing.T) {
 	assert.Equal(t, 5, inventory.Quantity()) // Quantity should remain unchanged
 }
 
+func TestInventory_CommitAndRelease(t *testing.T) {
+	inventory, _ := NewInventory(10)
+	_ = inventory.Reserve(5)
+
+	assert.NoError(t, inventory.Commit(2))
+	assert.Equal(t, 8, inventory.Quantity())
+	assert.Equal(t, 3, inventory.Reserved())
+
+	assert.NoError(t, inventory.Release(3))
+	assert.Equal(t, 8, inventory.Quantity())
+	assert.Equal(t, 0, inventory.Reserved())
+
+	assert.Equal(t, ErrInvalidQuantity, inventory.Commit(1))
+	assert.Equal(t, ErrInvalidQuantity, inventory.Release(1))
+}
+
+func TestInventory_Remove_KeepsReservedStock(t *testing.T) {
+	inventory, _ := RestoreInventory(10, 4)
+
+	err := inventory.Remove(7)
+	assert.Equal(t, ErrInsufficientStock, err)
+	assert.Equal(t, 10, inventory.Quantity())
+
+	assert.NoError(t, inventory.Remove(6))
+	assert.Equal(t, 4, inventory.Quantity())
+	assert.Equal(t, 0, inventory.Available())
+}
+
+func TestRestoreInventory_ReservedAboveOnHand(t *testing.T) {
+	_, err := RestoreInventory(2, 3)
+	assert.Equal(t, ErrInsufficientStock, err)
+}
+
 func TestInventory_Add_Success(t *testing.T) {
 	inventory, _ := NewInventory(10)
 
@@ -156,6 +193,22 @@ func TestProduct_AdjustStock_Negative(t *testing.T) {
 	assert.Equal(t, 7, product.Inventory.Quantity())
 }
 
+func TestProduct_AdjustStock_RecordsEvent(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(19.99))
+	inventory, _ := RestoreInventory(10, 4)
+	product, _ := NewProduct("Test Product", []string{}, price, inventory)
+
+	assert.NoError(t, product.AdjustStock(-3))
+
+	events := product.PullEvents()
+	assert.Len(t, events, 1)
+	changed := events[0].(ProductStockChanged)
+	assert.Equal(t, -3, changed.Delta)
+	assert.Equal(t, 7, changed.Quantity)
+	assert.Equal(t, 3, changed.Available)
+	assert.Nil(t, changed.VariantID)
+}
+
 func TestProduct_AdjustStock_InsufficientForNegative(t *testing.T) {
 	price, _ := NewMoney(decimal.NewFromFloat(19.99))
 	inventory, _ := NewInventory(5)
@@ -175,4 +228,129 @@ func TestProduct_IsAvailable(t *testing.T) {
 	assert.True(t, product.IsAvailable(5))
 	assert.True(t, product.IsAvailable(10))
 	assert.False(t, product.IsAvailable(15))
-}
\ No newline at end of file
+}
+
+func TestProduct_Archive(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(19.99))
+	inventory, _ := NewInventory(10)
+	product, _ := NewProduct("Test Product", []string{}, price, inventory)
+
+	assert.False(t, product.IsArchived())
+
+	err := product.Archive()
+	assert.NoError(t, err)
+	assert.True(t, product.IsArchived())
+
+	err = product.Archive()
+	assert.Equal(t, ErrProductAlreadyArchived, err)
+}
+
+func TestProduct_Unarchive(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(19.99))
+	inventory, _ := NewInventory(10)
+	product, _ := NewProduct("Test Product", []string{}, price, inventory)
+
+	err := product.Unarchive()
+	assert.Equal(t, ErrProductNotArchived, err)
+
+	_ = product.Archive()
+	err = product.Unarchive()
+	assert.NoError(t, err)
+	assert.False(t, product.IsArchived())
+}
+
+func TestProduct_CheckVersion(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(19.99))
+	inventory, _ := NewInventory(10)
+	product, _ := NewProduct("Test Product", []string{}, price, inventory)
+
+	current, stale := 1, 0
+
+	assert.Equal(t, 1, product.Version)
+	assert.NoError(t, product.CheckVersion(nil))
+	assert.NoError(t, product.CheckVersion(&current))
+	assert.Equal(t, ErrProductVersionConflict, product.CheckVersion(&stale))
+}
+
+func TestProduct_UpdateDescription(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(19.99))
+	inventory, _ := NewInventory(10)
+	product, _ := NewProduct("Test Product", []string{}, price, inventory)
+
+	err := product.UpdateDescription("  Fixed Product  ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Fixed Product", product.Description)
+
+	err = product.UpdateDescription("   ")
+	assert.Equal(t, ErrProductDescCannotBeEmpty, err)
+	assert.Equal(t, "Fixed Product", product.Description)
+}
+
+func TestProduct_UpdateTags(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(19.99))
+	inventory, _ := NewInventory(10)
+	product, _ := NewProduct("Test Product", []string{"old"}, price, inventory)
+
+	product.UpdateTags([]string{" new ", "", "sale"})
+	assert.Equal(t, []string{"new", "sale"}, product.Tags)
+
+	product.UpdateTags(nil)
+	assert.Empty(t, product.Tags)
+}
+
+func TestProduct_IsLowStock(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(5))
+	inventory, _ := NewInventory(5)
+	product, _ := NewProduct("Pen", nil, price, inventory)
+
+	assert.False(t, product.IsLowStock())
+
+	threshold := 5
+	assert.NoError(t, product.SetReorderThreshold(&threshold))
+	assert.True(t, product.IsLowStock())
+	assert.False(t, product.IsLowStockAt(6))
+
+	negative := -1
+	assert.Equal(t, ErrInvalidReorderThreshold, product.SetReorderThreshold(&negative))
+	assert.Equal(t, 5, *product.ReorderThreshold)
+}
+
+func TestNewStockAlert(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(5))
+	inventory, _ := NewInventory(2)
+	product, _ := NewProduct("Pen", nil, price, inventory)
+
+	_, err := NewStockAlert(product)
+	assert.Equal(t, ErrStockNotLow, err)
+
+	threshold := 3
+	assert.NoError(t, product.SetReorderThreshold(&threshold))
+
+	alert, err := NewStockAlert(product)
+	assert.NoError(t, err)
+	assert.Equal(t, product.ID, alert.ProductID)
+	assert.Equal(t, 3, alert.Threshold)
+	assert.Equal(t, 2, alert.Quantity)
+	assert.True(t, alert.IsOpen())
+}
+
+func TestProduct_BackordersAndPreorders(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromInt(10))
+	inventory, _ := NewInventory(0)
+	product, _ := NewProduct("Console", nil, price, inventory)
+
+	assert.False(t, product.AllowsBackorders())
+	assert.False(t, product.IsPreorderAt(time.Now()))
+
+	negative := -1
+	assert.Equal(t, ErrInvalidBackorderLimit, product.SetBackorderLimit(&negative))
+
+	limit := 5
+	assert.NoError(t, product.SetBackorderLimit(&limit))
+	assert.True(t, product.AllowsBackorders())
+
+	release := time.Now().Add(24 * time.Hour)
+	product.SetReleaseDate(&release)
+	assert.True(t, product.IsPreorderAt(time.Now()))
+	assert.False(t, product.IsPreorderAt(release))
+}
@@ -1,15 +1,44 @@
 package domain
 
-import "errors"
+import "github.com/BlackRRR/Irtea-test/pkg/errcode"
 
 var (
-	ErrProductNotFound              = errors.New("product not found")
-	ErrInsufficientStock            = errors.New("insufficient stock")
-	ErrQuantityToAddMustBePositive  = errors.New("quantity to add must be positive")
-	ErrInventoryQuantityCannotBeNeg = errors.New("inventory quantity cannot be negative")
-	ErrMoneyCannotBeNeg             = errors.New("money cannot be negative")
-	ErrProductDescCannotBeEmpty     = errors.New("product description cannot be empty")
-	ErrQuantityToAddMustBe          = errors.New("quantity must be less than zero")
-	ErrInvalidPrice                 = errors.New("invalid price")
-	ErrInvalidQuantity              = errors.New("invalid quantity")
+	ErrProductNotFound              = errcode.New("product-not-found", "product not found")
+	ErrInsufficientStock            = errcode.New("insufficient-stock", "insufficient stock")
+	ErrQuantityToAddMustBePositive  = errcode.New("quantity-to-add-not-positive", "quantity to add must be positive")
+	ErrInventoryQuantityCannotBeNeg = errcode.New("negative-inventory", "inventory quantity cannot be negative")
+	ErrMoneyCannotBeNeg             = errcode.New("negative-price", "money cannot be negative")
+	ErrProductDescCannotBeEmpty     = errcode.New("empty-description", "product description cannot be empty")
+	ErrQuantityToAddMustBe          = errcode.New("quantity-to-remove-not-negative", "quantity must be less than zero")
+	ErrInvalidPrice                 = errcode.New("invalid-price", "invalid price")
+	ErrInvalidQuantity              = errcode.New("invalid-quantity", "invalid quantity")
+	ErrVariantNotFound              = errcode.New("variant-not-found", "product variant not found")
+	ErrVariantRequired              = errcode.New("variant-required", "product has variants, variant must be specified")
+	ErrVariantSKUCannotBeEmpty      = errcode.New("empty-sku", "variant sku cannot be empty")
+	ErrDuplicateSKU                 = errcode.New("duplicate-sku", "variant with this sku already exists")
+	ErrInvalidVariantOptions        = errcode.New("invalid-variant-options", "variant options do not match product option axes")
+	ErrDuplicateVariantOptions      = errcode.New("duplicate-variant-options", "variant with these options already exists")
+	ErrOptionNameCannotBeEmpty      = errcode.New("empty-option-name", "option name cannot be empty")
+	ErrOptionValuesCannotBeEmpty    = errcode.New("empty-option-values", "option values cannot be empty")
+	ErrDuplicateOptionName          = errcode.New("duplicate-option-name", "option names must be unique")
+	ErrOptionsLockedByVariants      = errcode.New("options-locked", "options cannot be changed once variants exist")
+	ErrProductArchived              = errcode.New("product-archived", "product is archived")
+	ErrProductAlreadyArchived       = errcode.New("product-already-archived", "product is already archived")
+	ErrProductNotArchived           = errcode.New("product-not-archived", "product is not archived")
+	ErrProductHasOrders             = errcode.New("product-has-orders", "product has been ordered and cannot be deleted")
+	ErrProductVersionConflict       = errcode.New("version-conflict", "product was modified concurrently")
+	ErrImageNotFound                = errcode.New("image-not-found", "product image not found")
+	ErrTooManyImages                = errcode.New("too-many-images", "product has too many images")
+	ErrInvalidImageOrder            = errcode.New("invalid-image-order", "image order must list every product image exactly once")
+	ErrUnsupportedImageType         = errcode.New("unsupported-image-type", "unsupported image type")
+	ErrImageTooLarge                = errcode.New("image-too-large", "image is too large")
+	ErrInvalidReorderThreshold      = errcode.New("invalid-reorder-threshold", "reorder threshold cannot be negative")
+	ErrStockNotLow                  = errcode.New("stock-not-low", "product stock is above its reorder threshold")
+	ErrStockAlertAlreadyOpen        = errcode.New("stock-alert-already-open", "product already has an open stock alert")
+	ErrReservationNotActive         = errcode.New("reservation-not-active", "stock reservation is not active")
+	ErrReservationExpired           = errcode.New("reservation-expired", "stock reservation has expired")
+	ErrInvalidBackorderLimit        = errcode.New("invalid-backorder-limit", "backorder limit cannot be negative")
+	ErrBackordersNotAllowed         = errcode.New("backorders-not-allowed", "product does not accept backorders or pre-orders")
+	ErrBackorderLimitExceeded       = errcode.New("backorder-limit-exceeded", "product backorder limit exceeded")
+	ErrInvalidPurchaseLimits        = errcode.New("invalid-purchase-limits", "purchase limits must be positive and a per-user limit needs a window")
 )
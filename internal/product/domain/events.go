@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	EventProductPriceChanged = "product.price_changed"
+	EventProductStockChanged = "product.stock_changed"
+	EventProductStockLow     = "product.stock_low"
+)
+
+// ProductPriceChanged is raised when the price of a product or, with
+// VariantID set, of one of its variants changes.
+type ProductPriceChanged struct {
+	ProductID  uuid.UUID       `json:"product_id"`
+	VariantID  *uuid.UUID      `json:"variant_id,omitempty"`
+	OldPrice   decimal.Decimal `json:"old_price"`
+	NewPrice   decimal.Decimal `json:"new_price"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+func (e ProductPriceChanged) EventName() string   { return EventProductPriceChanged }
+func (e ProductPriceChanged) AggregateID() string { return e.ProductID.String() }
+
+// ProductStockChanged is raised when on-hand stock of a product or, with
+// VariantID set, of one of its variants is adjusted.
+type ProductStockChanged struct {
+	ProductID  uuid.UUID  `json:"product_id"`
+	VariantID  *uuid.UUID `json:"variant_id,omitempty"`
+	Delta      int        `json:"delta"`
+	Quantity   int        `json:"quantity"`
+	Available  int        `json:"available"`
+	OccurredAt time.Time  `json:"occurred_at"`
+}
+
+func (e ProductStockChanged) EventName() string   { return EventProductStockChanged }
+func (e ProductStockChanged) AggregateID() string { return e.ProductID.String() }
+
+// ProductStockLow is raised when a stock alert opens for a product. It only
+// feeds the stock notifier and is not offered to webhook subscribers.
+type ProductStockLow struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	Description string    `json:"description"`
+	Quantity    int       `json:"quantity"`
+	Threshold   int       `json:"threshold"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+func (e ProductStockLow) EventName() string   { return EventProductStockLow }
+func (e ProductStockLow) AggregateID() string { return e.ProductID.String() }
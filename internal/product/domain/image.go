@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const MaxProductImages = 20
+
+type ImageID uuid.UUID
+
+func NewImageID() ImageID {
+	return ImageID(uuid.New())
+}
+
+func (id ImageID) String() string {
+	return uuid.UUID(id).String()
+}
+
+// Image is a picture of a product kept in blob storage. Keys point to the
+// original upload and its thumbnail.
+type Image struct {
+	ID           ImageID
+	ProductID    ProductID
+	Key          string
+	ThumbnailKey string
+	ContentType  string
+	Size         int64
+	Width        int
+	Height       int
+	Position     int
+	IsPrimary    bool
+	CreatedAt    time.Time
+}
+
+// AddImage appends the image after the existing ones. The first image of a
+// product becomes its primary image.
+func (p *Product) AddImage(image *Image) error {
+	if len(p.Images) >= MaxProductImages {
+		return ErrTooManyImages
+	}
+
+	image.ProductID = p.ID
+	image.Position = len(p.Images)
+	image.IsPrimary = len(p.Images) == 0
+	p.Images = append(p.Images, image)
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) Image(id ImageID) (*Image, error) {
+	for _, image := range p.Images {
+		if image.ID == id {
+			return image, nil
+		}
+	}
+	return nil, ErrImageNotFound
+}
+
+// RemoveImage drops the image and closes the gap in positions. If the primary
+// image is removed, the next one in order takes its place.
+func (p *Product) RemoveImage(id ImageID) (*Image, error) {
+	index := -1
+	for i, image := range p.Images {
+		if image.ID == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, ErrImageNotFound
+	}
+
+	removed := p.Images[index]
+	p.Images = append(p.Images[:index:index], p.Images[index+1:]...)
+	for i, image := range p.Images {
+		image.Position = i
+	}
+	if removed.IsPrimary && len(p.Images) > 0 {
+		p.Images[0].IsPrimary = true
+	}
+
+	p.UpdatedAt = time.Now()
+	return removed, nil
+}
+
+// ReorderImages sets the display order. ids must list every image of the
+// product exactly once.
+func (p *Product) ReorderImages(ids []ImageID) error {
+	if len(ids) != len(p.Images) {
+		return ErrInvalidImageOrder
+	}
+
+	byID := make(map[ImageID]*Image, len(p.Images))
+	for _, image := range p.Images {
+		byID[image.ID] = image
+	}
+
+	ordered := make([]*Image, 0, len(ids))
+	for _, id := range ids {
+		image, ok := byID[id]
+		if !ok {
+			return ErrInvalidImageOrder
+		}
+		delete(byID, id)
+		ordered = append(ordered, image)
+	}
+
+	for i, image := range ordered {
+		image.Position = i
+	}
+	p.Images = ordered
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) SetPrimaryImage(id ImageID) error {
+	if _, err := p.Image(id); err != nil {
+		return err
+	}
+
+	for _, image := range p.Images {
+		image.IsPrimary = image.ID == id
+	}
+
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) PrimaryImage() *Image {
+	for _, image := range p.Images {
+		if image.IsPrimary {
+			return image
+		}
+	}
+	return nil
+}
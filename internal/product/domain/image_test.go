@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProductWithImages(t *testing.T, count int) (*Product, []ImageID) {
+	price, _ := NewMoney(decimal.NewFromFloat(9.99))
+	inventory, _ := NewInventory(1)
+	product, _ := NewProduct("Mug", nil, price, inventory)
+
+	ids := make([]ImageID, 0, count)
+	for range count {
+		image := &Image{ID: NewImageID()}
+		assert.NoError(t, product.AddImage(image))
+		ids = append(ids, image.ID)
+	}
+	return product, ids
+}
+
+func TestProduct_AddImage_FirstIsPrimary(t *testing.T) {
+	product, ids := newTestProductWithImages(t, 2)
+
+	assert.Equal(t, ids[0], product.PrimaryImage().ID)
+	assert.Equal(t, 1, product.Images[1].Position)
+	assert.Equal(t, product.ID, product.Images[1].ProductID)
+}
+
+func TestProduct_AddImage_TooMany(t *testing.T) {
+	product, _ := newTestProductWithImages(t, MaxProductImages)
+
+	err := product.AddImage(&Image{ID: NewImageID()})
+
+	assert.Equal(t, ErrTooManyImages, err)
+}
+
+func TestProduct_RemoveImage_PromotesNextPrimary(t *testing.T) {
+	product, ids := newTestProductWithImages(t, 3)
+
+	removed, err := product.RemoveImage(ids[0])
+
+	assert.NoError(t, err)
+	assert.Equal(t, ids[0], removed.ID)
+	assert.Len(t, product.Images, 2)
+	assert.Equal(t, ids[1], product.PrimaryImage().ID)
+	assert.Equal(t, 0, product.Images[0].Position)
+	assert.Equal(t, 1, product.Images[1].Position)
+}
+
+func TestProduct_ReorderImages(t *testing.T) {
+	product, ids := newTestProductWithImages(t, 3)
+
+	err := product.ReorderImages([]ImageID{ids[2], ids[0], ids[1]})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ids[2], product.Images[0].ID)
+	assert.Equal(t, 2, product.Images[2].Position)
+	assert.Equal(t, ids[0], product.PrimaryImage().ID)
+}
+
+func TestProduct_ReorderImages_Invalid(t *testing.T) {
+	product, ids := newTestProductWithImages(t, 2)
+
+	assert.Equal(t, ErrInvalidImageOrder, product.ReorderImages([]ImageID{ids[0]}))
+	assert.Equal(t, ErrInvalidImageOrder, product.ReorderImages([]ImageID{ids[0], ids[0]}))
+	assert.Equal(t, ids[0], product.Images[0].ID)
+}
+
+func TestProduct_SetPrimaryImage(t *testing.T) {
+	product, ids := newTestProductWithImages(t, 2)
+
+	assert.NoError(t, product.SetPrimaryImage(ids[1]))
+	assert.Equal(t, ids[1], product.PrimaryImage().ID)
+	assert.False(t, product.Images[0].IsPrimary)
+
+	assert.Equal(t, ErrImageNotFound, product.SetPrimaryImage(NewImageID()))
+}
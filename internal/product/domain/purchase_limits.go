@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// PurchaseLimits keep a few accounts from buying out a product. MaxPerOrder
+// caps the units of the product in a single order, MaxPerUser the units one
+// customer may order within the rolling Window. Nil caps are not enforced.
+type PurchaseLimits struct {
+	MaxPerOrder *int
+	MaxPerUser  *int
+	Window      time.Duration
+}
+
+func NewPurchaseLimits(maxPerOrder, maxPerUser *int, window time.Duration) (PurchaseLimits, error) {
+	if maxPerOrder != nil && *maxPerOrder <= 0 {
+		return PurchaseLimits{}, ErrInvalidPurchaseLimits
+	}
+	if maxPerUser != nil && (*maxPerUser <= 0 || window <= 0) {
+		return PurchaseLimits{}, ErrInvalidPurchaseLimits
+	}
+	if maxPerUser == nil && window != 0 {
+		return PurchaseLimits{}, ErrInvalidPurchaseLimits
+	}
+
+	return PurchaseLimits{
+		MaxPerOrder: maxPerOrder,
+		MaxPerUser:  maxPerUser,
+		Window:      window,
+	}, nil
+}
+
+func (l PurchaseLimits) IsZero() bool {
+	return l.MaxPerOrder == nil && l.MaxPerUser == nil
+}
+
+// AllowsOrder reports whether quantity units fit in a single order.
+func (l PurchaseLimits) AllowsOrder(quantity int) bool {
+	return l.MaxPerOrder == nil || quantity <= *l.MaxPerOrder
+}
+
+// AllowsUser reports whether a customer who already ordered purchased units
+// within the window may order quantity more.
+func (l PurchaseLimits) AllowsUser(purchased, quantity int) bool {
+	return l.MaxPerUser == nil || purchased+quantity <= *l.MaxPerUser
+}
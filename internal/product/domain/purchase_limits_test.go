@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPurchaseLimits(t *testing.T) {
+	two, zero := 2, 0
+
+	limits, err := NewPurchaseLimits(&two, &two, 24*time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, limits.IsZero())
+
+	_, err = NewPurchaseLimits(&zero, nil, 0)
+	assert.Equal(t, ErrInvalidPurchaseLimits, err)
+
+	_, err = NewPurchaseLimits(nil, &two, 0)
+	assert.Equal(t, ErrInvalidPurchaseLimits, err)
+
+	_, err = NewPurchaseLimits(nil, nil, time.Hour)
+	assert.Equal(t, ErrInvalidPurchaseLimits, err)
+
+	limits, err = NewPurchaseLimits(nil, nil, 0)
+	assert.NoError(t, err)
+	assert.True(t, limits.IsZero())
+}
+
+func TestPurchaseLimits_Allows(t *testing.T) {
+	perOrder, perUser := 2, 5
+	limits, _ := NewPurchaseLimits(&perOrder, &perUser, time.Hour)
+
+	assert.True(t, limits.AllowsOrder(2))
+	assert.False(t, limits.AllowsOrder(3))
+
+	assert.True(t, limits.AllowsUser(3, 2))
+	assert.False(t, limits.AllowsUser(4, 2))
+
+	assert.True(t, PurchaseLimits{}.AllowsOrder(100))
+	assert.True(t, PurchaseLimits{}.AllowsUser(100, 100))
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationID uuid.UUID
+
+func NewReservationID() ReservationID {
+	return ReservationID(uuid.New())
+}
+
+func (id ReservationID) String() string {
+	return uuid.UUID(id).String()
+}
+
+type ReservationStatus string
+
+const (
+	// ReservationWaiting is a backorder or pre-order that holds no stock yet.
+	ReservationWaiting ReservationStatus = "waiting"
+	// ReservationActive holds stock until the order is confirmed or the
+	// reservation expires.
+	ReservationActive    ReservationStatus = "active"
+	ReservationCommitted ReservationStatus = "committed"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationExpired   ReservationStatus = "expired"
+	// ReservationReturned puts committed stock back on hand when a confirmed
+	// order is cancelled.
+	ReservationReturned ReservationStatus = "returned"
+)
+
+// ReservationKind tells how an order line is fulfilled.
+type ReservationKind string
+
+const (
+	ReservationInStock   ReservationKind = "in_stock"
+	ReservationBackorder ReservationKind = "backorder"
+	ReservationPreorder  ReservationKind = "preorder"
+)
+
+func (k ReservationKind) IsValid() bool {
+	switch k {
+	case ReservationInStock, ReservationBackorder, ReservationPreorder:
+		return true
+	default:
+		return false
+	}
+}
+
+// Reservation holds stock of a product or one of its variants for an order.
+// OrderID is a plain UUID since orders live in another context.
+type Reservation struct {
+	ID        ReservationID
+	OrderID   uuid.UUID
+	ProductID ProductID
+	VariantID *VariantID
+	Quantity  int
+	Kind      ReservationKind
+	Status    ReservationStatus
+	// Confirmed is set when the order is confirmed while the reservation
+	// is still waiting, so allocation commits the stock right away.
+	Confirmed bool
+	// ExpiresAt is nil while the reservation is waiting for stock.
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewReservation(orderID uuid.UUID, productID ProductID, variantID *VariantID, quantity int, ttl time.Duration) (*Reservation, error) {
+	reservation, err := newReservation(orderID, productID, variantID, quantity, ReservationInStock)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := reservation.CreatedAt.Add(ttl)
+	reservation.Status = ReservationActive
+	reservation.ExpiresAt = &expiresAt
+	return reservation, nil
+}
+
+// NewWaitingReservation queues a backorder or pre-order until stock is
+// allocated to it.
+func NewWaitingReservation(
+	orderID uuid.UUID,
+	productID ProductID,
+	variantID *VariantID,
+	quantity int,
+	kind ReservationKind,
+) (*Reservation, error) {
+	if kind != ReservationBackorder && kind != ReservationPreorder {
+		return nil, ErrBackordersNotAllowed
+	}
+
+	reservation, err := newReservation(orderID, productID, variantID, quantity, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation.Status = ReservationWaiting
+	return reservation, nil
+}
+
+func newReservation(orderID uuid.UUID, productID ProductID, variantID *VariantID, quantity int, kind ReservationKind) (*Reservation, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	now := time.Now()
+	return &Reservation{
+		ID:        NewReservationID(),
+		OrderID:   orderID,
+		ProductID: productID,
+		VariantID: variantID,
+		Quantity:  quantity,
+		Kind:      kind,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (r *Reservation) IsExpiredAt(now time.Time) bool {
+	return r.Status == ReservationActive && r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
+
+// Commit converts the reservation into a stock decrement. An active
+// reservation past its expiry can no longer be committed; a waiting one is
+// marked to be committed once stock is allocated to it.
+func (r *Reservation) Commit(now time.Time) error {
+	if r.Status == ReservationWaiting {
+		r.Confirmed = true
+		r.UpdatedAt = now
+		return nil
+	}
+	if r.Status != ReservationActive {
+		return ErrReservationNotActive
+	}
+	if r.IsExpiredAt(now) {
+		return ErrReservationExpired
+	}
+	return r.transition(ReservationCommitted, now)
+}
+
+// Allocate gives stock to a waiting reservation. It is committed straight
+// away when the order was already confirmed and held for ttl otherwise.
+func (r *Reservation) Allocate(now time.Time, ttl time.Duration) error {
+	if r.Status != ReservationWaiting {
+		return ErrReservationNotActive
+	}
+	if r.Confirmed {
+		return r.transition(ReservationCommitted, now)
+	}
+
+	expiresAt := now.Add(ttl)
+	r.ExpiresAt = &expiresAt
+	return r.transition(ReservationActive, now)
+}
+
+func (r *Reservation) Release(now time.Time) error {
+	if r.Status != ReservationActive && r.Status != ReservationWaiting {
+		return ErrReservationNotActive
+	}
+	return r.transition(ReservationReleased, now)
+}
+
+func (r *Reservation) Expire(now time.Time) error {
+	if !r.IsExpiredAt(now) {
+		return ErrReservationNotActive
+	}
+	return r.transition(ReservationExpired, now)
+}
+
+func (r *Reservation) Return(now time.Time) error {
+	if r.Status != ReservationCommitted {
+		return ErrReservationNotActive
+	}
+	return r.transition(ReservationReturned, now)
+}
+
+func (r *Reservation) transition(status ReservationStatus, now time.Time) error {
+	r.Status = status
+	r.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReservation(t *testing.T) {
+	orderID := uuid.New()
+	productID := NewProductID()
+
+	reservation, err := NewReservation(orderID, productID, nil, 2, 15*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, orderID, reservation.OrderID)
+	assert.Equal(t, productID, reservation.ProductID)
+	assert.Equal(t, ReservationActive, reservation.Status)
+	assert.Equal(t, ReservationInStock, reservation.Kind)
+	assert.Equal(t, 15*time.Minute, reservation.ExpiresAt.Sub(reservation.CreatedAt))
+
+	_, err = NewReservation(orderID, productID, nil, 0, time.Minute)
+	assert.Equal(t, ErrInvalidQuantity, err)
+}
+
+func TestReservation_Commit(t *testing.T) {
+	reservation, _ := NewReservation(uuid.New(), NewProductID(), nil, 1, time.Minute)
+
+	assert.NoError(t, reservation.Commit(time.Now()))
+	assert.Equal(t, ReservationCommitted, reservation.Status)
+
+	assert.Equal(t, ErrReservationNotActive, reservation.Commit(time.Now()))
+	assert.Equal(t, ErrReservationNotActive, reservation.Release(time.Now()))
+
+	assert.NoError(t, reservation.Return(time.Now()))
+	assert.Equal(t, ReservationReturned, reservation.Status)
+}
+
+func TestReservation_CommitAfterExpiry(t *testing.T) {
+	reservation, _ := NewReservation(uuid.New(), NewProductID(), nil, 1, time.Minute)
+	later := reservation.ExpiresAt.Add(time.Second)
+
+	assert.Equal(t, ErrReservationExpired, reservation.Commit(later))
+	assert.Equal(t, ReservationActive, reservation.Status)
+}
+
+func TestReservation_Expire(t *testing.T) {
+	reservation, _ := NewReservation(uuid.New(), NewProductID(), nil, 1, time.Minute)
+
+	assert.Equal(t, ErrReservationNotActive, reservation.Expire(time.Now()))
+
+	assert.NoError(t, reservation.Expire(*reservation.ExpiresAt))
+	assert.Equal(t, ReservationExpired, reservation.Status)
+}
+
+func TestWaitingReservation_AllocateBeforeConfirm(t *testing.T) {
+	reservation, err := NewWaitingReservation(uuid.New(), NewProductID(), nil, 2, ReservationBackorder)
+	assert.NoError(t, err)
+	assert.Equal(t, ReservationWaiting, reservation.Status)
+	assert.Nil(t, reservation.ExpiresAt)
+	assert.False(t, reservation.IsExpiredAt(time.Now().Add(time.Hour)))
+
+	now := time.Now()
+	assert.NoError(t, reservation.Allocate(now, time.Minute))
+	assert.Equal(t, ReservationActive, reservation.Status)
+	assert.Equal(t, now.Add(time.Minute), *reservation.ExpiresAt)
+}
+
+func TestWaitingReservation_ConfirmedIsCommittedOnAllocation(t *testing.T) {
+	reservation, _ := NewWaitingReservation(uuid.New(), NewProductID(), nil, 2, ReservationPreorder)
+
+	assert.NoError(t, reservation.Commit(time.Now()))
+	assert.Equal(t, ReservationWaiting, reservation.Status)
+	assert.True(t, reservation.Confirmed)
+
+	assert.NoError(t, reservation.Allocate(time.Now(), time.Minute))
+	assert.Equal(t, ReservationCommitted, reservation.Status)
+}
+
+func TestNewWaitingReservation_InStockKind(t *testing.T) {
+	_, err := NewWaitingReservation(uuid.New(), NewProductID(), nil, 2, ReservationInStock)
+	assert.Equal(t, ErrBackordersNotAllowed, err)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockAlertID uuid.UUID
+
+func NewStockAlertID() StockAlertID {
+	return StockAlertID(uuid.New())
+}
+
+func (id StockAlertID) String() string {
+	return uuid.UUID(id).String()
+}
+
+// StockAlert records that a product fell to its reorder threshold. It stays
+// open until stock is raised above the threshold again.
+type StockAlert struct {
+	ID          StockAlertID
+	ProductID   ProductID
+	Description string
+	Threshold   int
+	Quantity    int
+	CreatedAt   time.Time
+	ResolvedAt  *time.Time
+}
+
+func NewStockAlert(product *Product) (*StockAlert, error) {
+	if !product.IsLowStock() {
+		return nil, ErrStockNotLow
+	}
+
+	return &StockAlert{
+		ID:          NewStockAlertID(),
+		ProductID:   product.ID,
+		Description: product.Description,
+		Threshold:   *product.ReorderThreshold,
+		Quantity:    product.Inventory.Available(),
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+func (a *StockAlert) IsOpen() bool {
+	return a.ResolvedAt == nil
+}
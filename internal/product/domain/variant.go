@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VariantID uuid.UUID
+
+func NewVariantID() VariantID {
+	return VariantID(uuid.New())
+}
+
+func (id VariantID) String() string {
+	return uuid.UUID(id).String()
+}
+
+// OptionAxis is a dimension a product varies along, e.g. size or color.
+type OptionAxis struct {
+	Name   string
+	Values []string
+}
+
+func NewOptionAxis(name string, values []string) (OptionAxis, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return OptionAxis{}, ErrOptionNameCannotBeEmpty
+	}
+
+	seen := make(map[string]struct{}, len(values))
+	cleanedValues := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		cleanedValues = append(cleanedValues, value)
+	}
+
+	if len(cleanedValues) == 0 {
+		return OptionAxis{}, ErrOptionValuesCannotBeEmpty
+	}
+
+	return OptionAxis{
+		Name:   name,
+		Values: cleanedValues,
+	}, nil
+}
+
+func (a OptionAxis) HasValue(value string) bool {
+	for _, v := range a.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Variant is a sellable SKU of a product with its own price and stock.
+type Variant struct {
+	ID        VariantID
+	ProductID ProductID
+	SKU       string
+	Barcode   string
+	Options   map[string]string
+	Price     Money
+	Inventory Inventory
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewVariant(sku, barcode string, options map[string]string, price Money, inventory Inventory) (*Variant, error) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil, ErrVariantSKUCannotBeEmpty
+	}
+
+	cleanedOptions := make(map[string]string, len(options))
+	for name, value := range options {
+		cleanedOptions[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	return &Variant{
+		ID:        NewVariantID(),
+		SKU:       sku,
+		Barcode:   strings.TrimSpace(barcode),
+		Options:   cleanedOptions,
+		Price:     price,
+		Inventory: inventory,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (v *Variant) UpdatePrice(price Money) {
+	v.Price = price
+	v.UpdatedAt = time.Now()
+}
+
+func (v *Variant) AdjustStock(quantity int) error {
+	if quantity > 0 {
+		if err := v.Inventory.Add(quantity); err != nil {
+			return err
+		}
+	} else if quantity < 0 {
+		if err := v.Inventory.Remove(-quantity); err != nil {
+			return err
+		}
+	}
+
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+func (v *Variant) IsAvailable(quantity int) bool {
+	return v.Inventory.IsAvailable(quantity)
+}
+
+func (v *Variant) sameOptions(options map[string]string) bool {
+	if len(v.Options) != len(options) {
+		return false
+	}
+	for name, value := range v.Options {
+		if options[name] != value {
+			return false
+		}
+	}
+	return true
+}
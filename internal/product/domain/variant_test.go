@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProductWithOptions(t *testing.T) *Product {
+	price, _ := NewMoney(decimal.NewFromFloat(19.99))
+	inventory, _ := NewInventory(0)
+	product, _ := NewProduct("T-shirt", []string{"apparel"}, price, inventory)
+
+	size, err := NewOptionAxis("size", []string{"S", "M", "L"})
+	assert.NoError(t, err)
+	color, err := NewOptionAxis("color", []string{"red", "blue"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, product.DefineOptions([]OptionAxis{size, color}))
+	return product
+}
+
+func TestNewOptionAxis_CleansValues(t *testing.T) {
+	axis, err := NewOptionAxis(" size ", []string{"S", " ", "M", "S"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "size", axis.Name)
+	assert.Equal(t, []string{"S", "M"}, axis.Values)
+}
+
+func TestNewOptionAxis_EmptyValues(t *testing.T) {
+	_, err := NewOptionAxis("size", []string{"", " "})
+
+	assert.Equal(t, ErrOptionValuesCannotBeEmpty, err)
+}
+
+func TestNewVariant_EmptySKU(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(19.99))
+	inventory, _ := NewInventory(5)
+
+	variant, err := NewVariant("  ", "", nil, price, inventory)
+
+	assert.Nil(t, variant)
+	assert.Equal(t, ErrVariantSKUCannotBeEmpty, err)
+}
+
+func TestProduct_AddVariant_Success(t *testing.T) {
+	product := newTestProductWithOptions(t)
+	price, _ := NewMoney(decimal.NewFromFloat(21.50))
+	inventory, _ := NewInventory(5)
+
+	variant, _ := NewVariant("TS-M-RED", "4600000000001", map[string]string{"size": "M", "color": "red"}, price, inventory)
+	err := product.AddVariant(variant)
+
+	assert.NoError(t, err)
+	assert.True(t, product.HasVariants())
+	assert.Equal(t, product.ID, variant.ProductID)
+
+	found, err := product.Variant(variant.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "TS-M-RED", found.SKU)
+}
+
+func TestProduct_AddVariant_InvalidOptions(t *testing.T) {
+	product := newTestProductWithOptions(t)
+	price, _ := NewMoney(decimal.NewFromFloat(21.50))
+	inventory, _ := NewInventory(5)
+
+	missingAxis, _ := NewVariant("TS-M", "", map[string]string{"size": "M"}, price, inventory)
+	assert.Equal(t, ErrInvalidVariantOptions, product.AddVariant(missingAxis))
+
+	unknownValue, _ := NewVariant("TS-XL-RED", "", map[string]string{"size": "XL", "color": "red"}, price, inventory)
+	assert.Equal(t, ErrInvalidVariantOptions, product.AddVariant(unknownValue))
+
+	assert.False(t, product.HasVariants())
+}
+
+func TestProduct_AddVariant_Duplicates(t *testing.T) {
+	product := newTestProductWithOptions(t)
+	price, _ := NewMoney(decimal.NewFromFloat(21.50))
+	inventory, _ := NewInventory(5)
+
+	first, _ := NewVariant("TS-M-RED", "", map[string]string{"size": "M", "color": "red"}, price, inventory)
+	assert.NoError(t, product.AddVariant(first))
+
+	sameSKU, _ := NewVariant("TS-M-RED", "", map[string]string{"size": "L", "color": "red"}, price, inventory)
+	assert.Equal(t, ErrDuplicateSKU, product.AddVariant(sameSKU))
+
+	sameOptions, _ := NewVariant("TS-M-RED-2", "", map[string]string{"size": "M", "color": "red"}, price, inventory)
+	assert.Equal(t, ErrDuplicateVariantOptions, product.AddVariant(sameOptions))
+
+	assert.Len(t, product.Variants, 1)
+}
+
+func TestProduct_DefineOptions_LockedByVariants(t *testing.T) {
+	product := newTestProductWithOptions(t)
+	price, _ := NewMoney(decimal.NewFromFloat(21.50))
+	inventory, _ := NewInventory(5)
+
+	variant, _ := NewVariant("TS-S-BLUE", "", map[string]string{"size": "S", "color": "blue"}, price, inventory)
+	assert.NoError(t, product.AddVariant(variant))
+
+	material, _ := NewOptionAxis("material", []string{"cotton"})
+	assert.Equal(t, ErrOptionsLockedByVariants, product.DefineOptions([]OptionAxis{material}))
+}
+
+func TestVariant_AdjustStock(t *testing.T) {
+	price, _ := NewMoney(decimal.NewFromFloat(21.50))
+	inventory, _ := NewInventory(5)
+	variant, _ := NewVariant("TS", "", nil, price, inventory)
+
+	assert.NoError(t, variant.AdjustStock(3))
+	assert.Equal(t, 8, variant.Inventory.Quantity())
+
+	assert.Equal(t, ErrInsufficientStock, variant.AdjustStock(-10))
+	assert.Equal(t, 8, variant.Inventory.Quantity())
+}
@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+)
+
+var _ pService.Notifier = (*LogNotifier)(nil)
+
+// LogNotifier writes notifications to the application log.
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification pService.Notification) error {
+	n.logger.WarnContext(ctx, notification.Subject,
+		slog.String("event", notification.Event),
+		slog.Any("items", notification.Items),
+	)
+	return nil
+}
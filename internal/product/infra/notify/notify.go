@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+)
+
+const (
+	DriverLog     = "log"
+	DriverWebhook = "webhook"
+	DriverFile    = "file"
+)
+
+type Config struct {
+	// values: log, webhook, file
+	Driver     string        `env:"DRIVER" envDefault:"log" validate:"oneof=log webhook file"`
+	WebhookURL string        `env:"WEBHOOK_URL"`
+	FilePath   string        `env:"FILE_PATH" envDefault:"./data/notifications.jsonl"`
+	Timeout    time.Duration `env:"TIMEOUT" envDefault:"5s"`
+}
+
+// New builds the notifier selected by cfg.Driver.
+func New(cfg Config, logger *slog.Logger) (pService.Notifier, error) {
+	switch cfg.Driver {
+	case DriverLog:
+		return NewLogNotifier(logger), nil
+	case DriverWebhook:
+		return NewWebhookNotifier(cfg.WebhookURL, cfg.Timeout)
+	case DriverFile:
+		return NewFileNotifier(cfg.FilePath)
+	default:
+		return nil, fmt.Errorf("unknown notifier driver %q", cfg.Driver)
+	}
+}
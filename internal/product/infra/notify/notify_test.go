@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+)
+
+func testNotification() pService.Notification {
+	return pService.Notification{
+		Event:   pService.NotificationLowStock,
+		Subject: "Low stock: Pen (1 left)",
+		Items: []pService.LowStockItem{
+			{ProductID: "p1", Description: "Pen", Quantity: 1, Threshold: 5},
+		},
+		SentAt: time.Now(),
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received pService.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(server.URL, time.Second)
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Notify(context.Background(), testNotification()))
+	assert.Equal(t, pService.NotificationLowStock, received.Event)
+	assert.Equal(t, "Pen", received.Items[0].Description)
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(server.URL, time.Second)
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, notifier.Notify(context.Background(), testNotification()), "502")
+}
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts", "notifications.jsonl")
+	notifier, err := NewFileNotifier(path)
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Notify(context.Background(), testNotification()))
+	require.NoError(t, notifier.Notify(context.Background(), testNotification()))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 2)
+
+	var decoded pService.Notification
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &decoded))
+	assert.Equal(t, 5, decoded.Items[0].Threshold)
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -10,13 +11,59 @@ import (
 )
 
 type ProductDB struct {
-	ID          string          `db:"id"`
-	Description string          `db:"description"`
-	Tags        string          `db:"tags"`
-	Price       decimal.Decimal `db:"price"`
-	Quantity    int             `db:"quantity"`
-	CreatedAt   time.Time       `db:"created_at"`
-	UpdatedAt   time.Time       `db:"updated_at"`
+	ID                string          `db:"id"`
+	Description       string          `db:"description"`
+	Tags              string          `db:"tags"`
+	Price             decimal.Decimal `db:"price"`
+	Quantity          int             `db:"quantity"`
+	Reserved          int             `db:"reserved"`
+	Options           []byte          `db:"options"`
+	ReorderThreshold  *int            `db:"reorder_threshold"`
+	BackorderLimit    *int            `db:"backorder_limit"`
+	RestockExpectedAt *time.Time      `db:"restock_expected_at"`
+	ReleaseDate       *time.Time      `db:"release_date"`
+	MaxPerOrder       *int            `db:"max_per_order"`
+	MaxPerUser        *int            `db:"max_per_user"`
+	// PurchaseWindowSeconds is the rolling window MaxPerUser applies to.
+	PurchaseWindowSeconds *int64      `db:"purchase_window_seconds"`
+	Version               int         `db:"version"`
+	CreatedAt             time.Time   `db:"created_at"`
+	UpdatedAt             time.Time   `db:"updated_at"`
+	ArchivedAt            *time.Time  `db:"archived_at"`
+	Variants              []VariantDB `db:"-"`
+	Images                []ImageDB   `db:"-"`
+}
+
+type OptionAxisDB struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type VariantDB struct {
+	ID        string          `db:"id"`
+	ProductID string          `db:"product_id"`
+	SKU       string          `db:"sku"`
+	Barcode   string          `db:"barcode"`
+	Options   []byte          `db:"options"`
+	Price     decimal.Decimal `db:"price"`
+	Quantity  int             `db:"quantity"`
+	Reserved  int             `db:"reserved"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+type ImageDB struct {
+	ID           string    `db:"id"`
+	ProductID    string    `db:"product_id"`
+	Key          string    `db:"storage_key"`
+	ThumbnailKey string    `db:"thumbnail_key"`
+	ContentType  string    `db:"content_type"`
+	Size         int64     `db:"size_bytes"`
+	Width        int       `db:"width"`
+	Height       int       `db:"height"`
+	Position     int       `db:"position"`
+	IsPrimary    bool      `db:"is_primary"`
+	CreatedAt    time.Time `db:"created_at"`
 }
 
 func (p *ProductDB) ToDomain() (*domain.Product, error) {
@@ -38,35 +85,232 @@ func (p *ProductDB) ToDomain() (*domain.Product, error) {
 		return nil, err
 	}
 
-	inventory, err := domain.NewInventory(p.Quantity)
+	inventory, err := domain.RestoreInventory(p.Quantity, p.Reserved)
 	if err != nil {
 		return nil, err
 	}
 
+	var optionsDB []OptionAxisDB
+	if len(p.Options) > 0 {
+		if err := json.Unmarshal(p.Options, &optionsDB); err != nil {
+			return nil, err
+		}
+	}
+
+	options := make([]domain.OptionAxis, 0, len(optionsDB))
+	for _, optionDB := range optionsDB {
+		options = append(options, domain.OptionAxis{
+			Name:   optionDB.Name,
+			Values: optionDB.Values,
+		})
+	}
+
+	variants := make([]*domain.Variant, 0, len(p.Variants))
+	for _, variantDB := range p.Variants {
+		variant, err := variantDB.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+
+	images := make([]*domain.Image, 0, len(p.Images))
+	for _, imageDB := range p.Images {
+		image, err := imageDB.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	limits := domain.PurchaseLimits{MaxPerOrder: p.MaxPerOrder, MaxPerUser: p.MaxPerUser}
+	if p.PurchaseWindowSeconds != nil {
+		limits.Window = time.Duration(*p.PurchaseWindowSeconds) * time.Second
+	}
+
 	return &domain.Product{
-		ID:          domain.ProductID(id),
-		Description: p.Description,
-		Tags:        tags,
-		Price:       price,
-		Inventory:   inventory,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:                domain.ProductID(id),
+		Description:       p.Description,
+		Tags:              tags,
+		Price:             price,
+		Inventory:         inventory,
+		Options:           options,
+		Variants:          variants,
+		Images:            images,
+		ReorderThreshold:  p.ReorderThreshold,
+		BackorderLimit:    p.BackorderLimit,
+		RestockExpectedAt: p.RestockExpectedAt,
+		ReleaseDate:       p.ReleaseDate,
+		PurchaseLimits:    limits,
+		Version:           p.Version,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+		ArchivedAt:        p.ArchivedAt,
 	}, nil
 }
 
-func FromDomain(product *domain.Product) *ProductDB {
+func (v *VariantDB) ToDomain() (*domain.Variant, error) {
+	id, err := uuid.Parse(v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := uuid.Parse(v.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make(map[string]string)
+	if len(v.Options) > 0 {
+		if err := json.Unmarshal(v.Options, &options); err != nil {
+			return nil, err
+		}
+	}
+
+	price, err := domain.NewMoney(v.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := domain.RestoreInventory(v.Quantity, v.Reserved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Variant{
+		ID:        domain.VariantID(id),
+		ProductID: domain.ProductID(productID),
+		SKU:       v.SKU,
+		Barcode:   v.Barcode,
+		Options:   options,
+		Price:     price,
+		Inventory: inventory,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}, nil
+}
+
+func (i *ImageDB) ToDomain() (*domain.Image, error) {
+	id, err := uuid.Parse(i.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := uuid.Parse(i.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Image{
+		ID:           domain.ImageID(id),
+		ProductID:    domain.ProductID(productID),
+		Key:          i.Key,
+		ThumbnailKey: i.ThumbnailKey,
+		ContentType:  i.ContentType,
+		Size:         i.Size,
+		Width:        i.Width,
+		Height:       i.Height,
+		Position:     i.Position,
+		IsPrimary:    i.IsPrimary,
+		CreatedAt:    i.CreatedAt,
+	}, nil
+}
+
+func FromDomain(product *domain.Product) (*ProductDB, error) {
 	var tagsStr string
 	if len(product.Tags) > 0 {
 		tagsStr = strings.Join(product.Tags, ",")
 	}
 
+	optionsDB := make([]OptionAxisDB, 0, len(product.Options))
+	for _, option := range product.Options {
+		optionsDB = append(optionsDB, OptionAxisDB{
+			Name:   option.Name,
+			Values: option.Values,
+		})
+	}
+
+	options, err := json.Marshal(optionsDB)
+	if err != nil {
+		return nil, err
+	}
+
+	variantsDB := make([]VariantDB, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		variantDB, err := VariantFromDomain(variant)
+		if err != nil {
+			return nil, err
+		}
+		variantsDB = append(variantsDB, *variantDB)
+	}
+
+	imagesDB := make([]ImageDB, 0, len(product.Images))
+	for _, image := range product.Images {
+		imagesDB = append(imagesDB, ImageFromDomain(image))
+	}
+
+	var purchaseWindow *int64
+	if product.PurchaseLimits.Window > 0 {
+		seconds := int64(product.PurchaseLimits.Window / time.Second)
+		purchaseWindow = &seconds
+	}
+
 	return &ProductDB{
-		ID:          product.ID.String(),
-		Description: product.Description,
-		Tags:        tagsStr,
-		Price:       product.Price.Amount(),
-		Quantity:    product.Inventory.Quantity(),
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
-	}
-}
\ No newline at end of file
+		ID:                    product.ID.String(),
+		Description:           product.Description,
+		Tags:                  tagsStr,
+		Price:                 product.Price.Amount(),
+		Quantity:              product.Inventory.Quantity(),
+		Reserved:              product.Inventory.Reserved(),
+		Options:               options,
+		ReorderThreshold:      product.ReorderThreshold,
+		BackorderLimit:        product.BackorderLimit,
+		RestockExpectedAt:     product.RestockExpectedAt,
+		ReleaseDate:           product.ReleaseDate,
+		MaxPerOrder:           product.PurchaseLimits.MaxPerOrder,
+		MaxPerUser:            product.PurchaseLimits.MaxPerUser,
+		PurchaseWindowSeconds: purchaseWindow,
+		Version:               product.Version,
+		CreatedAt:             product.CreatedAt,
+		UpdatedAt:             product.UpdatedAt,
+		ArchivedAt:            product.ArchivedAt,
+		Variants:              variantsDB,
+		Images:                imagesDB,
+	}, nil
+}
+
+func VariantFromDomain(variant *domain.Variant) (*VariantDB, error) {
+	options, err := json.Marshal(variant.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VariantDB{
+		ID:        variant.ID.String(),
+		ProductID: variant.ProductID.String(),
+		SKU:       variant.SKU,
+		Barcode:   variant.Barcode,
+		Options:   options,
+		Price:     variant.Price.Amount(),
+		Quantity:  variant.Inventory.Quantity(),
+		Reserved:  variant.Inventory.Reserved(),
+		CreatedAt: variant.CreatedAt,
+		UpdatedAt: variant.UpdatedAt,
+	}, nil
+}
+
+func ImageFromDomain(image *domain.Image) ImageDB {
+	return ImageDB{
+		ID:           image.ID.String(),
+		ProductID:    image.ProductID.String(),
+		Key:          image.Key,
+		ThumbnailKey: image.ThumbnailKey,
+		ContentType:  image.ContentType,
+		Size:         image.Size,
+		Width:        image.Width,
+		Height:       image.Height,
+		Position:     image.Position,
+		IsPrimary:    image.IsPrimary,
+		CreatedAt:    image.CreatedAt,
+	}
+}
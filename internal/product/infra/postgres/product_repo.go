@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
 	"github.com/BlackRRR/Irtea-test/internal/product/domain"
 	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
@@ -14,6 +17,18 @@ import (
 
 var _ pService.ProductRepo = (*ProductRepo)(nil)
 
+const (
+	uniqueVariantSKUConstraint = "unique_variant_sku"
+	foreignKeyViolationCode    = "23503"
+	checkViolationCode         = "23514"
+	productReservedConstraint  = "check_product_reserved"
+	variantReservedConstraint  = "check_variant_reserved"
+)
+
+// productColumns are the columns read by scanProduct, in its order.
+const productColumns = `id, description, tags, price, quantity, reserved, options, reorder_threshold, version, created_at, updated_at,
+	archived_at, backorder_limit, restock_expected_at, release_date, max_per_order, max_per_user, purchase_window_seconds`
+
 type ProductRepo struct {
 	pool *pgxpool.Pool
 }
@@ -24,33 +39,56 @@ func NewProductRepo(pool *pgxpool.Pool) *ProductRepo {
 
 func (r *ProductRepo) Create(ctx context.Context, product *domain.Product) error {
 	query := `
-		INSERT INTO products.product (id, description, tags, price, quantity, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO products.product (id, description, tags, price, quantity, options, reorder_threshold, version, created_at, updated_at, archived_at,
+		                              backorder_limit, restock_expected_at, release_date, max_per_order, max_per_user, purchase_window_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
 
-	productDB := FromDomain(product)
+	productDB, err := FromDomain(product)
+	if err != nil {
+		return fmt.Errorf("failed to convert product to DB format: %w", err)
+	}
+
 	querier := postgres.GetQuerier(ctx, r.pool)
 
-	_, err := querier.Exec(ctx, query,
+	_, err = querier.Exec(ctx, query,
 		productDB.ID,
 		productDB.Description,
 		productDB.Tags,
 		productDB.Price,
 		productDB.Quantity,
+		productDB.Options,
+		productDB.ReorderThreshold,
+		productDB.Version,
 		productDB.CreatedAt,
 		productDB.UpdatedAt,
+		productDB.ArchivedAt,
+		productDB.BackorderLimit,
+		productDB.RestockExpectedAt,
+		productDB.ReleaseDate,
+		productDB.MaxPerOrder,
+		productDB.MaxPerUser,
+		productDB.PurchaseWindowSeconds,
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to create product: %w", err)
 	}
 
+	if err = r.upsertVariants(ctx, productDB.Variants, querier); err != nil {
+		return err
+	}
+
+	if err = r.syncImages(ctx, productDB.ID, productDB.Images, querier); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (r *ProductRepo) GetByID(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
 	query := `
-		SELECT id, description, tags, price, quantity, created_at, updated_at
+		SELECT ` + productColumns + `
 		FROM products.product
 		WHERE id = $1
 	`
@@ -59,80 +97,96 @@ func (r *ProductRepo) GetByID(ctx context.Context, id domain.ProductID) (*domain
 	row := querier.QueryRow(ctx, query, id.String())
 
 	var productDB ProductDB
-	err := row.Scan(
-		&productDB.ID,
-		&productDB.Description,
-		&productDB.Tags,
-		&productDB.Price,
-		&productDB.Quantity,
-		&productDB.CreatedAt,
-		&productDB.UpdatedAt,
-	)
-
-	if err != nil {
+	if err := scanProduct(row, &productDB); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrProductNotFound
 		}
 		return nil, fmt.Errorf("failed to get product by ID: %w", err)
 	}
 
+	variants, err := r.getVariants(ctx, []string{productDB.ID}, querier)
+	if err != nil {
+		return nil, err
+	}
+	productDB.Variants = variants[productDB.ID]
+
+	images, err := r.getImages(ctx, []string{productDB.ID}, querier)
+	if err != nil {
+		return nil, err
+	}
+	productDB.Images = images[productDB.ID]
+
 	return productDB.ToDomain()
 }
 
 func (r *ProductRepo) GetAll(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
 	query := `
-		SELECT id, description, tags, price, quantity, created_at, updated_at
+		SELECT ` + productColumns + `
 		FROM products.product
-		ORDER BY created_at DESC
+		WHERE archived_at IS NULL
+		ORDER BY created_at DESC, id DESC
 		LIMIT $1 OFFSET $2
 	`
 
-	querier := postgres.GetQuerier(ctx, r.pool)
-	rows, err := querier.Query(ctx, query, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get all products: %w", err)
-	}
-	defer rows.Close()
+	return r.queryProducts(ctx, query, limit, offset)
+}
 
-	var products []*domain.Product
-	for rows.Next() {
-		var productDB ProductDB
-		err := rows.Scan(
-			&productDB.ID,
-			&productDB.Description,
-			&productDB.Tags,
-			&productDB.Price,
-			&productDB.Quantity,
-			&productDB.CreatedAt,
-			&productDB.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan product row: %w", err)
-		}
+// GetAllAfter continues GetAll's order after the cursor, so products created or
+// archived in the meantime cannot shift the next page. A nil cursor starts at
+// the newest product.
+func (r *ProductRepo) GetAllAfter(ctx context.Context, after *pService.ProductCursor, limit int) ([]*domain.Product, error) {
+	if after == nil {
+		query := `
+			SELECT ` + productColumns + `
+			FROM products.product
+			WHERE archived_at IS NULL
+			ORDER BY created_at DESC, id DESC
+			LIMIT $1
+		`
+
+		return r.queryProducts(ctx, query, limit)
+	}
 
-		product, err := productDB.ToDomain()
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert product to domain: %w", err)
-		}
+	query := `
+		SELECT ` + productColumns + `
+		FROM products.product
+		WHERE archived_at IS NULL AND (created_at, id) < ($1, $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
 
-		products = append(products, product)
-	}
+	return r.queryProducts(ctx, query, after.CreatedAt, after.ID.String(), limit)
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("row iteration error: %w", err)
-	}
+// GetLowStock lists active products at or below their reorder threshold,
+// emptiest first.
+func (r *ProductRepo) GetLowStock(ctx context.Context, limit, offset int) ([]*domain.Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products.product
+		WHERE archived_at IS NULL AND reorder_threshold IS NOT NULL AND quantity - reserved <= reorder_threshold
+		ORDER BY quantity - reserved, created_at
+		LIMIT $1 OFFSET $2
+	`
 
-	return products, nil
+	return r.queryProducts(ctx, query, limit, offset)
 }
 
 func (r *ProductRepo) Update(ctx context.Context, product *domain.Product) error {
 	query := `
 		UPDATE products.product
-		SET description = $2, tags = $3, price = $4, quantity = $5, updated_at = $6
-		WHERE id = $1
+		SET description = $2, tags = $3, price = $4, quantity = $5, options = $6, updated_at = $7, archived_at = $8,
+		    reorder_threshold = $10, backorder_limit = $11, restock_expected_at = $12, release_date = $13,
+		    max_per_order = $14, max_per_user = $15, purchase_window_seconds = $16,
+		    version = version + 1
+		WHERE id = $1 AND version = $9
 	`
 
-	productDB := FromDomain(product)
+	productDB, err := FromDomain(product)
+	if err != nil {
+		return fmt.Errorf("failed to convert product to DB format: %w", err)
+	}
+
 	querier := postgres.GetQuerier(ctx, r.pool)
 
 	result, err := querier.Exec(ctx, query,
@@ -141,15 +195,38 @@ func (r *ProductRepo) Update(ctx context.Context, product *domain.Product) error
 		productDB.Tags,
 		productDB.Price,
 		productDB.Quantity,
+		productDB.Options,
 		productDB.UpdatedAt,
+		productDB.ArchivedAt,
+		productDB.Version,
+		productDB.ReorderThreshold,
+		productDB.BackorderLimit,
+		productDB.RestockExpectedAt,
+		productDB.ReleaseDate,
+		productDB.MaxPerOrder,
+		productDB.MaxPerUser,
+		productDB.PurchaseWindowSeconds,
 	)
 
 	if err != nil {
+		if isReservedCheckViolation(err) {
+			return domain.ErrInsufficientStock
+		}
 		return fmt.Errorf("failed to update product: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return domain.ErrProductNotFound
+		return postgres.MissingOrConflict(ctx, querier, "products.product", productDB.ID, domain.ErrProductNotFound, domain.ErrProductVersionConflict)
+	}
+
+	product.Version++
+
+	if err = r.upsertVariants(ctx, productDB.Variants, querier); err != nil {
+		return err
+	}
+
+	if err = r.syncImages(ctx, productDB.ID, productDB.Images, querier); err != nil {
+		return err
 	}
 
 	return nil
@@ -162,6 +239,10 @@ func (r *ProductRepo) Delete(ctx context.Context, id domain.ProductID) error {
 	result, err := querier.Exec(ctx, query, id.String())
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return domain.ErrProductHasOrders
+		}
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
 
@@ -172,23 +253,309 @@ func (r *ProductRepo) Delete(ctx context.Context, id domain.ProductID) error {
 	return nil
 }
 
-func (r *ProductRepo) ReserveStock(ctx context.Context, id domain.ProductID, quantity int) error {
+// queryProducts runs a product SELECT and loads variants and images for the
+// returned rows.
+func (r *ProductRepo) queryProducts(ctx context.Context, query string, args ...any) ([]*domain.Product, error) {
+	querier := postgres.GetQuerier(ctx, r.pool)
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query products: %w", err)
+	}
+	defer rows.Close()
+
+	var productsDB []ProductDB
+	var productIDs []string
+	for rows.Next() {
+		var productDB ProductDB
+		if err := scanProduct(rows, &productDB); err != nil {
+			return nil, fmt.Errorf("failed to scan product row: %w", err)
+		}
+
+		productsDB = append(productsDB, productDB)
+		productIDs = append(productIDs, productDB.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	variants, err := r.getVariants(ctx, productIDs, querier)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := r.getImages(ctx, productIDs, querier)
+	if err != nil {
+		return nil, err
+	}
+
+	var products []*domain.Product
+	for _, productDB := range productsDB {
+		productDB.Variants = variants[productDB.ID]
+		productDB.Images = images[productDB.ID]
+
+		product, err := productDB.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert product to domain: %w", err)
+		}
+
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+func scanProduct(row pgx.Row, productDB *ProductDB) error {
+	return row.Scan(
+		&productDB.ID,
+		&productDB.Description,
+		&productDB.Tags,
+		&productDB.Price,
+		&productDB.Quantity,
+		&productDB.Reserved,
+		&productDB.Options,
+		&productDB.ReorderThreshold,
+		&productDB.Version,
+		&productDB.CreatedAt,
+		&productDB.UpdatedAt,
+		&productDB.ArchivedAt,
+		&productDB.BackorderLimit,
+		&productDB.RestockExpectedAt,
+		&productDB.ReleaseDate,
+		&productDB.MaxPerOrder,
+		&productDB.MaxPerUser,
+		&productDB.PurchaseWindowSeconds,
+	)
+}
+
+func (r *ProductRepo) getVariants(ctx context.Context, productIDs []string, q postgres.Querier) (map[string][]VariantDB, error) {
+	variants := make(map[string][]VariantDB)
+	if len(productIDs) == 0 {
+		return variants, nil
+	}
+
 	query := `
-		UPDATE products.product
-		SET quantity = quantity - $2, updated_at = NOW()
-		WHERE id = $1 AND quantity >= $2
+		SELECT id, product_id, sku, barcode, options, price, quantity, reserved, created_at, updated_at
+		FROM products.variant
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, created_at
 	`
 
-	querier := postgres.GetQuerier(ctx, r.pool)
-	result, err := querier.Exec(ctx, query, id.String(), quantity)
+	rows, err := q.Query(ctx, query, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var variantDB VariantDB
+		err := rows.Scan(
+			&variantDB.ID,
+			&variantDB.ProductID,
+			&variantDB.SKU,
+			&variantDB.Barcode,
+			&variantDB.Options,
+			&variantDB.Price,
+			&variantDB.Quantity,
+			&variantDB.Reserved,
+			&variantDB.CreatedAt,
+			&variantDB.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan variant row: %w", err)
+		}
+
+		variants[variantDB.ProductID] = append(variants[variantDB.ProductID], variantDB)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("variant rows iteration error: %w", err)
+	}
 
+	return variants, nil
+}
+
+func (r *ProductRepo) upsertVariants(ctx context.Context, variants []VariantDB, q postgres.Querier) error {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(variants))
+	productIDs := make([]string, 0, len(variants))
+	skus := make([]string, 0, len(variants))
+	barcodes := make([]string, 0, len(variants))
+	options := make([]string, 0, len(variants))
+	prices := make([]decimal.Decimal, 0, len(variants))
+	quantities := make([]int, 0, len(variants))
+	createdAts := make([]time.Time, 0, len(variants))
+	updatedAts := make([]time.Time, 0, len(variants))
+
+	for _, variant := range variants {
+		ids = append(ids, variant.ID)
+		productIDs = append(productIDs, variant.ProductID)
+		skus = append(skus, variant.SKU)
+		barcodes = append(barcodes, variant.Barcode)
+		options = append(options, string(variant.Options))
+		prices = append(prices, variant.Price)
+		quantities = append(quantities, variant.Quantity)
+		createdAts = append(createdAts, variant.CreatedAt)
+		updatedAts = append(updatedAts, variant.UpdatedAt)
+	}
+
+	query := `
+	INSERT INTO products.variant (id, product_id, sku, barcode, options, price, quantity, created_at, updated_at)
+	SELECT
+		UNNEST($1::uuid[]),
+		UNNEST($2::uuid[]),
+		UNNEST($3::varchar[]),
+		UNNEST($4::varchar[]),
+		UNNEST($5::jsonb[]),
+		UNNEST($6::numeric[]),
+		UNNEST($7::int[]),
+		UNNEST($8::timestamptz[]),
+		UNNEST($9::timestamptz[])
+	ON CONFLICT (id) DO UPDATE
+	SET sku = EXCLUDED.sku,
+		barcode = EXCLUDED.barcode,
+		options = EXCLUDED.options,
+		price = EXCLUDED.price,
+		quantity = EXCLUDED.quantity,
+		updated_at = EXCLUDED.updated_at
+`
+
+	if _, err := q.Exec(ctx, query,
+		ids, productIDs, skus, barcodes, options, prices, quantities, createdAts, updatedAts,
+	); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == uniqueVariantSKUConstraint {
+			return domain.ErrDuplicateSKU
+		}
+		if isReservedCheckViolation(err) {
+			return domain.ErrInsufficientStock
+		}
+		return fmt.Errorf("failed to upsert product variants: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProductRepo) getImages(ctx context.Context, productIDs []string, q postgres.Querier) (map[string][]ImageDB, error) {
+	images := make(map[string][]ImageDB)
+	if len(productIDs) == 0 {
+		return images, nil
+	}
+
+	query := `
+		SELECT id, product_id, storage_key, thumbnail_key, content_type, size_bytes, width, height, position, is_primary, created_at
+		FROM products.image
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, position
+	`
+
+	rows, err := q.Query(ctx, query, productIDs)
 	if err != nil {
-		return fmt.Errorf("failed to reserve stock: %w", err)
+		return nil, fmt.Errorf("failed to get product images: %w", err)
 	}
+	defer rows.Close()
 
-	if result.RowsAffected() == 0 {
-		return domain.ErrInsufficientStock
+	for rows.Next() {
+		var imageDB ImageDB
+		err := rows.Scan(
+			&imageDB.ID,
+			&imageDB.ProductID,
+			&imageDB.Key,
+			&imageDB.ThumbnailKey,
+			&imageDB.ContentType,
+			&imageDB.Size,
+			&imageDB.Width,
+			&imageDB.Height,
+			&imageDB.Position,
+			&imageDB.IsPrimary,
+			&imageDB.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan image row: %w", err)
+		}
+
+		images[imageDB.ProductID] = append(images[imageDB.ProductID], imageDB)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("image rows iteration error: %w", err)
+	}
+
+	return images, nil
+}
+
+// syncImages makes the stored images of a product match the given set:
+// removed images are deleted, the rest are upserted with their new order.
+func (r *ProductRepo) syncImages(ctx context.Context, productID string, images []ImageDB, q postgres.Querier) error {
+	ids := make([]string, 0, len(images))
+	keys := make([]string, 0, len(images))
+	thumbnailKeys := make([]string, 0, len(images))
+	contentTypes := make([]string, 0, len(images))
+	sizes := make([]int64, 0, len(images))
+	widths := make([]int, 0, len(images))
+	heights := make([]int, 0, len(images))
+	positions := make([]int, 0, len(images))
+	primaries := make([]bool, 0, len(images))
+	createdAts := make([]time.Time, 0, len(images))
+
+	for _, image := range images {
+		ids = append(ids, image.ID)
+		keys = append(keys, image.Key)
+		thumbnailKeys = append(thumbnailKeys, image.ThumbnailKey)
+		contentTypes = append(contentTypes, image.ContentType)
+		sizes = append(sizes, image.Size)
+		widths = append(widths, image.Width)
+		heights = append(heights, image.Height)
+		positions = append(positions, image.Position)
+		primaries = append(primaries, image.IsPrimary)
+		createdAts = append(createdAts, image.CreatedAt)
+	}
+
+	deleteQuery := `DELETE FROM products.image WHERE product_id = $1 AND NOT (id = ANY($2::uuid[]))`
+
+	if _, err := q.Exec(ctx, deleteQuery, productID, ids); err != nil {
+		return fmt.Errorf("failed to delete removed product images: %w", err)
+	}
+
+	if len(images) == 0 {
+		return nil
+	}
+
+	query := `
+	INSERT INTO products.image (id, product_id, storage_key, thumbnail_key, content_type, size_bytes, width, height, position, is_primary, created_at)
+	SELECT
+		UNNEST($1::uuid[]),
+		$2::uuid,
+		UNNEST($3::varchar[]),
+		UNNEST($4::varchar[]),
+		UNNEST($5::varchar[]),
+		UNNEST($6::bigint[]),
+		UNNEST($7::int[]),
+		UNNEST($8::int[]),
+		UNNEST($9::int[]),
+		UNNEST($10::boolean[]),
+		UNNEST($11::timestamptz[])
+	ON CONFLICT (id) DO UPDATE
+	SET position = EXCLUDED.position,
+		is_primary = EXCLUDED.is_primary
+`
+
+	if _, err := q.Exec(ctx, query,
+		ids, productID, keys, thumbnailKeys, contentTypes, sizes, widths, heights, positions, primaries, createdAts,
+	); err != nil {
+		return fmt.Errorf("failed to upsert product images: %w", err)
 	}
 
 	return nil
 }
+
+// isReservedCheckViolation reports whether a write tried to drop stock on
+// hand below the units held by reservations taken since it was read.
+func isReservedCheckViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == checkViolationCode &&
+		(pgErr.ConstraintName == productReservedConstraint || pgErr.ConstraintName == variantReservedConstraint)
+}
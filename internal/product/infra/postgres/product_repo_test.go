@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+)
+
+// fakeTx answers SELECTs from canned rows by table. Its rows are scanned by
+// column name, like pgx does by position, so a scan list that does not match
+// the selected columns fails.
+type fakeTx struct {
+	pgx.Tx
+	tables map[string][]map[string]any
+}
+
+func (tx *fakeTx) Query(_ context.Context, sql string, _ ...any) (pgx.Rows, error) {
+	selectList, rest, ok := strings.Cut(strings.TrimSpace(sql)[len("SELECT"):], "FROM")
+	if !ok {
+		return nil, fmt.Errorf("unsupported query: %s", sql)
+	}
+
+	var columns []string
+	for _, column := range strings.Split(selectList, ",") {
+		columns = append(columns, strings.TrimSpace(column))
+	}
+	table := strings.Fields(rest)[0]
+
+	return &fakeRows{columns: columns, rows: tx.tables[table], next: -1}, nil
+}
+
+func (tx *fakeTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return errRow{err}
+	}
+	return firstRow{rows}
+}
+
+type fakeRows struct {
+	pgx.Rows
+	columns []string
+	rows    []map[string]any
+	next    int
+}
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next < len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if len(dest) != len(r.columns) {
+		return fmt.Errorf("number of field descriptions must equal number of destinations, got %d and %d", len(r.columns), len(dest))
+	}
+
+	row := r.rows[r.next]
+	for i, column := range r.columns {
+		value, ok := row[column]
+		if !ok {
+			return fmt.Errorf("no value for column %s", column)
+		}
+		if value == nil {
+			continue
+		}
+
+		target := reflect.ValueOf(dest[i]).Elem()
+		v := reflect.ValueOf(value)
+		if target.Kind() == reflect.Pointer && v.Kind() != reflect.Pointer {
+			ptr := reflect.New(v.Type())
+			ptr.Elem().Set(v)
+			v = ptr
+		}
+		if !v.Type().AssignableTo(target.Type()) {
+			return fmt.Errorf("cannot scan column %s of type %T into %s", column, value, target.Type())
+		}
+		target.Set(v)
+	}
+
+	return nil
+}
+
+func (r *fakeRows) Err() error { return nil }
+func (r *fakeRows) Close()     {}
+
+type firstRow struct{ rows pgx.Rows }
+
+func (r firstRow) Scan(dest ...any) error {
+	if !r.rows.Next() {
+		return pgx.ErrNoRows
+	}
+	return r.rows.Scan(dest...)
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
+
+func TestProductRepo_List(t *testing.T) {
+	id := "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+	created := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	tx := &fakeTx{tables: map[string][]map[string]any{
+		"products.product": {{
+			"id":                      id,
+			"description":             "Sneaker",
+			"tags":                    "shoes, sale",
+			"price":                   decimal.RequireFromString("59.90"),
+			"quantity":                10,
+			"reserved":                3,
+			"options":                 []byte(`[{"name":"size","values":["41","42"]}]`),
+			"reorder_threshold":       5,
+			"version":                 4,
+			"created_at":              created,
+			"updated_at":              created.Add(time.Hour),
+			"archived_at":             nil,
+			"backorder_limit":         nil,
+			"restock_expected_at":     nil,
+			"release_date":            nil,
+			"max_per_order":           2,
+			"max_per_user":            6,
+			"purchase_window_seconds": int64(86400),
+		}},
+	}}
+	ctx := postgres.ContextWithTx(context.Background(), tx)
+	repo := NewProductRepo(nil)
+
+	products, err := repo.GetAll(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+
+	product := products[0]
+	inventory, err := domain.RestoreInventory(10, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "Sneaker", product.Description)
+	assert.Equal(t, []string{"shoes", "sale"}, product.Tags)
+	assert.Equal(t, inventory, product.Inventory)
+	assert.Equal(t, []domain.OptionAxis{{Name: "size", Values: []string{"41", "42"}}}, product.Options)
+	assert.Equal(t, 4, product.Version)
+	assert.Equal(t, 24*time.Hour, product.PurchaseLimits.Window)
+
+	lowStock, err := repo.GetLowStock(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, products, lowStock)
+
+	page, err := repo.GetAllAfter(ctx, &pService.ProductCursor{CreatedAt: created, ID: product.ID}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, products, page)
+
+	// Listing and loading one product read the same columns.
+	byID, err := repo.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, product, byID)
+}
@@ -0,0 +1,419 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+)
+
+var _ pService.ReservationRepo = (*ReservationRepo)(nil)
+
+// ReservationRepo stores reservation rows and keeps the reserved and on-hand
+// counters of products and variants in step with them.
+type ReservationRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewReservationRepo(pool *pgxpool.Pool) *ReservationRepo {
+	return &ReservationRepo{pool: pool}
+}
+
+type ReservationDB struct {
+	ID        string     `db:"id"`
+	OrderID   string     `db:"order_id"`
+	ProductID string     `db:"product_id"`
+	VariantID *string    `db:"variant_id"`
+	Quantity  int        `db:"quantity"`
+	Kind      string     `db:"kind"`
+	Status    string     `db:"status"`
+	Confirmed bool       `db:"confirmed"`
+	ExpiresAt *time.Time `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+func (r *ReservationDB) ToDomain() (*domain.Reservation, error) {
+	id, err := uuid.Parse(r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderID, err := uuid.Parse(r.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := uuid.Parse(r.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	var variantID *domain.VariantID
+	if r.VariantID != nil {
+		parsed, err := uuid.Parse(*r.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		id := domain.VariantID(parsed)
+		variantID = &id
+	}
+
+	return &domain.Reservation{
+		ID:        domain.ReservationID(id),
+		OrderID:   orderID,
+		ProductID: domain.ProductID(productID),
+		VariantID: variantID,
+		Quantity:  r.Quantity,
+		Kind:      domain.ReservationKind(r.Kind),
+		Status:    domain.ReservationStatus(r.Status),
+		Confirmed: r.Confirmed,
+		ExpiresAt: r.ExpiresAt,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}, nil
+}
+
+// Create holds the stock and stores the reservation. The counter update only
+// succeeds while enough stock is available, so concurrent orders cannot
+// oversell.
+func (r *ReservationRepo) Create(ctx context.Context, reservation *domain.Reservation) error {
+	querier := postgres.GetQuerier(ctx, r.pool)
+
+	table, id := stockRow(reservation)
+	result, err := querier.Exec(ctx, `
+		UPDATE `+table+`
+		SET reserved = reserved + $2, updated_at = NOW()
+		WHERE id = $1 AND quantity - reserved >= $2
+	`, id, reservation.Quantity)
+	if err != nil {
+		return fmt.Errorf("failed to reserve stock: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrInsufficientStock
+	}
+
+	return r.insert(ctx, reservation, querier)
+}
+
+// CreateWaiting stores a backorder or pre-order. The product row is locked
+// so concurrent backorders cannot exceed the limit together; a nil limit
+// means unlimited.
+func (r *ReservationRepo) CreateWaiting(ctx context.Context, reservation *domain.Reservation, limit *int) error {
+	querier := postgres.GetQuerier(ctx, r.pool)
+
+	_, err := querier.Exec(ctx, `SELECT 1 FROM products.product WHERE id = $1 FOR UPDATE`, reservation.ProductID.String())
+	if err != nil {
+		return fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	if limit != nil {
+		var waiting int
+		err := querier.QueryRow(ctx, `
+			SELECT COALESCE(SUM(quantity), 0)
+			FROM products.stock_reservation
+			WHERE product_id = $1 AND status = 'waiting' AND kind = 'backorder'
+		`, reservation.ProductID.String()).Scan(&waiting)
+		if err != nil {
+			return fmt.Errorf("failed to count backorders: %w", err)
+		}
+
+		if waiting+reservation.Quantity > *limit {
+			return domain.ErrBackorderLimitExceeded
+		}
+	}
+
+	return r.insert(ctx, reservation, querier)
+}
+
+func (r *ReservationRepo) insert(ctx context.Context, reservation *domain.Reservation, querier postgres.Querier) error {
+	var variantID *string
+	if reservation.VariantID != nil {
+		id := reservation.VariantID.String()
+		variantID = &id
+	}
+
+	query := `
+		INSERT INTO products.stock_reservation (id, order_id, product_id, variant_id, quantity, kind, status, confirmed, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := querier.Exec(ctx, query,
+		reservation.ID.String(),
+		reservation.OrderID.String(),
+		reservation.ProductID.String(),
+		variantID,
+		reservation.Quantity,
+		string(reservation.Kind),
+		string(reservation.Status),
+		reservation.Confirmed,
+		reservation.ExpiresAt,
+		reservation.CreatedAt,
+		reservation.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create stock reservation: %w", err)
+	}
+
+	return nil
+}
+
+// GetByOrder returns the reservations of an order locked for update.
+func (r *ReservationRepo) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.Reservation, error) {
+	query := `
+		SELECT id, order_id, product_id, variant_id, quantity, kind, status, confirmed, expires_at, created_at, updated_at
+		FROM products.stock_reservation
+		WHERE order_id = $1
+		ORDER BY created_at, id
+		FOR UPDATE
+	`
+
+	return r.queryReservations(ctx, query, orderID.String())
+}
+
+// GetWaiting returns the waiting reservations of a product in the order they
+// were placed, locked for update.
+func (r *ReservationRepo) GetWaiting(ctx context.Context, productID domain.ProductID) ([]*domain.Reservation, error) {
+	query := `
+		SELECT id, order_id, product_id, variant_id, quantity, kind, status, confirmed, expires_at, created_at, updated_at
+		FROM products.stock_reservation
+		WHERE product_id = $1 AND status = 'waiting'
+		ORDER BY created_at, id
+		FOR UPDATE
+	`
+
+	return r.queryReservations(ctx, query, productID.String())
+}
+
+// GetAllocatableProducts lists released products with a waiting reservation
+// that the stock available now could cover.
+func (r *ReservationRepo) GetAllocatableProducts(ctx context.Context, now time.Time, limit int) ([]domain.ProductID, error) {
+	query := `
+		SELECT r.product_id
+		FROM products.stock_reservation r
+		JOIN products.product p ON p.id = r.product_id
+		LEFT JOIN products.variant v ON v.id = r.variant_id
+		WHERE r.status = 'waiting'
+		  AND (p.release_date IS NULL OR p.release_date <= $1)
+		  AND CASE
+		          WHEN r.variant_id IS NULL THEN p.quantity - p.reserved
+		          ELSE v.quantity - v.reserved
+		      END >= r.quantity
+		GROUP BY r.product_id
+		ORDER BY MIN(r.created_at)
+		LIMIT $2
+	`
+
+	querier := postgres.GetQuerier(ctx, r.pool)
+	rows, err := querier.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocatable products: %w", err)
+	}
+	defer rows.Close()
+
+	var productIDs []domain.ProductID
+	for rows.Next() {
+		var productID string
+		if err := rows.Scan(&productID); err != nil {
+			return nil, fmt.Errorf("failed to scan allocatable product id: %w", err)
+		}
+
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			return nil, err
+		}
+
+		productIDs = append(productIDs, domain.ProductID(id))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("allocatable product rows iteration error: %w", err)
+	}
+
+	return productIDs, nil
+}
+
+func (r *ReservationRepo) queryReservations(ctx context.Context, query string, args ...any) ([]*domain.Reservation, error) {
+	querier := postgres.GetQuerier(ctx, r.pool)
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []*domain.Reservation
+	for rows.Next() {
+		var reservationDB ReservationDB
+		err := rows.Scan(
+			&reservationDB.ID,
+			&reservationDB.OrderID,
+			&reservationDB.ProductID,
+			&reservationDB.VariantID,
+			&reservationDB.Quantity,
+			&reservationDB.Kind,
+			&reservationDB.Status,
+			&reservationDB.Confirmed,
+			&reservationDB.ExpiresAt,
+			&reservationDB.CreatedAt,
+			&reservationDB.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock reservation row: %w", err)
+		}
+
+		reservation, err := reservationDB.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert stock reservation to domain: %w", err)
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("stock reservation rows iteration error: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// GetExpiredOrders lists orders holding active reservations that expired at
+// or before now, oldest first.
+func (r *ReservationRepo) GetExpiredOrders(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT order_id
+		FROM products.stock_reservation
+		WHERE status = 'active' AND expires_at <= $1
+		GROUP BY order_id
+		ORDER BY MIN(expires_at)
+		LIMIT $2
+	`
+
+	querier := postgres.GetQuerier(ctx, r.pool)
+	rows, err := querier.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired stock reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var orderIDs []uuid.UUID
+	for rows.Next() {
+		var orderID string
+		if err := rows.Scan(&orderID); err != nil {
+			return nil, fmt.Errorf("failed to scan expired order id: %w", err)
+		}
+
+		id, err := uuid.Parse(orderID)
+		if err != nil {
+			return nil, err
+		}
+
+		orderIDs = append(orderIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("expired order rows iteration error: %w", err)
+	}
+
+	return orderIDs, nil
+}
+
+// reservationCounters maps a status transition to its effect on the stock
+// counters. Transitions out of waiting take stock and are guarded so they
+// only succeed while enough is available.
+var reservationCounters = map[[2]domain.ReservationStatus]struct {
+	set     string
+	guarded bool
+	onHand  bool
+}{
+	{domain.ReservationActive, domain.ReservationCommitted}:   {set: "quantity = quantity - $2, reserved = reserved - $2", onHand: true},
+	{domain.ReservationActive, domain.ReservationReleased}:    {set: "reserved = reserved - $2"},
+	{domain.ReservationActive, domain.ReservationExpired}:     {set: "reserved = reserved - $2"},
+	{domain.ReservationCommitted, domain.ReservationReturned}: {set: "quantity = quantity + $2", onHand: true},
+	{domain.ReservationWaiting, domain.ReservationActive}:     {set: "reserved = reserved + $2", guarded: true},
+	{domain.ReservationWaiting, domain.ReservationCommitted}:  {set: "quantity = quantity - $2", guarded: true, onHand: true},
+	{domain.ReservationWaiting, domain.ReservationReleased}:   {},
+	{domain.ReservationWaiting, domain.ReservationWaiting}:    {},
+}
+
+// Update stores a reservation that moved from the given status and applies
+// the effect on the stock counters. Transitions that change stock on hand
+// also bump the product version so concurrent stock edits are detected.
+// Changes of the reserved counter alone leave it, as in Create: product
+// edits do not write it, and bumping it would fail them with a conflict
+// whenever an order comes in.
+func (r *ReservationRepo) Update(ctx context.Context, reservation *domain.Reservation, from domain.ReservationStatus) error {
+	counters, ok := reservationCounters[[2]domain.ReservationStatus{from, reservation.Status}]
+	if !ok {
+		return fmt.Errorf("unexpected stock reservation transition %q -> %q", from, reservation.Status)
+	}
+
+	querier := postgres.GetQuerier(ctx, r.pool)
+
+	result, err := querier.Exec(ctx, `
+		UPDATE products.stock_reservation
+		SET status = $2, confirmed = $3, expires_at = $4, updated_at = $5
+		WHERE id = $1 AND status = $6
+	`,
+		reservation.ID.String(),
+		string(reservation.Status),
+		reservation.Confirmed,
+		reservation.ExpiresAt,
+		reservation.UpdatedAt,
+		string(from),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update stock reservation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrReservationNotActive
+	}
+
+	if counters.set == "" {
+		return nil
+	}
+
+	table, id := stockRow(reservation)
+	query := `UPDATE ` + table + ` SET ` + counters.set + `, updated_at = NOW() WHERE id = $1`
+	if counters.guarded {
+		query += ` AND quantity - reserved >= $2`
+	}
+
+	result, err = querier.Exec(ctx, query, id, reservation.Quantity)
+	if err != nil {
+		return fmt.Errorf("failed to update reserved stock: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrInsufficientStock
+	}
+
+	if !counters.onHand {
+		return nil
+	}
+
+	if _, err = querier.Exec(ctx, `
+		UPDATE products.product
+		SET version = version + 1
+		WHERE id = $1
+	`, reservation.ProductID.String()); err != nil {
+		return fmt.Errorf("failed to bump product version: %w", err)
+	}
+
+	return nil
+}
+
+// stockRow names the row whose counters a reservation holds: the variant
+// when one was ordered, the product otherwise.
+func stockRow(reservation *domain.Reservation) (string, string) {
+	if reservation.VariantID != nil {
+		return "products.variant", reservation.VariantID.String()
+	}
+	return "products.product", reservation.ProductID.String()
+}
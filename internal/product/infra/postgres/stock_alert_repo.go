@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	pService "github.com/BlackRRR/Irtea-test/internal/product/app"
+)
+
+var _ pService.StockAlertRepo = (*StockAlertRepo)(nil)
+
+type StockAlertRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewStockAlertRepo(pool *pgxpool.Pool) *StockAlertRepo {
+	return &StockAlertRepo{pool: pool}
+}
+
+type StockAlertDB struct {
+	ID          string     `db:"id"`
+	ProductID   string     `db:"product_id"`
+	Description string     `db:"description"`
+	Threshold   int        `db:"threshold"`
+	Quantity    int        `db:"quantity"`
+	CreatedAt   time.Time  `db:"created_at"`
+	ResolvedAt  *time.Time `db:"resolved_at"`
+}
+
+func (a *StockAlertDB) ToDomain() (*domain.StockAlert, error) {
+	id, err := uuid.Parse(a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := uuid.Parse(a.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.StockAlert{
+		ID:          domain.StockAlertID(id),
+		ProductID:   domain.ProductID(productID),
+		Description: a.Description,
+		Threshold:   a.Threshold,
+		Quantity:    a.Quantity,
+		CreatedAt:   a.CreatedAt,
+		ResolvedAt:  a.ResolvedAt,
+	}, nil
+}
+
+// Create opens an alert. The partial unique index on open alerts turns a
+// second alert for the same product into ErrStockAlertAlreadyOpen.
+func (r *StockAlertRepo) Create(ctx context.Context, alert *domain.StockAlert) error {
+	query := `
+		INSERT INTO products.stock_alert (id, product_id, description, threshold, quantity, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (product_id) WHERE resolved_at IS NULL DO NOTHING
+	`
+
+	querier := postgres.GetQuerier(ctx, r.pool)
+	result, err := querier.Exec(ctx, query,
+		alert.ID.String(),
+		alert.ProductID.String(),
+		alert.Description,
+		alert.Threshold,
+		alert.Quantity,
+		alert.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create stock alert: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrStockAlertAlreadyOpen
+	}
+
+	return nil
+}
+
+func (r *StockAlertRepo) Resolve(ctx context.Context, productID domain.ProductID, resolvedAt time.Time) error {
+	query := `
+		UPDATE products.stock_alert
+		SET resolved_at = $2
+		WHERE product_id = $1 AND resolved_at IS NULL
+	`
+
+	querier := postgres.GetQuerier(ctx, r.pool)
+	if _, err := querier.Exec(ctx, query, productID.String(), resolvedAt); err != nil {
+		return fmt.Errorf("failed to resolve stock alert: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StockAlertRepo) List(ctx context.Context, status pService.AlertStatus, limit, offset int) ([]*domain.StockAlert, error) {
+	var condition string
+	switch status {
+	case pService.AlertStatusOpen:
+		condition = "WHERE resolved_at IS NULL"
+	case pService.AlertStatusResolved:
+		condition = "WHERE resolved_at IS NOT NULL"
+	case pService.AlertStatusAll:
+	default:
+		return nil, errors.New("unknown stock alert status")
+	}
+
+	query := `
+		SELECT id, product_id, description, threshold, quantity, created_at, resolved_at
+		FROM products.stock_alert
+		` + condition + `
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	querier := postgres.GetQuerier(ctx, r.pool)
+	rows, err := querier.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []*domain.StockAlert
+	for rows.Next() {
+		var alertDB StockAlertDB
+		err := rows.Scan(
+			&alertDB.ID,
+			&alertDB.ProductID,
+			&alertDB.Description,
+			&alertDB.Threshold,
+			&alertDB.Quantity,
+			&alertDB.CreatedAt,
+			&alertDB.ResolvedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock alert row: %w", err)
+		}
+
+		alert, err := alertDB.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert stock alert to domain: %w", err)
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("stock alert rows iteration error: %w", err)
+	}
+
+	return alerts, nil
+}
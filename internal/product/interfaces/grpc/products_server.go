@@ -0,0 +1,288 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/BlackRRR/Irtea-test/internal/product/app"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	productv1 "github.com/BlackRRR/Irtea-test/pkg/api/irtea/product/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultListLimit = 10
+
+// ProductsServer serves productv1.ProductService. Domain errors are returned
+// as they are and mapped to status codes by the server's interceptor.
+type ProductsServer struct {
+	productv1.UnimplementedProductServiceServer
+
+	productService *app.ProductService
+}
+
+func NewProductsServer(productService *app.ProductService) *ProductsServer {
+	return &ProductsServer{
+		productService: productService,
+	}
+}
+
+func (s *ProductsServer) CreateProduct(ctx context.Context, req *productv1.CreateProductRequest) (*productv1.CreateProductResponse, error) {
+	price, err := s.parsePrice(req.GetPrice())
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]app.OptionAxisInput, 0, len(req.GetOptions()))
+	for _, option := range req.GetOptions() {
+		options = append(options, app.OptionAxisInput{
+			Name:   option.GetName(),
+			Values: option.GetValues(),
+		})
+	}
+
+	input := app.CreateProductInput{
+		Description:       req.GetDescription(),
+		Tags:              req.GetTags(),
+		Price:             price,
+		Quantity:          int(req.GetQuantity()),
+		Options:           options,
+		ReorderThreshold:  intPtr(req.ReorderThreshold),
+		BackorderLimit:    intPtr(req.BackorderLimit),
+		RestockExpectedAt: timePtr(req.GetRestockExpectedAt()),
+		ReleaseDate:       timePtr(req.GetReleaseDate()),
+	}
+
+	product, err := s.productService.CreateProduct(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productv1.CreateProductResponse{Product: s.mapProduct(product)}, nil
+}
+
+func (s *ProductsServer) GetProduct(ctx context.Context, req *productv1.GetProductRequest) (*productv1.GetProductResponse, error) {
+	productID, err := s.parseProductID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productService.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productv1.GetProductResponse{Product: s.mapProduct(product)}, nil
+}
+
+func (s *ProductsServer) ListProducts(ctx context.Context, req *productv1.ListProductsRequest) (*productv1.ListProductsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	offset := int(req.GetOffset())
+	if offset < 0 {
+		offset = 0
+	}
+
+	products, err := s.productService.GetProducts(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &productv1.ListProductsResponse{
+		Products: make([]*productv1.Product, 0, len(products)),
+	}
+	for _, product := range products {
+		response.Products = append(response.Products, s.mapProduct(product))
+	}
+
+	return response, nil
+}
+
+func (s *ProductsServer) UpdatePrice(ctx context.Context, req *productv1.UpdatePriceRequest) (*productv1.UpdatePriceResponse, error) {
+	productID, err := s.parseProductID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := s.parsePrice(req.GetPrice())
+	if err != nil {
+		return nil, err
+	}
+
+	input := app.UpdatePriceInput{
+		ProductID:       productID,
+		Price:           price,
+		ExpectedVersion: intPtr(req.ExpectedVersion),
+	}
+
+	product, err := s.productService.UpdatePrice(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productv1.UpdatePriceResponse{Product: s.mapProduct(product)}, nil
+}
+
+func (s *ProductsServer) AdjustStock(ctx context.Context, req *productv1.AdjustStockRequest) (*productv1.AdjustStockResponse, error) {
+	productID, err := s.parseProductID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	input := app.AdjustStockInput{
+		ProductID:       productID,
+		Quantity:        int(req.GetQuantity()),
+		ExpectedVersion: intPtr(req.ExpectedVersion),
+	}
+
+	product, err := s.productService.AdjustStock(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productv1.AdjustStockResponse{Product: s.mapProduct(product)}, nil
+}
+
+func (s *ProductsServer) ArchiveProduct(ctx context.Context, req *productv1.ArchiveProductRequest) (*productv1.ArchiveProductResponse, error) {
+	productID, err := s.parseProductID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	input := app.ArchiveProductInput{
+		ProductID:       productID,
+		ExpectedVersion: intPtr(req.ExpectedVersion),
+	}
+
+	product, err := s.productService.ArchiveProduct(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productv1.ArchiveProductResponse{Product: s.mapProduct(product)}, nil
+}
+
+func (s *ProductsServer) RestoreProduct(ctx context.Context, req *productv1.RestoreProductRequest) (*productv1.RestoreProductResponse, error) {
+	productID, err := s.parseProductID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	input := app.ArchiveProductInput{
+		ProductID:       productID,
+		ExpectedVersion: intPtr(req.ExpectedVersion),
+	}
+
+	product, err := s.productService.RestoreProduct(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productv1.RestoreProductResponse{Product: s.mapProduct(product)}, nil
+}
+
+func (s *ProductsServer) mapProduct(product *domain.Product) *productv1.Product {
+	options := make([]*productv1.OptionAxis, 0, len(product.Options))
+	for _, option := range product.Options {
+		options = append(options, &productv1.OptionAxis{
+			Name:   option.Name,
+			Values: option.Values,
+		})
+	}
+
+	variants := make([]*productv1.Variant, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		variants = append(variants, &productv1.Variant{
+			Id:        variant.ID.String(),
+			Sku:       variant.SKU,
+			Barcode:   variant.Barcode,
+			Options:   variant.Options,
+			Price:     variant.Price.Amount().String(),
+			Quantity:  int32(variant.Inventory.Quantity()),
+			Reserved:  int32(variant.Inventory.Reserved()),
+			Available: int32(variant.Inventory.Available()),
+			CreatedAt: timestamppb.New(variant.CreatedAt),
+			UpdatedAt: timestamppb.New(variant.UpdatedAt),
+		})
+	}
+
+	return &productv1.Product{
+		Id:                product.ID.String(),
+		Description:       product.Description,
+		Tags:              product.Tags,
+		Price:             product.Price.Amount().String(),
+		Quantity:          int32(product.Inventory.Quantity()),
+		Reserved:          int32(product.Inventory.Reserved()),
+		Available:         int32(product.Inventory.Available()),
+		Options:           options,
+		Variants:          variants,
+		ReorderThreshold:  int32Ptr(product.ReorderThreshold),
+		LowStock:          product.IsLowStock(),
+		BackorderLimit:    int32Ptr(product.BackorderLimit),
+		RestockExpectedAt: timestamp(product.RestockExpectedAt),
+		ReleaseDate:       timestamp(product.ReleaseDate),
+		Version:           int32(product.Version),
+		CreatedAt:         timestamppb.New(product.CreatedAt),
+		UpdatedAt:         timestamppb.New(product.UpdatedAt),
+		ArchivedAt:        timestamp(product.ArchivedAt),
+	}
+}
+
+func (s *ProductsServer) parseProductID(v string) (domain.ProductID, error) {
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return domain.ProductID{}, status.Error(codes.InvalidArgument, "invalid product ID format")
+	}
+
+	return domain.ProductID(id), nil
+}
+
+func (s *ProductsServer) parsePrice(v string) (decimal.Decimal, error) {
+	price, err := decimal.NewFromString(v)
+	if err != nil {
+		return decimal.Decimal{}, status.Error(codes.InvalidArgument, "invalid price")
+	}
+
+	return price, nil
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+
+	i := int(*v)
+	return &i
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+
+	i := int32(*v)
+	return &i
+}
+
+func timePtr(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+	return &t
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,252 @@
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+
+	"github.com/BlackRRR/Irtea-test/internal/product/app"
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/BlackRRR/Irtea-test/internal/product/interfaces/http/dto"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	catalogFormatCSV    = "csv"
+	catalogFormatNDJSON = "ndjson"
+
+	mimeTextCSV           = "text/csv"
+	mimeApplicationNDJSON = "application/x-ndjson"
+
+	csvTagSeparator = ";"
+)
+
+var csvColumns = []string{"id", "description", "tags", "price", "quantity"}
+
+// catalogFormat picks the format from ?format= and falls back to the given
+// media type header (Content-Type for imports, Accept for exports).
+func catalogFormat(c *fiber.Ctx, mediaType string) (string, error) {
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		switch {
+		case strings.Contains(mediaType, "csv"):
+			format = catalogFormatCSV
+		case strings.Contains(mediaType, "ndjson"), strings.Contains(mediaType, "jsonl"):
+			format = catalogFormatNDJSON
+		default:
+			format = catalogFormatCSV
+		}
+	}
+
+	if format != catalogFormatCSV && format != catalogFormatNDJSON {
+		return "", ErrUnknownCatalogFormat
+	}
+
+	return format, nil
+}
+
+func decodeCatalog(format string, r io.Reader) iter.Seq[app.ImportRow] {
+	if format == catalogFormatNDJSON {
+		return decodeNDJSON(r)
+	}
+	return decodeCSV(r)
+}
+
+// decodeCSV reads a header row and then one product per record. Columns are
+// matched by header name, so their order does not matter and id is ignored.
+func decodeCSV(r io.Reader) iter.Seq[app.ImportRow] {
+	return func(yield func(app.ImportRow) bool) {
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		header, err := reader.Read()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				yield(app.ImportRow{Line: 1, Err: fmt.Errorf("invalid csv header: %w", err)})
+			}
+			return
+		}
+
+		columns := make(map[string]int, len(header))
+		for i, name := range header {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+
+		for _, required := range []string{"description", "price", "quantity"} {
+			if _, ok := columns[required]; !ok {
+				yield(app.ImportRow{Line: 1, Err: fmt.Errorf("csv header is missing column %q", required)})
+				return
+			}
+		}
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			line, _ := reader.FieldPos(0)
+
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				if !yield(app.ImportRow{Line: parseErr.Line, Err: parseErr.Err}) {
+					return
+				}
+				continue
+			}
+			if err != nil {
+				yield(app.ImportRow{Line: line, Err: err})
+				return
+			}
+
+			row := app.ImportRow{Line: line}
+			row.Input, row.Err = csvRecordInput(record, columns)
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+func csvRecordInput(record []string, columns map[string]int) (app.CreateProductInput, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	input := app.CreateProductInput{
+		Description: field("description"),
+	}
+
+	if tags := field("tags"); tags != "" {
+		input.Tags = strings.Split(tags, csvTagSeparator)
+	}
+
+	priceValue := field("price")
+	if priceValue == "" {
+		return input, errMissingPrice
+	}
+
+	price, err := decimal.NewFromString(priceValue)
+	if err != nil {
+		return input, domain.ErrInvalidPrice
+	}
+	input.Price = price
+
+	quantity, err := strconv.Atoi(field("quantity"))
+	if err != nil {
+		return input, domain.ErrInvalidQuantity
+	}
+	input.Quantity = quantity
+
+	return input, nil
+}
+
+// decodeNDJSON reads one JSON object per line; blank lines are skipped.
+func decodeNDJSON(r io.Reader) iter.Seq[app.ImportRow] {
+	return func(yield func(app.ImportRow) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		line := 0
+		for scanner.Scan() {
+			line++
+
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+
+			row := app.ImportRow{Line: line}
+
+			var record dto.ProductRecord
+			if err := json.Unmarshal([]byte(text), &record); err != nil {
+				row.Err = fmt.Errorf("invalid json: %w", err)
+			} else if record.Price == nil {
+				row.Err = errMissingPrice
+			} else {
+				row.Input = app.CreateProductInput{
+					Description: record.Description,
+					Tags:        record.Tags,
+					Price:       *record.Price,
+					Quantity:    record.Quantity,
+				}
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield(app.ImportRow{Line: line + 1, Err: err})
+		}
+	}
+}
+
+// catalogEncoder writes products in one of the catalog formats.
+type catalogEncoder interface {
+	Encode(product *domain.Product) error
+	Flush() error
+}
+
+func newCatalogEncoder(format string, w io.Writer) (catalogEncoder, error) {
+	if format == catalogFormatNDJSON {
+		return &ndjsonEncoder{encoder: json.NewEncoder(w)}, nil
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return nil, err
+	}
+	return &csvEncoder{writer: writer}, nil
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) Encode(product *domain.Product) error {
+	return e.writer.Write([]string{
+		product.ID.String(),
+		product.Description,
+		strings.Join(product.Tags, csvTagSeparator),
+		product.Price.Amount().String(),
+		strconv.Itoa(product.Inventory.Quantity()),
+	})
+}
+
+func (e *csvEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(product *domain.Product) error {
+	price := product.Price.Amount()
+	return e.encoder.Encode(dto.ProductRecord{
+		ID:          product.ID.String(),
+		Description: product.Description,
+		Tags:        product.Tags,
+		Price:       &price,
+		Quantity:    product.Inventory.Quantity(),
+	})
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return nil
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/BlackRRR/Irtea-test/internal/product/domain"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCSV(t *testing.T) {
+	body := "quantity,description,price,tags\n" +
+		"10,Mug,5.50,kitchen;ceramic\n" +
+		"3,Plate,abc,\n"
+
+	rows := slices.Collect(decodeCSV(strings.NewReader(body)))
+
+	assert.Len(t, rows, 2)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "Mug", rows[0].Input.Description)
+	assert.Equal(t, []string{"kitchen", "ceramic"}, rows[0].Input.Tags)
+	assert.True(t, decimal.RequireFromString("5.50").Equal(rows[0].Input.Price))
+	assert.Equal(t, 10, rows[0].Input.Quantity)
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, domain.ErrInvalidPrice, rows[1].Err)
+}
+
+func TestDecodeCSV_MissingColumn(t *testing.T) {
+	rows := slices.Collect(decodeCSV(strings.NewReader("description,price\nMug,5\n")))
+
+	assert.Len(t, rows, 1)
+	assert.ErrorContains(t, rows[0].Err, `"quantity"`)
+}
+
+func TestDecodeNDJSON(t *testing.T) {
+	body := `{"description":"Mug","tags":["kitchen"],"price":"5.50","quantity":10}` + "\n" +
+		"\n" +
+		`{"description":"Plate","quantity":3}` + "\n" +
+		`not json` + "\n"
+
+	rows := slices.Collect(decodeNDJSON(strings.NewReader(body)))
+
+	assert.Len(t, rows, 3)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, "Mug", rows[0].Input.Description)
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, errMissingPrice, rows[1].Err)
+	assert.Equal(t, 4, rows[2].Line)
+	assert.ErrorContains(t, rows[2].Err, "invalid json")
+}
+
+func TestCatalogEncoder_RoundTrip(t *testing.T) {
+	price, _ := domain.NewMoney(decimal.RequireFromString("5.50"))
+	inventory, _ := domain.NewInventory(10)
+	product, _ := domain.NewProduct("Mug", []string{"kitchen", "ceramic"}, price, inventory)
+
+	for _, format := range []string{catalogFormatCSV, catalogFormatNDJSON} {
+		var buf bytes.Buffer
+		encoder, err := newCatalogEncoder(format, &buf)
+		assert.NoError(t, err)
+		assert.NoError(t, encoder.Encode(product))
+		assert.NoError(t, encoder.Flush())
+
+		rows := slices.Collect(decodeCatalog(format, &buf))
+		assert.Len(t, rows, 1, format)
+		assert.NoError(t, rows[0].Err, format)
+		assert.Equal(t, "Mug", rows[0].Input.Description, format)
+		assert.Equal(t, []string{"kitchen", "ceramic"}, rows[0].Input.Tags, format)
+		assert.Equal(t, 10, rows[0].Input.Quantity, format)
+	}
+}
@@ -1,28 +1,159 @@
 package dto
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/BlackRRR/Irtea-test/pkg/patch"
+)
+
+type OptionAxisRequest struct {
+	Name   string   `json:"name" validate:"required"`
+	Values []string `json:"values" validate:"required,min=1"`
+}
 
 type CreateProductRequest struct {
-	Description string          `json:"description" validate:"required"`
-	Tags        []string        `json:"tags"`
-	Price       decimal.Decimal `json:"price" validate:"required"`
-	Quantity    int             `json:"quantity" validate:"required,min=0"`
+	Description      string              `json:"description" validate:"required"`
+	Tags             []string            `json:"tags" validate:"omitempty,tags"`
+	Price            decimal.Decimal     `json:"price" validate:"required,price"`
+	Quantity         int                 `json:"quantity" validate:"min=0"`
+	Options          []OptionAxisRequest `json:"options" validate:"omitempty,dive"`
+	ReorderThreshold *int                `json:"reorder_threshold" validate:"omitempty,min=0"`
+	// BackorderLimit caps the units that may be ordered beyond stock; nil
+	// or zero disables backorders.
+	BackorderLimit    *int       `json:"backorder_limit" validate:"omitempty,min=0"`
+	RestockExpectedAt *time.Time `json:"restock_expected_at"`
+	ReleaseDate       *time.Time `json:"release_date"`
+}
+
+type AddVariantRequest struct {
+	SKU      string            `json:"sku" validate:"required"`
+	Barcode  string            `json:"barcode"`
+	Options  map[string]string `json:"options"`
+	Price    decimal.Decimal   `json:"price" validate:"required,price"`
+	Quantity int               `json:"quantity" validate:"min=0"`
 }
 
 type UpdatePriceRequest struct {
-	Price decimal.Decimal `json:"price" validate:"required"`
+	Price decimal.Decimal `json:"price" validate:"required,price"`
+}
+
+// PatchProductRequest is a JSON Merge Patch document (RFC 7396).
+type PatchProductRequest struct {
+	Description       patch.Field[string]          `json:"description"`
+	Tags              patch.Field[[]string]        `json:"tags" validate:"omitempty,tags"`
+	Price             patch.Field[decimal.Decimal] `json:"price" validate:"omitempty,price"`
+	ReorderThreshold  patch.Field[int]             `json:"reorder_threshold"`
+	BackorderLimit    patch.Field[int]             `json:"backorder_limit"`
+	RestockExpectedAt patch.Field[time.Time]       `json:"restock_expected_at"`
+	ReleaseDate       patch.Field[time.Time]       `json:"release_date"`
+}
+
+// PurchaseLimitsRequest replaces a product's purchase limits. Window is a
+// Go duration such as "24h" and is required with max_per_user.
+type PurchaseLimitsRequest struct {
+	MaxPerOrder *int   `json:"max_per_order" validate:"omitempty,min=1"`
+	MaxPerUser  *int   `json:"max_per_user" validate:"omitempty,min=1"`
+	Window      string `json:"window"`
 }
 
 type AdjustStockRequest struct {
 	Quantity int `json:"quantity" validate:"required"`
 }
 
+type ReorderImagesRequest struct {
+	ImageIDs []string `json:"image_ids" validate:"required,dive,uuid"`
+}
+
+type OptionAxisResponse struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type VariantResponse struct {
+	ID        string            `json:"id"`
+	SKU       string            `json:"sku"`
+	Barcode   string            `json:"barcode"`
+	Options   map[string]string `json:"options"`
+	Price     decimal.Decimal   `json:"price"`
+	Quantity  int               `json:"quantity"`
+	Reserved  int               `json:"reserved"`
+	Available int               `json:"available"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+type ImageResponse struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Position     int    `json:"position"`
+	IsPrimary    bool   `json:"is_primary"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type PurchaseLimitsResponse struct {
+	MaxPerOrder *int   `json:"max_per_order"`
+	MaxPerUser  *int   `json:"max_per_user"`
+	Window      string `json:"window,omitempty"`
+}
+
+// ProductResponse is sent with Version as its ETag. The version does not
+// cover Reserved and Available: reservations change them without bumping it.
 type ProductResponse struct {
-	ID          string          `json:"id"`
-	Description string          `json:"description"`
-	Tags        []string        `json:"tags"`
-	Price       decimal.Decimal `json:"price"`
-	Quantity    int             `json:"quantity"`
-	CreatedAt   string          `json:"created_at"`
-	UpdatedAt   string          `json:"updated_at"`
+	ID                string                  `json:"id"`
+	Description       string                  `json:"description"`
+	Tags              []string                `json:"tags"`
+	Price             decimal.Decimal         `json:"price"`
+	Quantity          int                     `json:"quantity"`
+	Reserved          int                     `json:"reserved"`
+	Available         int                     `json:"available"`
+	Options           []OptionAxisResponse    `json:"options"`
+	Variants          []VariantResponse       `json:"variants"`
+	Images            []ImageResponse         `json:"images"`
+	ReorderThreshold  *int                    `json:"reorder_threshold"`
+	LowStock          bool                    `json:"low_stock"`
+	BackorderLimit    *int                    `json:"backorder_limit"`
+	RestockExpectedAt *string                 `json:"restock_expected_at"`
+	ReleaseDate       *string                 `json:"release_date"`
+	PurchaseLimits    *PurchaseLimitsResponse `json:"purchase_limits,omitempty"`
+	Version           int                     `json:"version"`
+	CreatedAt         string                  `json:"created_at"`
+	UpdatedAt         string                  `json:"updated_at"`
+	ArchivedAt        *string                 `json:"archived_at,omitempty"`
+}
+
+type ProductListResponse struct {
+	Products []ProductResponse `json:"products"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+type StockAlertResponse struct {
+	ID          string  `json:"id"`
+	ProductID   string  `json:"product_id"`
+	Description string  `json:"description"`
+	Threshold   int     `json:"threshold"`
+	Quantity    int     `json:"quantity"`
+	CreatedAt   string  `json:"created_at"`
+	ResolvedAt  *string `json:"resolved_at,omitempty"`
+}
+
+type StockAlertListResponse struct {
+	Alerts []StockAlertResponse `json:"alerts"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// ProductRecord is one line of an NDJSON catalog import or export.
+type ProductRecord struct {
+	ID          string           `json:"id,omitempty"`
+	Description string           `json:"description"`
+	Tags        []string         `json:"tags"`
+	Price       *decimal.Decimal `json:"price"`
+	Quantity    int              `json:"quantity"`
 }
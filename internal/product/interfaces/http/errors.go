@@ -0,0 +1,15 @@
+package http
+
+import "github.com/BlackRRR/Irtea-test/pkg/errcode"
+
+var (
+	ErrInvalidProductID      = errcode.New("invalid-product-id", "invalid product ID format")
+	ErrInvalidVariantID      = errcode.New("invalid-variant-id", "invalid variant ID format")
+	ErrInvalidImageID        = errcode.New("invalid-image-id", "invalid image ID format")
+	ErrInvalidPurchaseWindow = errcode.New("invalid-purchase-window", "invalid purchase window")
+	ErrImageRequired         = errcode.New("image-required", `multipart field "image" is required`)
+	ErrInvalidImageUpload    = errcode.New("invalid-image-upload", "invalid image upload")
+	ErrUnknownCatalogFormat  = errcode.New("unknown-catalog-format", "format must be csv or ndjson")
+
+	errMissingPrice = errcode.New("missing-price", "price is required")
+)
@@ -1,25 +1,43 @@
 package http
 
 import (
+	"bufio"
+	"bytes"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/BlackRRR/Irtea-test/internal/product/app"
 	"github.com/BlackRRR/Irtea-test/internal/product/domain"
 	"github.com/BlackRRR/Irtea-test/internal/product/interfaces/http/dto"
-	"errors"
 	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/pkg/consts"
+	"github.com/BlackRRR/Irtea-test/pkg/etag"
 	"github.com/BlackRRR/Irtea-test/pkg/validator"
 )
 
+const imageFormField = "image"
+
 type ProductsHandler struct {
-	productService *app.ProductService
+	productService   *app.ProductService
+	imageService     *app.ImageService
+	inventoryService *app.InventoryService
+	logger           *slog.Logger
 }
 
-func NewProductsHandler(productService *app.ProductService) *ProductsHandler {
+func NewProductsHandler(
+	productService *app.ProductService,
+	imageService *app.ImageService,
+	inventoryService *app.InventoryService,
+	logger *slog.Logger,
+) *ProductsHandler {
 	return &ProductsHandler{
-		productService: productService,
+		productService:   productService,
+		imageService:     imageService,
+		inventoryService: inventoryService,
+		logger:           logger,
 	}
 }
 
@@ -28,26 +46,36 @@ func (h *ProductsHandler) CreateProduct(c *fiber.Ctx) error {
 
 	var req dto.CreateProductRequest
 	if err := validator.ReadRequest(c, &req); err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
+		return err
+	}
+
+	options := make([]app.OptionAxisInput, 0, len(req.Options))
+	for _, optionReq := range req.Options {
+		options = append(options, app.OptionAxisInput{
+			Name:   optionReq.Name,
+			Values: optionReq.Values,
 		})
 	}
 
 	input := app.CreateProductInput{
-		Description: req.Description,
-		Tags:        req.Tags,
-		Price:       req.Price,
-		Quantity:    req.Quantity,
+		Description:       req.Description,
+		Tags:              req.Tags,
+		Price:             req.Price,
+		Quantity:          req.Quantity,
+		Options:           options,
+		ReorderThreshold:  req.ReorderThreshold,
+		BackorderLimit:    req.BackorderLimit,
+		RestockExpectedAt: req.RestockExpectedAt,
+		ReleaseDate:       req.ReleaseDate,
 	}
 
 	product, err := h.productService.CreateProduct(ctx, input)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
 	return c.Status(http.StatusCreated).JSON(response)
 }
 
@@ -56,31 +84,21 @@ func (h *ProductsHandler) GetProduct(c *fiber.Ctx) error {
 
 	idParam := c.Params("id")
 	if idParam == "" {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Product ID is required",
-		})
+		return ErrInvalidProductID
 	}
 
 	productID, err := h.parseProductID(idParam)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid product ID format",
-		})
+		return ErrInvalidProductID
 	}
 
 	product, err := h.productService.GetProduct(ctx, productID)
 	if err != nil {
-		if errors.Is(err, domain.ErrProductNotFound) {
-			return c.Status(http.StatusNotFound).JSON(fiber.Map{
-				"error": "Product not found",
-			})
-		}
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Internal server error",
-		})
+		return err
 	}
 
 	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
 	return c.JSON(response)
 }
 
@@ -102,9 +120,7 @@ func (h *ProductsHandler) GetProducts(c *fiber.Ctx) error {
 
 	products, err := h.productService.GetProducts(ctx, limit, offset)
 	if err != nil {
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Internal server error",
-		})
+		return err
 	}
 
 	responses := make([]dto.ProductResponse, 0, len(products))
@@ -112,10 +128,10 @@ func (h *ProductsHandler) GetProducts(c *fiber.Ctx) error {
 		responses = append(responses, h.mapProductToResponse(product))
 	}
 
-	return c.JSON(fiber.Map{
-		"products": responses,
-		"limit":    limit,
-		"offset":   offset,
+	return c.JSON(dto.ProductListResponse{
+		Products: responses,
+		Limit:    limit,
+		Offset:   offset,
 	})
 }
 
@@ -124,43 +140,132 @@ func (h *ProductsHandler) UpdatePrice(c *fiber.Ctx) error {
 
 	idParam := c.Params("id")
 	if idParam == "" {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Product ID is required",
-		})
+		return ErrInvalidProductID
 	}
 
 	productID, err := h.parseProductID(idParam)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid product ID format",
-		})
+		return ErrInvalidProductID
 	}
 
 	var req dto.UpdatePriceRequest
 	if err := validator.ReadRequest(c, &req); err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return err
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
 	}
 
 	input := app.UpdatePriceInput{
-		ProductID: productID,
-		Price:     req.Price,
+		ProductID:       productID,
+		Price:           req.Price,
+		ExpectedVersion: expectedVersion,
 	}
 
 	product, err := h.productService.UpdatePrice(ctx, input)
 	if err != nil {
-		if errors.Is(err, domain.ErrProductNotFound) {
-			return c.Status(http.StatusNotFound).JSON(fiber.Map{
-				"error": "Product not found",
-			})
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+// SetPurchaseLimits replaces the product's per-order and per-customer caps.
+func (h *ProductsHandler) SetPurchaseLimits(c *fiber.Ctx) error {
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	var req dto.PurchaseLimitsRequest
+	if err := validator.ReadRequest(c, &req); err != nil {
+		return err
+	}
+
+	var window time.Duration
+	if req.Window != "" {
+		window, err = time.ParseDuration(req.Window)
+		if err != nil {
+			return ErrInvalidPurchaseWindow
 		}
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	}
+
+	return h.setPurchaseLimits(c, app.SetPurchaseLimitsInput{
+		ProductID:   productID,
+		MaxPerOrder: req.MaxPerOrder,
+		MaxPerUser:  req.MaxPerUser,
+		Window:      window,
+	})
+}
+
+func (h *ProductsHandler) ClearPurchaseLimits(c *fiber.Ctx) error {
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	return h.setPurchaseLimits(c, app.SetPurchaseLimitsInput{ProductID: productID})
+}
+
+func (h *ProductsHandler) setPurchaseLimits(c *fiber.Ctx, input app.SetPurchaseLimitsInput) error {
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+	input.ExpectedVersion = expectedVersion
+
+	product, err := h.productService.SetPurchaseLimits(c.UserContext(), input)
+	if err != nil {
+		return err
 	}
 
 	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+// PatchProduct applies a JSON Merge Patch to the product's editable fields.
+func (h *ProductsHandler) PatchProduct(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	var req dto.PatchProductRequest
+	if err := validator.ReadRequest(c, &req); err != nil {
+		return err
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	input := app.PatchProductInput{
+		ProductID:         productID,
+		Description:       req.Description,
+		Tags:              req.Tags,
+		Price:             req.Price,
+		ReorderThreshold:  req.ReorderThreshold,
+		BackorderLimit:    req.BackorderLimit,
+		RestockExpectedAt: req.RestockExpectedAt,
+		ReleaseDate:       req.ReleaseDate,
+		ExpectedVersion:   expectedVersion,
+	}
+
+	product, err := h.productService.PatchProduct(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
 	return c.JSON(response)
 }
 
@@ -169,61 +274,595 @@ func (h *ProductsHandler) AdjustStock(c *fiber.Ctx) error {
 
 	idParam := c.Params("id")
 	if idParam == "" {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Product ID is required",
-		})
+		return ErrInvalidProductID
 	}
 
 	productID, err := h.parseProductID(idParam)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid product ID format",
-		})
+		return ErrInvalidProductID
 	}
 
 	var req dto.AdjustStockRequest
 	if err := validator.ReadRequest(c, &req); err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return err
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
 	}
 
 	input := app.AdjustStockInput{
-		ProductID: productID,
-		Quantity:  req.Quantity,
+		ProductID:       productID,
+		Quantity:        req.Quantity,
+		ExpectedVersion: expectedVersion,
 	}
 
 	product, err := h.productService.AdjustStock(ctx, input)
 	if err != nil {
-		if errors.Is(err, domain.ErrProductNotFound) {
-			return c.Status(http.StatusNotFound).JSON(fiber.Map{
-				"error": "Product not found",
-			})
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+func (h *ProductsHandler) AddVariant(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	var req dto.AddVariantRequest
+	if err := validator.ReadRequest(c, &req); err != nil {
+		return err
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	input := app.AddVariantInput{
+		ProductID:       productID,
+		SKU:             req.SKU,
+		Barcode:         req.Barcode,
+		Options:         req.Options,
+		Price:           req.Price,
+		Quantity:        req.Quantity,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.productService.AddVariant(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.Status(http.StatusCreated).JSON(response)
+}
+
+func (h *ProductsHandler) UpdateVariantPrice(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	variantID, err := h.parseVariantID(c.Params("variantId"))
+	if err != nil {
+		return ErrInvalidVariantID
+	}
+
+	var req dto.UpdatePriceRequest
+	if err := validator.ReadRequest(c, &req); err != nil {
+		return err
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	input := app.UpdateVariantPriceInput{
+		ProductID:       productID,
+		VariantID:       variantID,
+		Price:           req.Price,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.productService.UpdateVariantPrice(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+func (h *ProductsHandler) AdjustVariantStock(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	variantID, err := h.parseVariantID(c.Params("variantId"))
+	if err != nil {
+		return ErrInvalidVariantID
+	}
+
+	var req dto.AdjustStockRequest
+	if err := validator.ReadRequest(c, &req); err != nil {
+		return err
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	input := app.AdjustVariantStockInput{
+		ProductID:       productID,
+		VariantID:       variantID,
+		Quantity:        req.Quantity,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.productService.AdjustVariantStock(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+// DeleteProduct archives the product. With ?hard=true the product is removed
+// permanently, which is only allowed if it has never been ordered.
+func (h *ProductsHandler) DeleteProduct(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	if c.QueryBool("hard") {
+		err = h.productService.DeleteProduct(ctx, productID)
+		if err != nil {
+			return err
 		}
-		if errors.Is(err, domain.ErrInsufficientStock) {
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-				"error": "Insufficient stock",
+
+		return c.SendStatus(http.StatusNoContent)
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	input := app.ArchiveProductInput{
+		ProductID:       productID,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.productService.ArchiveProduct(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+func (h *ProductsHandler) RestoreProduct(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	input := app.ArchiveProductInput{
+		ProductID:       productID,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.productService.RestoreProduct(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+// ImportProducts creates products from a CSV or NDJSON body. ?mode=atomic
+// (default) stores all rows or none, ?mode=best_effort stores the valid rows,
+// and ?dry_run=true only validates. The response is a per-row report.
+func (h *ProductsHandler) ImportProducts(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	format, err := catalogFormat(c, c.Get(fiber.HeaderContentType))
+	if err != nil {
+		return err
+	}
+
+	input := app.ImportProductsInput{
+		Mode:   app.ImportMode(c.Query("mode", string(app.ImportModeAtomic))),
+		DryRun: c.QueryBool("dry_run"),
+	}
+
+	input.Rows = decodeCatalog(format, bytes.NewReader(c.Body()))
+
+	report, err := h.productService.ImportProducts(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusOK
+	if !report.DryRun && report.Mode == app.ImportModeAtomic && report.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(report)
+}
+
+// ExportProducts streams the active catalog as CSV or NDJSON.
+func (h *ProductsHandler) ExportProducts(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	format, err := catalogFormat(c, c.Get(fiber.HeaderAccept))
+	if err != nil {
+		return err
+	}
+
+	contentType := mimeTextCSV
+	if format == catalogFormatNDJSON {
+		contentType = mimeApplicationNDJSON
+	}
+
+	export, err := h.productService.ExportProducts(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="products.`+format+`"`)
+
+	// The status is sent by now, so later failures can only cut the file
+	// short and be logged.
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		encoder, err := newCatalogEncoder(format, w)
+		if err == nil {
+			err = export.Each(ctx, func(product *domain.Product) error {
+				if err := encoder.Encode(product); err != nil {
+					return err
+				}
+				return w.Flush()
 			})
 		}
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		if err == nil {
+			err = encoder.Flush()
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Catalog export failed mid-stream",
+				slog.String("format", format),
+				slog.Any("error", err),
+			)
+		}
+	})
+
+	return nil
+}
+
+// UploadImage accepts a multipart form with the file in the "image" field.
+func (h *ProductsHandler) UploadImage(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile(imageFormField)
+	if err != nil {
+		return ErrImageRequired
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ErrInvalidImageUpload
+	}
+	defer file.Close()
+
+	input := app.UploadImageInput{
+		ProductID:       productID,
+		Body:            file,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.imageService.UploadImage(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.Status(http.StatusCreated).JSON(response)
+}
+
+func (h *ProductsHandler) DeleteImage(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	imageID, err := h.parseImageID(c.Params("imageId"))
+	if err != nil {
+		return ErrInvalidImageID
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	input := app.DeleteImageInput{
+		ProductID:       productID,
+		ImageID:         imageID,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.imageService.DeleteImage(ctx, input)
+	if err != nil {
+		return err
 	}
 
 	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
 	return c.JSON(response)
 }
 
+func (h *ProductsHandler) ReorderImages(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ReorderImagesRequest
+	if err := validator.ReadRequest(c, &req); err != nil {
+		return err
+	}
+
+	imageIDs := make([]domain.ImageID, 0, len(req.ImageIDs))
+	for _, rawID := range req.ImageIDs {
+		imageID, err := h.parseImageID(rawID)
+		if err != nil {
+			return ErrInvalidImageID
+		}
+		imageIDs = append(imageIDs, imageID)
+	}
+
+	input := app.ReorderImagesInput{
+		ProductID:       productID,
+		ImageIDs:        imageIDs,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.imageService.ReorderImages(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+func (h *ProductsHandler) SetPrimaryImage(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	productID, err := h.parseProductID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidProductID
+	}
+
+	imageID, err := h.parseImageID(c.Params("imageId"))
+	if err != nil {
+		return ErrInvalidImageID
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	input := app.SetPrimaryImageInput{
+		ProductID:       productID,
+		ImageID:         imageID,
+		ExpectedVersion: expectedVersion,
+	}
+
+	product, err := h.imageService.SetPrimaryImage(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	response := h.mapProductToResponse(product)
+	etag.Set(c, product.Version)
+	return c.JSON(response)
+}
+
+// ListStockAlerts lists low-stock alerts, newest first. ?status=open (default),
+// resolved or all.
+func (h *ProductsHandler) ListStockAlerts(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	limitParam := c.Query("limit", "10")
+	offsetParam := c.Query("offset", "0")
+
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(offsetParam)
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	status := app.AlertStatus(c.Query("status", string(app.AlertStatusOpen)))
+
+	alerts, err := h.inventoryService.ListAlerts(ctx, status, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.StockAlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		response := dto.StockAlertResponse{
+			ID:          alert.ID.String(),
+			ProductID:   alert.ProductID.String(),
+			Description: alert.Description,
+			Threshold:   alert.Threshold,
+			Quantity:    alert.Quantity,
+			CreatedAt:   alert.CreatedAt.Format(consts.FormatTimeLayout),
+		}
+		if alert.ResolvedAt != nil {
+			resolvedAt := alert.ResolvedAt.Format(consts.FormatTimeLayout)
+			response.ResolvedAt = &resolvedAt
+		}
+		responses = append(responses, response)
+	}
+
+	return c.JSON(dto.StockAlertListResponse{
+		Alerts: responses,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
 func (h *ProductsHandler) mapProductToResponse(product *domain.Product) dto.ProductResponse {
-	return dto.ProductResponse{
-		ID:          product.ID.String(),
-		Description: product.Description,
-		Tags:        product.Tags,
-		Price:       product.Price.Amount(),
-		Quantity:    product.Inventory.Quantity(),
-		CreatedAt:   product.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:   product.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	options := make([]dto.OptionAxisResponse, 0, len(product.Options))
+	for _, option := range product.Options {
+		options = append(options, dto.OptionAxisResponse{
+			Name:   option.Name,
+			Values: option.Values,
+		})
+	}
+
+	variants := make([]dto.VariantResponse, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		variants = append(variants, dto.VariantResponse{
+			ID:        variant.ID.String(),
+			SKU:       variant.SKU,
+			Barcode:   variant.Barcode,
+			Options:   variant.Options,
+			Price:     variant.Price.Amount(),
+			Quantity:  variant.Inventory.Quantity(),
+			Reserved:  variant.Inventory.Reserved(),
+			Available: variant.Inventory.Available(),
+			CreatedAt: variant.CreatedAt.Format(consts.FormatTimeLayout),
+			UpdatedAt: variant.UpdatedAt.Format(consts.FormatTimeLayout),
+		})
+	}
+
+	images := make([]dto.ImageResponse, 0, len(product.Images))
+	for _, image := range product.Images {
+		images = append(images, dto.ImageResponse{
+			ID:           image.ID.String(),
+			URL:          h.imageService.URL(image.Key),
+			ThumbnailURL: h.imageService.URL(image.ThumbnailKey),
+			ContentType:  image.ContentType,
+			Size:         image.Size,
+			Width:        image.Width,
+			Height:       image.Height,
+			Position:     image.Position,
+			IsPrimary:    image.IsPrimary,
+			CreatedAt:    image.CreatedAt.Format(consts.FormatTimeLayout),
+		})
+	}
+
+	response := dto.ProductResponse{
+		ID:               product.ID.String(),
+		Description:      product.Description,
+		Tags:             product.Tags,
+		Price:            product.Price.Amount(),
+		Quantity:         product.Inventory.Quantity(),
+		Reserved:         product.Inventory.Reserved(),
+		Available:        product.Inventory.Available(),
+		Options:          options,
+		Variants:         variants,
+		Images:           images,
+		ReorderThreshold: product.ReorderThreshold,
+		LowStock:         product.IsLowStock(),
+		BackorderLimit:   product.BackorderLimit,
+		Version:          product.Version,
+		CreatedAt:        product.CreatedAt.Format(consts.FormatTimeLayout),
+		UpdatedAt:        product.UpdatedAt.Format(consts.FormatTimeLayout),
+	}
+
+	if product.ArchivedAt != nil {
+		archivedAt := product.ArchivedAt.Format(consts.FormatTimeLayout)
+		response.ArchivedAt = &archivedAt
+	}
+
+	if product.RestockExpectedAt != nil {
+		restockExpectedAt := product.RestockExpectedAt.Format(consts.FormatTimeLayout)
+		response.RestockExpectedAt = &restockExpectedAt
 	}
+
+	if product.ReleaseDate != nil {
+		releaseDate := product.ReleaseDate.Format(consts.FormatTimeLayout)
+		response.ReleaseDate = &releaseDate
+	}
+
+	if limits := product.PurchaseLimits; !limits.IsZero() {
+		response.PurchaseLimits = &dto.PurchaseLimitsResponse{
+			MaxPerOrder: limits.MaxPerOrder,
+			MaxPerUser:  limits.MaxPerUser,
+		}
+		if limits.Window > 0 {
+			response.PurchaseLimits.Window = limits.Window.String()
+		}
+	}
+
+	return response
 }
 
 func (h *ProductsHandler) parseProductID(s string) (domain.ProductID, error) {
@@ -234,3 +873,21 @@ func (h *ProductsHandler) parseProductID(s string) (domain.ProductID, error) {
 
 	return domain.ProductID(id), err
 }
+
+func (h *ProductsHandler) parseVariantID(s string) (domain.VariantID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return domain.VariantID{}, err
+	}
+
+	return domain.VariantID(id), err
+}
+
+func (h *ProductsHandler) parseImageID(s string) (domain.ImageID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return domain.ImageID{}, err
+	}
+
+	return domain.ImageID(id), err
+}
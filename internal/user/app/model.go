@@ -1,5 +1,7 @@
 package app
 
+import "github.com/BlackRRR/Irtea-test/internal/user/domain"
+
 type RegisterInput struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
@@ -8,3 +10,9 @@ type RegisterInput struct {
 	IsMarried bool   `json:"is_married"`
 	Password  string `json:"password"`
 }
+
+// SetBlockedInput blocks or unblocks a user.
+type SetBlockedInput struct {
+	UserID          domain.UserID `json:"user_id"`
+	ExpectedVersion *int          `json:"expected_version"`
+}
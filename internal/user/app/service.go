@@ -76,3 +76,45 @@ func (s *UserService) Authenticate(ctx context.Context, email, password string)
 
 	return user, nil
 }
+
+// BlockUser keeps the account but stops the user from placing orders.
+func (s *UserService) BlockUser(ctx context.Context, input SetBlockedInput) (*domain.User, error) {
+	return s.update(ctx, input.UserID, input.ExpectedVersion, (*domain.User).Block)
+}
+
+func (s *UserService) UnblockUser(ctx context.Context, input SetBlockedInput) (*domain.User, error) {
+	return s.update(ctx, input.UserID, input.ExpectedVersion, (*domain.User).Unblock)
+}
+
+// update applies change to the stored user when it still has the expected
+// version.
+func (s *UserService) update(ctx context.Context, id domain.UserID, expectedVersion *int, change func(*domain.User) error) (*domain.User, error) {
+	var updatedUser *domain.User
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		user, err := s.userRepo.GetByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := user.CheckVersion(expectedVersion); err != nil {
+			return err
+		}
+
+		if err := change(user); err != nil {
+			return err
+		}
+
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+
+		updatedUser = user
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedUser, nil
+}
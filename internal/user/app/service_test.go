@@ -185,4 +185,60 @@ func TestUserService_Register_UserAlreadyExists(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 	mockHasher.AssertNotCalled(t, "Hash")
-}
\ No newline at end of file
+}
+
+func newTestUser(t *testing.T) *domain.User {
+	t.Helper()
+
+	fullName, err := domain.NewFullName("John", "Doe")
+	assert.NoError(t, err)
+	passwordHash, err := domain.NewPasswordHash("hashedpassword")
+	assert.NoError(t, err)
+	user, err := domain.NewUser(fullName, 25, false, passwordHash)
+	assert.NoError(t, err)
+	return user
+}
+
+func TestUserService_BlockUser(t *testing.T) {
+	mockRepo := new(MockUserRepo)
+	mockTx := new(MockTxManager)
+	service := NewUserService(mockRepo, new(MockPasswordHasher), mockTx)
+
+	user := newTestUser(t)
+	mockTx.On("WithTx", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("Update", mock.Anything, user).Return(nil).Once()
+
+	blocked, err := service.BlockUser(context.Background(), SetBlockedInput{UserID: user.ID})
+	assert.NoError(t, err)
+	assert.True(t, blocked.IsBlocked())
+
+	_, err = service.BlockUser(context.Background(), SetBlockedInput{UserID: user.ID})
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyBlocked)
+
+	mockRepo.On("Update", mock.Anything, user).Return(nil).Once()
+	unblocked, err := service.UnblockUser(context.Background(), SetBlockedInput{UserID: user.ID})
+	assert.NoError(t, err)
+	assert.False(t, unblocked.IsBlocked())
+
+	_, err = service.UnblockUser(context.Background(), SetBlockedInput{UserID: user.ID})
+	assert.ErrorIs(t, err, domain.ErrUserNotBlocked)
+	mockRepo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestUserService_BlockUser_VersionConflict(t *testing.T) {
+	mockRepo := new(MockUserRepo)
+	mockTx := new(MockTxManager)
+	service := NewUserService(mockRepo, new(MockPasswordHasher), mockTx)
+
+	user := newTestUser(t)
+	mockTx.On("WithTx", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	stale := user.Version + 1
+	_, err := service.BlockUser(context.Background(), SetBlockedInput{UserID: user.ID, ExpectedVersion: &stale})
+
+	assert.ErrorIs(t, err, domain.ErrUserVersionConflict)
+	assert.False(t, user.IsBlocked())
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
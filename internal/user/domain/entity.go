@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -29,10 +28,10 @@ func NewFullName(firstName, lastName string) (FullName, error) {
 	lastName = strings.TrimSpace(lastName)
 
 	if firstName == "" {
-		return FullName{}, errors.New("first name cannot be empty")
+		return FullName{}, ErrFirstNameEmpty
 	}
 	if lastName == "" {
-		return FullName{}, errors.New("last name cannot be empty")
+		return FullName{}, ErrLastNameEmpty
 	}
 
 	return FullName{
@@ -51,7 +50,7 @@ type PasswordHash struct {
 
 func NewPasswordHash(hashedPassword string) (PasswordHash, error) {
 	if hashedPassword == "" {
-		return PasswordHash{}, errors.New("password hash cannot be empty")
+		return PasswordHash{}, ErrPasswordHashEmpty
 	}
 	return PasswordHash{value: hashedPassword}, nil
 }
@@ -67,8 +66,10 @@ type User struct {
 	Age       int
 	IsMarried bool
 	Password  PasswordHash
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	BlockedAt *time.Time
 }
 
 func NewUser(fullName FullName, age int, isMarried bool, passwordHash PasswordHash) (*User, error) {
@@ -82,7 +83,41 @@ func NewUser(fullName FullName, age int, isMarried bool, passwordHash PasswordHa
 		Age:       age,
 		IsMarried: isMarried,
 		Password:  passwordHash,
+		Version:   1,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}, nil
 }
+
+// CheckVersion fails when the caller expects a different version than the
+// one loaded. A nil expectation always passes.
+func (u *User) CheckVersion(expected *int) error {
+	if expected != nil && *expected != u.Version {
+		return ErrUserVersionConflict
+	}
+	return nil
+}
+
+// Block keeps the account around but stops the user from placing orders.
+func (u *User) Block() error {
+	if u.IsBlocked() {
+		return ErrUserAlreadyBlocked
+	}
+	now := time.Now()
+	u.BlockedAt = &now
+	u.UpdatedAt = now
+	return nil
+}
+
+func (u *User) Unblock() error {
+	if !u.IsBlocked() {
+		return ErrUserNotBlocked
+	}
+	u.BlockedAt = nil
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+func (u *User) IsBlocked() bool {
+	return u.BlockedAt != nil
+}
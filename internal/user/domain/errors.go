@@ -1,11 +1,17 @@
 package domain
 
-import "errors"
+import "github.com/BlackRRR/Irtea-test/pkg/errcode"
 
 var (
-	ErrUserTooYoung       = errors.New("user must be at least 18 years old")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserAlreadyExists  = errors.New("user already exists")
-	ErrInvalidPassword    = errors.New("password must be at least 8 characters long")
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserTooYoung        = errcode.New("user-too-young", "user must be at least 18 years old")
+	ErrUserNotFound        = errcode.New("user-not-found", "user not found")
+	ErrUserAlreadyExists   = errcode.New("user-already-exists", "user already exists")
+	ErrInvalidPassword     = errcode.New("invalid-password", "password must be at least 8 characters long")
+	ErrInvalidCredentials  = errcode.New("invalid-credentials", "invalid credentials")
+	ErrUserVersionConflict = errcode.New("version-conflict", "user was modified concurrently")
+	ErrUserAlreadyBlocked  = errcode.New("user-already-blocked", "user is already blocked")
+	ErrUserNotBlocked      = errcode.New("user-not-blocked", "user is not blocked")
+	ErrFirstNameEmpty      = errcode.New("empty-first-name", "first name cannot be empty")
+	ErrLastNameEmpty       = errcode.New("empty-last-name", "last name cannot be empty")
+	ErrPasswordHashEmpty   = errcode.New("empty-password-hash", "password hash cannot be empty")
 )
@@ -8,15 +8,17 @@ import (
 )
 
 type UserDB struct {
-	ID        string    `db:"id"`
-	Email     string    `db:"email"`
-	FirstName string    `db:"first_name"`
-	LastName  string    `db:"last_name"`
-	Age       int       `db:"age"`
-	IsMarried bool      `db:"is_married"`
-	Password  string    `db:"password_hash"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID        string     `db:"id"`
+	Email     string     `db:"email"`
+	FirstName string     `db:"first_name"`
+	LastName  string     `db:"last_name"`
+	Age       int        `db:"age"`
+	IsMarried bool       `db:"is_married"`
+	Password  string     `db:"password_hash"`
+	Version   int        `db:"version"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	BlockedAt *time.Time `db:"blocked_at"`
 }
 
 func (u *UserDB) ToDomain() (*domain.User, error) {
@@ -42,8 +44,10 @@ func (u *UserDB) ToDomain() (*domain.User, error) {
 		Age:       u.Age,
 		IsMarried: u.IsMarried,
 		Password:  passwordHash,
+		Version:   u.Version,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
+		BlockedAt: u.BlockedAt,
 	}, nil
 }
 
@@ -56,7 +60,9 @@ func FromDomain(user *domain.User) *UserDB {
 		Age:       user.Age,
 		IsMarried: user.IsMarried,
 		Password:  user.Password.Value(),
+		Version:   user.Version,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+		BlockedAt: user.BlockedAt,
 	}
 }
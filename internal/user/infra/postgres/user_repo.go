@@ -24,8 +24,8 @@ func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
 
 func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
 	const query = `
-		INSERT INTO users.user (id, first_name, last_name, email, age, is_married, password_hash, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO users.user (id, first_name, last_name, email, age, is_married, password_hash, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	userDB := FromDomain(user)
@@ -40,6 +40,7 @@ func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
 		userDB.Age,
 		userDB.IsMarried,
 		userDB.Password,
+		userDB.Version,
 		userDB.CreatedAt,
 		userDB.UpdatedAt,
 	)
@@ -53,7 +54,8 @@ func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
 
 func (r *UserRepo) GetByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
 	query := `
-		SELECT id, email, first_name, last_name, age, is_married, password_hash, created_at, updated_at
+		SELECT id, email, first_name, last_name, age, is_married, password_hash, version, created_at, updated_at,
+		       blocked_at
 		FROM users."user"
 		WHERE id = $1
 	`
@@ -70,8 +72,10 @@ func (r *UserRepo) GetByID(ctx context.Context, id domain.UserID) (*domain.User,
 		&userDB.Age,
 		&userDB.IsMarried,
 		&userDB.Password,
+		&userDB.Version,
 		&userDB.CreatedAt,
 		&userDB.UpdatedAt,
+		&userDB.BlockedAt,
 	)
 
 	if err != nil {
@@ -86,7 +90,8 @@ func (r *UserRepo) GetByID(ctx context.Context, id domain.UserID) (*domain.User,
 
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	const query = `
-		SELECT id, first_name, last_name, age, is_married, password_hash, created_at, updated_at
+		SELECT id, first_name, last_name, age, is_married, password_hash, version, created_at, updated_at,
+		       blocked_at
 		FROM users."user"
 		WHERE $1
 	`
@@ -102,8 +107,10 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User,
 		&userDB.Age,
 		&userDB.IsMarried,
 		&userDB.Password,
+		&userDB.Version,
 		&userDB.CreatedAt,
 		&userDB.UpdatedAt,
+		&userDB.BlockedAt,
 	)
 
 	if err != nil {
@@ -119,8 +126,9 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User,
 func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
 	const query = `
 		UPDATE users."user"
-		SET first_name = $2, last_name = $3, age = $4, is_married = $5, password_hash = $6, updated_at = $7
-		WHERE id = $1
+		SET first_name = $2, last_name = $3, age = $4, is_married = $5, password_hash = $6, updated_at = $7,
+		    blocked_at = $9, version = version + 1
+		WHERE id = $1 AND version = $8
 	`
 
 	userDB := FromDomain(user)
@@ -134,6 +142,8 @@ func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
 		userDB.IsMarried,
 		userDB.Password,
 		userDB.UpdatedAt,
+		userDB.Version,
+		userDB.BlockedAt,
 	)
 
 	if err != nil {
@@ -141,9 +151,11 @@ func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return domain.ErrUserNotFound
+		return postgres.MissingOrConflict(ctx, querier, `users."user"`, userDB.ID, domain.ErrUserNotFound, domain.ErrUserVersionConflict)
 	}
 
+	user.Version++
+
 	return nil
 }
 
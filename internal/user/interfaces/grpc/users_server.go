@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/internal/user/app"
+	"github.com/BlackRRR/Irtea-test/internal/user/domain"
+	userv1 "github.com/BlackRRR/Irtea-test/pkg/api/irtea/user/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UsersServer serves userv1.UserService. Domain errors are returned as they
+// are and mapped to status codes by the server's interceptor.
+type UsersServer struct {
+	userv1.UnimplementedUserServiceServer
+
+	userService *app.UserService
+}
+
+func NewUsersServer(userService *app.UserService) *UsersServer {
+	return &UsersServer{
+		userService: userService,
+	}
+}
+
+func (s *UsersServer) Register(ctx context.Context, req *userv1.RegisterRequest) (*userv1.RegisterResponse, error) {
+	input := app.RegisterInput{
+		FirstName: req.GetFirstName(),
+		LastName:  req.GetLastName(),
+		Age:       int(req.GetAge()),
+		Email:     req.GetEmail(),
+		IsMarried: req.GetIsMarried(),
+		Password:  req.GetPassword(),
+	}
+
+	user, err := s.userService.Register(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.RegisterResponse{User: s.mapUser(user)}, nil
+}
+
+func (s *UsersServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
+	}
+
+	user, err := s.userService.GetByID(ctx, domain.UserID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.GetUserResponse{User: s.mapUser(user)}, nil
+}
+
+func (s *UsersServer) mapUser(user *domain.User) *userv1.User {
+	return &userv1.User{
+		Id:        user.ID.String(),
+		FirstName: user.FullName.FirstName,
+		LastName:  user.FullName.LastName,
+		FullName:  user.FullName.String(),
+		Age:       int32(user.Age),
+		IsMarried: user.IsMarried,
+		Email:     user.Email,
+		Version:   int32(user.Version),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
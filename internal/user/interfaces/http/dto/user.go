@@ -17,6 +17,9 @@ type UserResponse struct {
 	Age       int    `json:"age"`
 	IsMarried bool   `json:"is_married"`
 	Email     string `json:"email"`
+	Version   int    `json:"version"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+	// Set while the user is blocked from placing orders
+	BlockedAt string `json:"blocked_at,omitempty"`
 }
@@ -0,0 +1,5 @@
+package http
+
+import "github.com/BlackRRR/Irtea-test/pkg/errcode"
+
+var ErrInvalidUserID = errcode.New("invalid-user-id", "invalid user ID format")
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,8 +9,8 @@ import (
 	"github.com/BlackRRR/Irtea-test/internal/user/app"
 	"github.com/BlackRRR/Irtea-test/internal/user/domain"
 	"github.com/BlackRRR/Irtea-test/internal/user/interfaces/http/dto"
-	"errors"
 	"github.com/BlackRRR/Irtea-test/pkg/consts"
+	"github.com/BlackRRR/Irtea-test/pkg/etag"
 	"github.com/BlackRRR/Irtea-test/pkg/validator"
 )
 
@@ -30,9 +31,7 @@ func (h *UsersHandler) Register(c *fiber.Ctx) error {
 
 	err := validator.ReadRequest(c, &req)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return err
 	}
 
 	input := app.RegisterInput{
@@ -46,65 +45,76 @@ func (h *UsersHandler) Register(c *fiber.Ctx) error {
 
 	user, err := h.userService.Register(ctx, input)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrUserTooYoung):
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-				"error": "User must be at least 18 years old",
-			})
-		case errors.Is(err, domain.ErrInvalidPassword):
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-				"error": "Password must be at least 8 characters long",
-			})
-		case errors.Is(err, domain.ErrUserAlreadyExists):
-			return c.Status(http.StatusConflict).JSON(fiber.Map{
-				"error": "User with this email already exists",
-			})
-		default:
-			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Internal server error",
-			})
-		}
+		return err
 	}
 
 	response := h.mapUserToResponse(user)
+	etag.Set(c, user.Version)
 	return c.Status(http.StatusCreated).JSON(response)
 }
 
 func (h *UsersHandler) GetByID(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 
-	idParam := c.Params("id")
-	if idParam == "" {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "User ID is required",
-		})
+	userID, err := parseUserID(c.Params("id"))
+	if err != nil {
+		return err
 	}
 
-	parsedUUID, err := uuid.Parse(idParam)
+	user, err := h.userService.GetByID(ctx, userID)
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid user ID format",
-		})
+		return err
 	}
-	userID := domain.UserID(parsedUUID)
 
-	user, err := h.userService.GetByID(ctx, userID)
+	response := h.mapUserToResponse(user)
+	etag.Set(c, user.Version)
+	return c.JSON(response)
+}
+
+// BlockUser stops the user from placing orders. If-Match is optional.
+func (h *UsersHandler) BlockUser(c *fiber.Ctx) error {
+	return h.setBlocked(c, h.userService.BlockUser)
+}
+
+func (h *UsersHandler) UnblockUser(c *fiber.Ctx) error {
+	return h.setBlocked(c, h.userService.UnblockUser)
+}
+
+func (h *UsersHandler) setBlocked(c *fiber.Ctx, apply func(context.Context, app.SetBlockedInput) (*domain.User, error)) error {
+	userID, err := parseUserID(c.Params("id"))
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			return c.Status(http.StatusNotFound).JSON(fiber.Map{
-				"error": "User not found",
-			})
-		}
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Internal server error",
-		})
+		return err
+	}
+
+	expectedVersion, err := etag.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	user, err := apply(c.UserContext(), app.SetBlockedInput{UserID: userID, ExpectedVersion: expectedVersion})
+	if err != nil {
+		return err
 	}
 
 	response := h.mapUserToResponse(user)
+	etag.Set(c, user.Version)
 	return c.JSON(response)
 }
 
+func parseUserID(value string) (domain.UserID, error) {
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return domain.UserID{}, ErrInvalidUserID
+	}
+	return domain.UserID(parsed), nil
+}
+
 func (h *UsersHandler) mapUserToResponse(user *domain.User) dto.UserResponse {
+	var blockedAt string
+	if user.BlockedAt != nil {
+		blockedAt = user.BlockedAt.Format(consts.FormatTimeLayout)
+	}
+
 	return dto.UserResponse{
 		ID:        user.ID.String(),
 		FirstName: user.FullName.FirstName,
@@ -113,7 +123,9 @@ func (h *UsersHandler) mapUserToResponse(user *domain.User) dto.UserResponse {
 		Age:       user.Age,
 		IsMarried: user.IsMarried,
 		Email:     user.Email,
+		Version:   user.Version,
 		CreatedAt: user.CreatedAt.Format(consts.FormatTimeLayout),
 		UpdatedAt: user.UpdatedAt.Format(consts.FormatTimeLayout),
+		BlockedAt: blockedAt,
 	}
 }
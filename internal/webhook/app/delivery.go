@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+	"github.com/BlackRRR/Irtea-test/pkg/schedule"
+)
+
+const (
+	HeaderEventID    = "X-Webhook-Event-Id"
+	HeaderEventType  = "X-Webhook-Event"
+	HeaderDeliveryID = "X-Webhook-Delivery-Id"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+type DeliveryConfig struct {
+	BatchSize    int           `env:"BATCH_SIZE" envDefault:"20" validate:"gt=0"`
+	PollInterval time.Duration `env:"POLL_INTERVAL" envDefault:"5s" validate:"gt=0"`
+	Timeout      time.Duration `env:"TIMEOUT" envDefault:"10s" validate:"gt=0"`
+	MaxAttempts  int           `env:"MAX_ATTEMPTS" envDefault:"8" validate:"gt=0"`
+	BaseBackoff  time.Duration `env:"BASE_BACKOFF" envDefault:"30s" validate:"gt=0"`
+	MaxBackoff   time.Duration `env:"MAX_BACKOFF" envDefault:"6h" validate:"gt=0"`
+}
+
+// envelope is the body partners receive. Data is the event as it was stored
+// in the outbox.
+type envelope struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DeliverDue sends batches of due deliveries until none are left and
+// returns how many succeeded.
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	return schedule.Drain(ctx, s.config.BatchSize, s.deliverBatch)
+}
+
+// deliverBatch claims a batch for long enough to send every delivery in it,
+// so concurrent workers skip them, and records each attempt in its own
+// transaction as soon as it is made. No transaction is open while a request
+// is in flight. Deliveries left over when the lease runs short are sent by
+// whoever claims them next.
+func (s *WebhookService) deliverBatch(ctx context.Context) (int, int, error) {
+	now := time.Now()
+	leaseUntil := now.Add(s.config.Timeout * time.Duration(s.config.BatchSize))
+
+	deliveries, err := s.deliveryRepo.Claim(ctx, now, leaseUntil, s.config.BatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var succeeded int
+	subscriptions := make(map[domain.SubscriptionID]*domain.Subscription)
+	for _, delivery := range deliveries {
+		if time.Now().Add(s.config.Timeout).After(leaseUntil) {
+			break
+		}
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.subscriptionRepo.GetByID(ctx, delivery.SubscriptionID)
+			if errors.Is(err, domain.ErrSubscriptionNotFound) {
+				// Deleted since it was claimed, together with its deliveries.
+				continue
+			}
+			if err != nil {
+				return len(deliveries), succeeded, err
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		s.deliver(ctx, subscription, delivery)
+
+		err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+			return s.deliveryRepo.RecordAttempt(txCtx, delivery)
+		})
+		if err != nil {
+			return len(deliveries), succeeded, err
+		}
+
+		if delivery.Status == domain.DeliveryStatusSucceeded {
+			succeeded++
+		}
+	}
+
+	return len(deliveries), succeeded, nil
+}
+
+func (s *WebhookService) deliver(ctx context.Context, subscription *domain.Subscription, delivery *domain.Delivery) {
+	body, err := json.Marshal(envelope{
+		ID:        delivery.EventID,
+		Type:      delivery.EventType,
+		CreatedAt: delivery.CreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		delivery.RecordAttempt(domain.DeliveryAttempt{Error: err.Error(), AttemptedAt: time.Now()}, nil)
+		return
+	}
+
+	startedAt := time.Now()
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(HeaderEventID, delivery.EventID.String())
+	header.Set(HeaderEventType, delivery.EventType)
+	header.Set(HeaderDeliveryID, delivery.ID.String())
+	header.Set(HeaderTimestamp, strconv.FormatInt(startedAt.Unix(), 10))
+	header.Set(HeaderSignature, domain.Sign(subscription.Secret, startedAt, body))
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	statusCode, err := s.sender.Send(sendCtx, Request{URL: subscription.URL, Header: header, Body: body})
+
+	attempt := domain.DeliveryAttempt{
+		StatusCode:  statusCode,
+		Duration:    time.Since(startedAt),
+		AttemptedAt: startedAt,
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	var retryAt *time.Time
+	if delivery.AttemptCount+1 < s.config.MaxAttempts {
+		next := startedAt.Add(schedule.Backoff(s.config.BaseBackoff, s.config.MaxBackoff, delivery.AttemptCount+1))
+		retryAt = &next
+	}
+
+	delivery.RecordAttempt(attempt, retryAt)
+}
@@ -0,0 +1,19 @@
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+type CreateSubscriptionInput struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+type EnqueueInput struct {
+	EventID   uuid.UUID
+	EventType string
+	Payload   json.RawMessage
+}
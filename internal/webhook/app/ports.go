@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+)
+
+type SubscriptionRepo interface {
+	Create(ctx context.Context, subscription *domain.Subscription) error
+	GetByID(ctx context.Context, id domain.SubscriptionID) (*domain.Subscription, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*domain.Subscription, error)
+	GetByEventType(ctx context.Context, eventType string) ([]*domain.Subscription, error)
+	// Delete removes the subscription together with its deliveries.
+	Delete(ctx context.Context, id domain.SubscriptionID) error
+}
+
+type DeliveryRepo interface {
+	Create(ctx context.Context, deliveries ...*domain.Delivery) error
+	GetByID(ctx context.Context, id domain.DeliveryID) (*domain.Delivery, error)
+	// GetBySubscription returns deliveries newest first, with their attempts.
+	GetBySubscription(ctx context.Context, id domain.SubscriptionID, limit, offset int) ([]*domain.Delivery, error)
+	// Claim leases up to limit pending deliveries due at now by moving their
+	// next attempt to leaseUntil, so other workers skip them until then.
+	Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.Delivery, error)
+	// RecordAttempt stores the delivery's state and its latest attempt.
+	RecordAttempt(ctx context.Context, delivery *domain.Delivery) error
+}
+
+type Request struct {
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// Sender POSTs a request and returns the response status code. An error
+// means no response was received.
+type Sender interface {
+	Send(ctx context.Context, request Request) (int, error)
+}
+
+type TxManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+)
+
+type WebhookService struct {
+	subscriptionRepo SubscriptionRepo
+	deliveryRepo     DeliveryRepo
+	sender           Sender
+	txManager        TxManager
+	config           DeliveryConfig
+}
+
+func NewWebhookService(
+	subscriptionRepo SubscriptionRepo,
+	deliveryRepo DeliveryRepo,
+	sender Sender,
+	txManager TxManager,
+	config DeliveryConfig,
+) *WebhookService {
+	return &WebhookService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		sender:           sender,
+		txManager:        txManager,
+		config:           config,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, input CreateSubscriptionInput) (*domain.Subscription, error) {
+	subscription, err := domain.NewSubscription(input.URL, input.EventTypes, input.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.subscriptionRepo.Create(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id domain.SubscriptionID) (*domain.Subscription, error) {
+	return s.subscriptionRepo.GetByID(ctx, id)
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context, limit, offset int) ([]*domain.Subscription, error) {
+	return s.subscriptionRepo.GetAll(ctx, limit, offset)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id domain.SubscriptionID) error {
+	return s.subscriptionRepo.Delete(ctx, id)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, id domain.SubscriptionID, limit, offset int) ([]*domain.Delivery, error) {
+	if _, err := s.subscriptionRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.deliveryRepo.GetBySubscription(ctx, id, limit, offset)
+}
+
+// Redeliver queues the event of a past delivery again. The original
+// delivery and its attempts are kept.
+func (s *WebhookService) Redeliver(ctx context.Context, subscriptionID domain.SubscriptionID, deliveryID domain.DeliveryID) (*domain.Delivery, error) {
+	delivery, err := s.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.SubscriptionID != subscriptionID {
+		return nil, domain.ErrDeliveryNotFound
+	}
+
+	redelivery := delivery.Redeliver()
+	err = s.deliveryRepo.Create(ctx, redelivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return redelivery, nil
+}
+
+// Enqueue creates a delivery for every subscription that wants the event.
+// It runs in its own savepoint so a failure leaves the caller's transaction
+// usable.
+func (s *WebhookService) Enqueue(ctx context.Context, input EnqueueInput) error {
+	return s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		subscriptions, err := s.subscriptionRepo.GetByEventType(txCtx, input.EventType)
+		if err != nil {
+			return err
+		}
+
+		if len(subscriptions) == 0 {
+			return nil
+		}
+
+		deliveries := make([]*domain.Delivery, 0, len(subscriptions))
+		for _, subscription := range subscriptions {
+			deliveries = append(deliveries, domain.NewDelivery(subscription.ID, input.EventID, input.EventType, input.Payload))
+		}
+
+		return s.deliveryRepo.Create(txCtx, deliveries...)
+	})
+}
@@ -0,0 +1,264 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+)
+
+type MockSubscriptionRepo struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepo) Create(ctx context.Context, subscription *domain.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepo) GetByID(ctx context.Context, id domain.SubscriptionID) (*domain.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepo) GetAll(ctx context.Context, limit, offset int) ([]*domain.Subscription, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepo) GetByEventType(ctx context.Context, eventType string) ([]*domain.Subscription, error) {
+	args := m.Called(ctx, eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepo) Delete(ctx context.Context, id domain.SubscriptionID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockDeliveryRepo struct {
+	mock.Mock
+}
+
+func (m *MockDeliveryRepo) Create(ctx context.Context, deliveries ...*domain.Delivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepo) GetByID(ctx context.Context, id domain.DeliveryID) (*domain.Delivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Delivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepo) GetBySubscription(ctx context.Context, id domain.SubscriptionID, limit, offset int) ([]*domain.Delivery, error) {
+	args := m.Called(ctx, id, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Delivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepo) Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.Delivery, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Delivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepo) RecordAttempt(ctx context.Context, delivery *domain.Delivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+type MockSender struct {
+	mock.Mock
+}
+
+func (m *MockSender) Send(ctx context.Context, request Request) (int, error) {
+	args := m.Called(ctx, request)
+	return args.Int(0), args.Error(1)
+}
+
+type MockTxManager struct{}
+
+func (m *MockTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var testDeliveryConfig = DeliveryConfig{
+	BatchSize:   10,
+	Timeout:     time.Second,
+	MaxAttempts: 3,
+	BaseBackoff: time.Second,
+	MaxBackoff:  time.Minute,
+}
+
+func newTestSubscription(t *testing.T) *domain.Subscription {
+	subscription, err := domain.NewSubscription("https://partner.example.com/hooks", []string{"order.placed"}, "")
+	assert.NoError(t, err)
+	return subscription
+}
+
+func TestWebhookService_Enqueue(t *testing.T) {
+	subscriptions := new(MockSubscriptionRepo)
+	deliveries := new(MockDeliveryRepo)
+	service := NewWebhookService(subscriptions, deliveries, new(MockSender), &MockTxManager{}, testDeliveryConfig)
+
+	first, second := newTestSubscription(t), newTestSubscription(t)
+	eventID := uuid.New()
+
+	subscriptions.On("GetByEventType", mock.Anything, "order.placed").Return([]*domain.Subscription{first, second}, nil)
+	deliveries.On("Create", mock.Anything, mock.MatchedBy(func(created []*domain.Delivery) bool {
+		return len(created) == 2 &&
+			created[0].SubscriptionID == first.ID && created[1].SubscriptionID == second.ID &&
+			created[0].EventID == eventID && created[0].Status == domain.DeliveryStatusPending
+	})).Return(nil)
+
+	err := service.Enqueue(context.Background(), EnqueueInput{
+		EventID:   eventID,
+		EventType: "order.placed",
+		Payload:   json.RawMessage(`{"order_id":"1"}`),
+	})
+
+	assert.NoError(t, err)
+	deliveries.AssertExpectations(t)
+}
+
+func TestWebhookService_Enqueue_NoSubscribers(t *testing.T) {
+	subscriptions := new(MockSubscriptionRepo)
+	deliveries := new(MockDeliveryRepo)
+	service := NewWebhookService(subscriptions, deliveries, new(MockSender), &MockTxManager{}, testDeliveryConfig)
+
+	subscriptions.On("GetByEventType", mock.Anything, "product.stock_changed").Return([]*domain.Subscription{}, nil)
+
+	err := service.Enqueue(context.Background(), EnqueueInput{EventID: uuid.New(), EventType: "product.stock_changed"})
+
+	assert.NoError(t, err)
+	deliveries.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_DeliverDue_SignsRequest(t *testing.T) {
+	subscriptions := new(MockSubscriptionRepo)
+	deliveries := new(MockDeliveryRepo)
+	sender := new(MockSender)
+	service := NewWebhookService(subscriptions, deliveries, sender, &MockTxManager{}, testDeliveryConfig)
+
+	subscription := newTestSubscription(t)
+	delivery := domain.NewDelivery(subscription.ID, uuid.New(), "order.placed", json.RawMessage(`{"order_id":"1"}`))
+
+	deliveries.On("Claim", mock.Anything, mock.Anything, mock.Anything, 10).Return([]*domain.Delivery{delivery}, nil)
+	subscriptions.On("GetByID", mock.Anything, subscription.ID).Return(subscription, nil)
+	sender.On("Send", mock.Anything, mock.MatchedBy(func(request Request) bool {
+		return request.URL == subscription.URL &&
+			request.Header.Get(HeaderEventID) == delivery.EventID.String() &&
+			request.Header.Get(HeaderDeliveryID) == delivery.ID.String() &&
+			request.Header.Get(HeaderTimestamp) != "" && request.Header.Get(HeaderSignature) != ""
+	})).Return(http.StatusOK, nil)
+	deliveries.On("RecordAttempt", mock.Anything, delivery).Return(nil)
+
+	succeeded, err := service.DeliverDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, domain.DeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, http.StatusOK, delivery.Attempts[0].StatusCode)
+}
+
+func TestWebhookService_DeliverDue_RetriesThenFails(t *testing.T) {
+	subscriptions := new(MockSubscriptionRepo)
+	deliveries := new(MockDeliveryRepo)
+	sender := new(MockSender)
+	service := NewWebhookService(subscriptions, deliveries, sender, &MockTxManager{}, testDeliveryConfig)
+
+	subscription := newTestSubscription(t)
+	delivery := domain.NewDelivery(subscription.ID, uuid.New(), "order.placed", json.RawMessage(`{}`))
+
+	deliveries.On("Claim", mock.Anything, mock.Anything, mock.Anything, 10).Return([]*domain.Delivery{delivery}, nil)
+	subscriptions.On("GetByID", mock.Anything, subscription.ID).Return(subscription, nil)
+	sender.On("Send", mock.Anything, mock.Anything).Return(0, errors.New("connection refused"))
+	deliveries.On("RecordAttempt", mock.Anything, delivery).Return(nil)
+
+	before := time.Now()
+	_, err := service.DeliverDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryStatusPending, delivery.Status)
+	assert.WithinDuration(t, before.Add(time.Second), *delivery.NextAttemptAt, time.Second)
+
+	_, _ = service.DeliverDue(context.Background())
+	assert.WithinDuration(t, time.Now().Add(2*time.Second), *delivery.NextAttemptAt, time.Second)
+
+	_, _ = service.DeliverDue(context.Background())
+	assert.Equal(t, domain.DeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.AttemptCount)
+	assert.Equal(t, "connection refused", delivery.Attempts[2].Error)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookService_DeliverDue_RecordsEachAttempt(t *testing.T) {
+	subscriptions := new(MockSubscriptionRepo)
+	deliveries := new(MockDeliveryRepo)
+	sender := new(MockSender)
+	service := NewWebhookService(subscriptions, deliveries, sender, &MockTxManager{}, testDeliveryConfig)
+
+	subscription := newTestSubscription(t)
+	first := domain.NewDelivery(subscription.ID, uuid.New(), "order.placed", json.RawMessage(`{}`))
+	second := domain.NewDelivery(subscription.ID, uuid.New(), "order.placed", json.RawMessage(`{}`))
+	orphan := domain.NewDelivery(domain.NewSubscriptionID(), uuid.New(), "order.placed", json.RawMessage(`{}`))
+
+	deliveries.On("Claim", mock.Anything, mock.Anything, mock.Anything, 10).
+		Return([]*domain.Delivery{orphan, first, second}, nil)
+	subscriptions.On("GetByID", mock.Anything, orphan.SubscriptionID).Return(nil, domain.ErrSubscriptionNotFound)
+	subscriptions.On("GetByID", mock.Anything, subscription.ID).Return(subscription, nil)
+	sender.On("Send", mock.Anything, mock.Anything).Return(http.StatusOK, nil)
+	errDown := errors.New("connection reset")
+	deliveries.On("RecordAttempt", mock.Anything, first).Return(nil)
+	deliveries.On("RecordAttempt", mock.Anything, second).Return(errDown)
+
+	succeeded, err := service.DeliverDue(context.Background())
+
+	assert.ErrorIs(t, err, errDown)
+	assert.Equal(t, 1, succeeded)
+	sender.AssertNumberOfCalls(t, "Send", 2)
+	deliveries.AssertNotCalled(t, "RecordAttempt", mock.Anything, orphan)
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	deliveries := new(MockDeliveryRepo)
+	service := NewWebhookService(new(MockSubscriptionRepo), deliveries, new(MockSender), &MockTxManager{}, testDeliveryConfig)
+
+	subscription := newTestSubscription(t)
+	delivery := domain.NewDelivery(subscription.ID, uuid.New(), "order.placed", json.RawMessage(`{}`))
+	delivery.RecordAttempt(domain.DeliveryAttempt{StatusCode: http.StatusInternalServerError, AttemptedAt: time.Now()}, nil)
+
+	deliveries.On("GetByID", mock.Anything, delivery.ID).Return(delivery, nil)
+	deliveries.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	redelivery, err := service.Redeliver(context.Background(), subscription.ID, delivery.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, delivery.EventID, redelivery.EventID)
+	assert.Equal(t, domain.DeliveryStatusPending, redelivery.Status)
+
+	_, err = service.Redeliver(context.Background(), domain.NewSubscriptionID(), delivery.ID)
+	assert.Equal(t, domain.ErrDeliveryNotFound, err)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryID uuid.UUID
+
+func NewDeliveryID() DeliveryID {
+	return DeliveryID(uuid.New())
+}
+
+func (id DeliveryID) String() string {
+	return uuid.UUID(id).String()
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is one event sent to one subscription, together with the log of
+// its attempts. EventID stays the same across redeliveries so receivers can
+// drop duplicates.
+type Delivery struct {
+	ID             DeliveryID
+	SubscriptionID SubscriptionID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Status         DeliveryStatus
+	AttemptCount   int
+	// Attempts is only loaded when deliveries are listed.
+	Attempts      []DeliveryAttempt
+	NextAttemptAt *time.Time
+	CreatedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+// DeliveryAttempt is one HTTP request of a delivery. StatusCode is zero when
+// no response was received.
+type DeliveryAttempt struct {
+	Number      int
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+func (a DeliveryAttempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= http.StatusOK && a.StatusCode < http.StatusMultipleChoices
+}
+
+func NewDelivery(subscriptionID SubscriptionID, eventID uuid.UUID, eventType string, payload json.RawMessage) *Delivery {
+	now := time.Now()
+	return &Delivery{
+		ID:             NewDeliveryID(),
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         DeliveryStatusPending,
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+	}
+}
+
+// RecordAttempt logs an attempt and moves the delivery on. A failed attempt
+// is retried at retryAt; without one the delivery has failed for good.
+func (d *Delivery) RecordAttempt(attempt DeliveryAttempt, retryAt *time.Time) {
+	d.AttemptCount++
+	attempt.Number = d.AttemptCount
+	d.Attempts = append(d.Attempts, attempt)
+
+	switch {
+	case attempt.Succeeded():
+		d.Status = DeliveryStatusSucceeded
+	case retryAt != nil:
+		d.NextAttemptAt = retryAt
+		return
+	default:
+		d.Status = DeliveryStatusFailed
+	}
+
+	d.NextAttemptAt = nil
+	d.CompletedAt = &attempt.AttemptedAt
+}
+
+// Redeliver returns a new pending delivery of the same event.
+func (d *Delivery) Redeliver() *Delivery {
+	return NewDelivery(d.SubscriptionID, d.EventID, d.EventType, d.Payload)
+}
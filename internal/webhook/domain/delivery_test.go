@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDelivery() *Delivery {
+	return NewDelivery(NewSubscriptionID(), uuid.New(), "order.placed", json.RawMessage(`{}`))
+}
+
+func TestDelivery_RecordAttempt_Succeeded(t *testing.T) {
+	delivery := newTestDelivery()
+	at := time.Now()
+
+	delivery.RecordAttempt(DeliveryAttempt{StatusCode: http.StatusNoContent, AttemptedAt: at}, nil)
+
+	assert.Equal(t, DeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.AttemptCount)
+	assert.Equal(t, 1, delivery.Attempts[0].Number)
+	assert.Nil(t, delivery.NextAttemptAt)
+	assert.Equal(t, &at, delivery.CompletedAt)
+}
+
+func TestDelivery_RecordAttempt_Retried(t *testing.T) {
+	delivery := newTestDelivery()
+	retryAt := time.Now().Add(time.Minute)
+
+	delivery.RecordAttempt(DeliveryAttempt{StatusCode: http.StatusBadGateway, AttemptedAt: time.Now()}, &retryAt)
+
+	assert.Equal(t, DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, &retryAt, delivery.NextAttemptAt)
+	assert.Nil(t, delivery.CompletedAt)
+}
+
+func TestDelivery_RecordAttempt_GivesUp(t *testing.T) {
+	delivery := newTestDelivery()
+
+	delivery.RecordAttempt(DeliveryAttempt{Error: "connection refused", AttemptedAt: time.Now()}, nil)
+
+	assert.Equal(t, DeliveryStatusFailed, delivery.Status)
+	assert.Nil(t, delivery.NextAttemptAt)
+	assert.NotNil(t, delivery.CompletedAt)
+}
+
+func TestDelivery_Redeliver(t *testing.T) {
+	delivery := newTestDelivery()
+	delivery.RecordAttempt(DeliveryAttempt{Error: "timeout", AttemptedAt: time.Now()}, nil)
+
+	redelivery := delivery.Redeliver()
+
+	assert.NotEqual(t, delivery.ID, redelivery.ID)
+	assert.Equal(t, delivery.EventID, redelivery.EventID)
+	assert.Equal(t, DeliveryStatusPending, redelivery.Status)
+	assert.Zero(t, redelivery.AttemptCount)
+}
+
+func TestNewSubscription(t *testing.T) {
+	subscription, err := NewSubscription("https://partner.example.com/hooks", []string{"order.placed", "order.placed"}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"order.placed"}, subscription.EventTypes)
+	assert.NotEmpty(t, subscription.Secret)
+
+	_, err = NewSubscription("ftp://partner.example.com", []string{"order.placed"}, "")
+	assert.Equal(t, ErrInvalidWebhookURL, err)
+
+	_, err = NewSubscription("https://partner.example.com", nil, "")
+	assert.Equal(t, ErrNoEventTypes, err)
+
+	_, err = NewSubscription("https://partner.example.com", []string{"order.shipped"}, "")
+	assert.ErrorIs(t, err, ErrUnknownEventType)
+
+	_, err = NewSubscription("https://partner.example.com", []string{"order.placed"}, "short")
+	assert.Equal(t, ErrSecretTooShort, err)
+}
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	body := []byte(`{"order_id":"1"}`)
+
+	signature := Sign("secret", at, body)
+
+	assert.True(t, VerifySignature("secret", at, body, signature))
+	assert.False(t, VerifySignature("other", at, body, signature))
+	assert.False(t, VerifySignature("secret", at.Add(time.Second), body, signature))
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "github.com/BlackRRR/Irtea-test/pkg/errcode"
+
+var (
+	ErrSubscriptionNotFound = errcode.New("webhook-not-found", "webhook subscription not found")
+	ErrDeliveryNotFound     = errcode.New("delivery-not-found", "webhook delivery not found")
+	ErrInvalidWebhookURL    = errcode.New("invalid-webhook-url", "webhook url must be an absolute http or https url")
+	ErrNoEventTypes         = errcode.New("no-event-types", "webhook must subscribe to at least one event type")
+	ErrUnknownEventType     = errcode.New("unknown-event-type", "unknown webhook event type")
+	ErrSecretTooShort       = errcode.New("secret-too-short", "webhook secret must be at least 16 characters")
+)
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const minSecretLength = 16
+
+// EventTypes lists the events partners can subscribe to.
+var EventTypes = []string{
+	"order.placed",
+	"order.confirmed",
+	"order.cancelled",
+	"product.price_changed",
+	"product.stock_changed",
+}
+
+type SubscriptionID uuid.UUID
+
+func NewSubscriptionID() SubscriptionID {
+	return SubscriptionID(uuid.New())
+}
+
+func (id SubscriptionID) String() string {
+	return uuid.UUID(id).String()
+}
+
+// Subscription is a partner endpoint that receives the listed events. Every
+// delivery is signed with Secret.
+type Subscription struct {
+	ID         SubscriptionID
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewSubscription validates the endpoint and event types. An empty secret is
+// replaced by a random one.
+func NewSubscription(rawURL string, eventTypes []string, secret string) (*Subscription, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	cleanedTypes := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !slices.Contains(EventTypes, eventType) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+		}
+		if !slices.Contains(cleanedTypes, eventType) {
+			cleanedTypes = append(cleanedTypes, eventType)
+		}
+	}
+
+	if len(cleanedTypes) == 0 {
+		return nil, ErrNoEventTypes
+	}
+
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return nil, err
+		}
+	} else if len(secret) < minSecretLength {
+		return nil, ErrSecretTooShort
+	}
+
+	now := time.Now()
+	return &Subscription{
+		ID:         NewSubscriptionID(),
+		URL:        rawURL,
+		EventTypes: cleanedTypes,
+		Secret:     secret,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+func (s *Subscription) Wants(eventType string) bool {
+	return slices.Contains(s.EventTypes, eventType)
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
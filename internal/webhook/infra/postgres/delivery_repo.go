@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+	wService "github.com/BlackRRR/Irtea-test/internal/webhook/app"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+)
+
+var _ wService.DeliveryRepo = (*DeliveryRepo)(nil)
+
+type DeliveryRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewDeliveryRepo(pool *pgxpool.Pool) *DeliveryRepo {
+	return &DeliveryRepo{pool: pool}
+}
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempt_count,
+	next_attempt_at, created_at, completed_at`
+
+func (r *DeliveryRepo) Create(ctx context.Context, deliveries ...*domain.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(deliveries))
+	subscriptionIDs := make([]string, 0, len(deliveries))
+	eventIDs := make([]string, 0, len(deliveries))
+	eventTypes := make([]string, 0, len(deliveries))
+	payloads := make([]string, 0, len(deliveries))
+	nextAttemptAts := make([]*time.Time, 0, len(deliveries))
+	createdAts := make([]time.Time, 0, len(deliveries))
+
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.ID.String())
+		subscriptionIDs = append(subscriptionIDs, delivery.SubscriptionID.String())
+		eventIDs = append(eventIDs, delivery.EventID.String())
+		eventTypes = append(eventTypes, delivery.EventType)
+		payloads = append(payloads, string(delivery.Payload))
+		nextAttemptAts = append(nextAttemptAts, delivery.NextAttemptAt)
+		createdAts = append(createdAts, delivery.CreatedAt)
+	}
+
+	query := `
+		INSERT INTO webhooks.delivery (id, subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		SELECT id, subscription_id, event_id, event_type, payload, $6, next_attempt_at, created_at
+		FROM UNNEST($1::uuid[], $2::uuid[], $3::uuid[], $4::varchar[], $5::jsonb[], $7::timestamptz[], $8::timestamptz[])
+		    AS d(id, subscription_id, event_id, event_type, payload, next_attempt_at, created_at)
+	`
+
+	q := postgres.GetQuerier(ctx, r.pool)
+	_, err := q.Exec(ctx, query,
+		ids, subscriptionIDs, eventIDs, eventTypes, payloads, string(domain.DeliveryStatusPending), nextAttemptAts, createdAts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepo) GetByID(ctx context.Context, id domain.DeliveryID) (*domain.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhooks.delivery WHERE id = $1`
+
+	q := postgres.GetQuerier(ctx, r.pool)
+
+	var deliveryDB DeliveryDB
+	err := scanDelivery(q.QueryRow(ctx, query, id.String()), &deliveryDB)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return deliveryDB.ToDomain()
+}
+
+func (r *DeliveryRepo) GetBySubscription(ctx context.Context, id domain.SubscriptionID, limit, offset int) ([]*domain.Delivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhooks.delivery
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	deliveries, err := r.query(ctx, query, id.String(), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	byID := make(map[string]*domain.Delivery, len(deliveries))
+	ids := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		byID[delivery.ID.String()] = delivery
+		ids = append(ids, delivery.ID.String())
+	}
+
+	attemptsQuery := `
+		SELECT delivery_id, number, status_code, error, duration_ms, attempted_at
+		FROM webhooks.delivery_attempt
+		WHERE delivery_id = ANY($1)
+		ORDER BY delivery_id, number
+	`
+
+	q := postgres.GetQuerier(ctx, r.pool)
+	rows, err := q.Query(ctx, attemptsQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery attempts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attemptDB DeliveryAttemptDB
+		err := rows.Scan(
+			&attemptDB.DeliveryID,
+			&attemptDB.Number,
+			&attemptDB.StatusCode,
+			&attemptDB.Error,
+			&attemptDB.DurationMs,
+			&attemptDB.AttemptedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery attempt: %w", err)
+		}
+
+		delivery := byID[attemptDB.DeliveryID]
+		delivery.Attempts = append(delivery.Attempts, attemptDB.ToDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Claim is a single statement, so the rows are only locked while it runs. A
+// worker that dies mid-batch leaves its deliveries to be claimed again once
+// the lease has passed.
+func (r *DeliveryRepo) Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.Delivery, error) {
+	query := `
+		UPDATE webhooks.delivery
+		SET next_attempt_at = $3
+		WHERE id IN (
+			SELECT id
+			FROM webhooks.delivery
+			WHERE status = $1 AND next_attempt_at <= $2
+			ORDER BY next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns + `
+	`
+
+	return r.query(ctx, query, string(domain.DeliveryStatusPending), now, leaseUntil, limit)
+}
+
+func (r *DeliveryRepo) RecordAttempt(ctx context.Context, delivery *domain.Delivery) error {
+	if len(delivery.Attempts) == 0 {
+		return errors.New("webhook delivery has no attempt to record")
+	}
+
+	q := postgres.GetQuerier(ctx, r.pool)
+
+	updateQuery := `
+		UPDATE webhooks.delivery
+		SET status = $2, attempt_count = $3, next_attempt_at = $4, completed_at = $5
+		WHERE id = $1
+	`
+
+	_, err := q.Exec(ctx, updateQuery,
+		delivery.ID.String(),
+		string(delivery.Status),
+		delivery.AttemptCount,
+		delivery.NextAttemptAt,
+		delivery.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	attemptDB := NewDeliveryAttemptDB(delivery.ID, delivery.Attempts[len(delivery.Attempts)-1])
+
+	insertQuery := `
+		INSERT INTO webhooks.delivery_attempt (delivery_id, number, status_code, error, duration_ms, attempted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = q.Exec(ctx, insertQuery,
+		attemptDB.DeliveryID,
+		attemptDB.Number,
+		attemptDB.StatusCode,
+		attemptDB.Error,
+		attemptDB.DurationMs,
+		attemptDB.AttemptedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepo) query(ctx context.Context, query string, args ...any) ([]*domain.Delivery, error) {
+	q := postgres.GetQuerier(ctx, r.pool)
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*domain.Delivery, 0)
+	for rows.Next() {
+		var deliveryDB DeliveryDB
+		if err := scanDelivery(rows, &deliveryDB); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+
+		delivery, err := deliveryDB.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func scanDelivery(row pgx.Row, deliveryDB *DeliveryDB) error {
+	return row.Scan(
+		&deliveryDB.ID,
+		&deliveryDB.SubscriptionID,
+		&deliveryDB.EventID,
+		&deliveryDB.EventType,
+		&deliveryDB.Payload,
+		&deliveryDB.Status,
+		&deliveryDB.AttemptCount,
+		&deliveryDB.NextAttemptAt,
+		&deliveryDB.CreatedAt,
+		&deliveryDB.CompletedAt,
+	)
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+)
+
+type SubscriptionDB struct {
+	ID         string    `db:"id"`
+	URL        string    `db:"url"`
+	EventTypes []string  `db:"event_types"`
+	Secret     string    `db:"secret"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+func (s *SubscriptionDB) ToDomain() (*domain.Subscription, error) {
+	id, err := uuid.Parse(s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Subscription{
+		ID:         domain.SubscriptionID(id),
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		Secret:     s.Secret,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}, nil
+}
+
+type DeliveryDB struct {
+	ID             string     `db:"id"`
+	SubscriptionID string     `db:"subscription_id"`
+	EventID        string     `db:"event_id"`
+	EventType      string     `db:"event_type"`
+	Payload        []byte     `db:"payload"`
+	Status         string     `db:"status"`
+	AttemptCount   int        `db:"attempt_count"`
+	NextAttemptAt  *time.Time `db:"next_attempt_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	CompletedAt    *time.Time `db:"completed_at"`
+}
+
+func (d *DeliveryDB) ToDomain() (*domain.Delivery, error) {
+	id, err := uuid.Parse(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionID, err := uuid.Parse(d.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := uuid.Parse(d.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Delivery{
+		ID:             domain.DeliveryID(id),
+		SubscriptionID: domain.SubscriptionID(subscriptionID),
+		EventID:        eventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         domain.DeliveryStatus(d.Status),
+		AttemptCount:   d.AttemptCount,
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+		CompletedAt:    d.CompletedAt,
+	}, nil
+}
+
+type DeliveryAttemptDB struct {
+	DeliveryID  string    `db:"delivery_id"`
+	Number      int       `db:"number"`
+	StatusCode  *int      `db:"status_code"`
+	Error       *string   `db:"error"`
+	DurationMs  int64     `db:"duration_ms"`
+	AttemptedAt time.Time `db:"attempted_at"`
+}
+
+func NewDeliveryAttemptDB(deliveryID domain.DeliveryID, attempt domain.DeliveryAttempt) DeliveryAttemptDB {
+	attemptDB := DeliveryAttemptDB{
+		DeliveryID:  deliveryID.String(),
+		Number:      attempt.Number,
+		DurationMs:  attempt.Duration.Milliseconds(),
+		AttemptedAt: attempt.AttemptedAt,
+	}
+
+	if attempt.StatusCode != 0 {
+		attemptDB.StatusCode = &attempt.StatusCode
+	}
+
+	if attempt.Error != "" {
+		attemptDB.Error = &attempt.Error
+	}
+
+	return attemptDB
+}
+
+func (a *DeliveryAttemptDB) ToDomain() domain.DeliveryAttempt {
+	attempt := domain.DeliveryAttempt{
+		Number:      a.Number,
+		Duration:    time.Duration(a.DurationMs) * time.Millisecond,
+		AttemptedAt: a.AttemptedAt,
+	}
+
+	if a.StatusCode != nil {
+		attempt.StatusCode = *a.StatusCode
+	}
+
+	if a.Error != nil {
+		attempt.Error = *a.Error
+	}
+
+	return attempt
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/BlackRRR/Irtea-test/infrastructure/postgres"
+	wService "github.com/BlackRRR/Irtea-test/internal/webhook/app"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+)
+
+var _ wService.SubscriptionRepo = (*SubscriptionRepo)(nil)
+
+type SubscriptionRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSubscriptionRepo(pool *pgxpool.Pool) *SubscriptionRepo {
+	return &SubscriptionRepo{pool: pool}
+}
+
+const subscriptionColumns = `id, url, event_types, secret, created_at, updated_at`
+
+func (r *SubscriptionRepo) Create(ctx context.Context, subscription *domain.Subscription) error {
+	query := `
+		INSERT INTO webhooks.subscription (id, url, event_types, secret, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	q := postgres.GetQuerier(ctx, r.pool)
+	_, err := q.Exec(ctx, query,
+		subscription.ID.String(),
+		subscription.URL,
+		subscription.EventTypes,
+		subscription.Secret,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepo) GetByID(ctx context.Context, id domain.SubscriptionID) (*domain.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhooks.subscription WHERE id = $1`
+
+	q := postgres.GetQuerier(ctx, r.pool)
+
+	var subscriptionDB SubscriptionDB
+	err := q.QueryRow(ctx, query, id.String()).Scan(
+		&subscriptionDB.ID,
+		&subscriptionDB.URL,
+		&subscriptionDB.EventTypes,
+		&subscriptionDB.Secret,
+		&subscriptionDB.CreatedAt,
+		&subscriptionDB.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return subscriptionDB.ToDomain()
+}
+
+func (r *SubscriptionRepo) GetAll(ctx context.Context, limit, offset int) ([]*domain.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhooks.subscription
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	return r.query(ctx, query, limit, offset)
+}
+
+func (r *SubscriptionRepo) GetByEventType(ctx context.Context, eventType string) ([]*domain.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhooks.subscription
+		WHERE event_types @> ARRAY[$1::varchar]
+		ORDER BY created_at
+	`
+
+	return r.query(ctx, query, eventType)
+}
+
+func (r *SubscriptionRepo) Delete(ctx context.Context, id domain.SubscriptionID) error {
+	query := `DELETE FROM webhooks.subscription WHERE id = $1`
+
+	q := postgres.GetQuerier(ctx, r.pool)
+	result, err := q.Exec(ctx, query, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepo) query(ctx context.Context, query string, args ...any) ([]*domain.Subscription, error) {
+	q := postgres.GetQuerier(ctx, r.pool)
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*domain.Subscription, 0)
+	for rows.Next() {
+		var subscriptionDB SubscriptionDB
+		err := rows.Scan(
+			&subscriptionDB.ID,
+			&subscriptionDB.URL,
+			&subscriptionDB.EventTypes,
+			&subscriptionDB.Secret,
+			&subscriptionDB.CreatedAt,
+			&subscriptionDB.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+
+		subscription, err := subscriptionDB.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,184 @@
+package sender
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	wService "github.com/BlackRRR/Irtea-test/internal/webhook/app"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+)
+
+// memoryStore keeps a single subscription.
+type memoryStore struct {
+	subscription *domain.Subscription
+}
+
+func (s *memoryStore) Create(ctx context.Context, subscription *domain.Subscription) error {
+	s.subscription = subscription
+	return nil
+}
+
+func (s *memoryStore) GetByID(ctx context.Context, id domain.SubscriptionID) (*domain.Subscription, error) {
+	return s.subscription, nil
+}
+
+func (s *memoryStore) GetAll(ctx context.Context, limit, offset int) ([]*domain.Subscription, error) {
+	return []*domain.Subscription{s.subscription}, nil
+}
+
+func (s *memoryStore) GetByEventType(ctx context.Context, eventType string) ([]*domain.Subscription, error) {
+	if !s.subscription.Wants(eventType) {
+		return nil, nil
+	}
+	return []*domain.Subscription{s.subscription}, nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, id domain.SubscriptionID) error {
+	return nil
+}
+
+type memoryDeliveries struct {
+	deliveries []*domain.Delivery
+}
+
+func (r *memoryDeliveries) Create(ctx context.Context, deliveries ...*domain.Delivery) error {
+	r.deliveries = append(r.deliveries, deliveries...)
+	return nil
+}
+
+func (r *memoryDeliveries) GetByID(ctx context.Context, id domain.DeliveryID) (*domain.Delivery, error) {
+	for _, delivery := range r.deliveries {
+		if delivery.ID == id {
+			return delivery, nil
+		}
+	}
+	return nil, domain.ErrDeliveryNotFound
+}
+
+func (r *memoryDeliveries) GetBySubscription(ctx context.Context, id domain.SubscriptionID, limit, offset int) ([]*domain.Delivery, error) {
+	return r.deliveries, nil
+}
+
+func (r *memoryDeliveries) Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.Delivery, error) {
+	var due []*domain.Delivery
+	for _, delivery := range r.deliveries {
+		if len(due) < limit && delivery.NextAttemptAt != nil && !delivery.NextAttemptAt.After(now) {
+			delivery.NextAttemptAt = &leaseUntil
+			due = append(due, delivery)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryDeliveries) RecordAttempt(ctx context.Context, delivery *domain.Delivery) error {
+	return nil
+}
+
+type noTx struct{}
+
+func (noTx) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestHTTPSender_DeliversSignedWebhook(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan received, 2)
+	statuses := []int{http.StatusServiceUnavailable, http.StatusOK}
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header, body: body}
+		w.WriteHeader(statuses[0])
+		statuses = statuses[1:]
+	}))
+	defer receiver.Close()
+
+	subscriptions := &memoryStore{}
+	deliveries := &memoryDeliveries{}
+	service := wService.NewWebhookService(subscriptions, deliveries, NewHTTPSender(time.Second), noTx{}, wService.DeliveryConfig{
+		BatchSize:   10,
+		Timeout:     time.Second,
+		MaxAttempts: 3,
+		BaseBackoff: time.Nanosecond,
+		MaxBackoff:  time.Nanosecond,
+	})
+
+	subscription, err := service.CreateSubscription(context.Background(), wService.CreateSubscriptionInput{
+		URL:        receiver.URL,
+		EventTypes: []string{"order.placed"},
+	})
+	require.NoError(t, err)
+
+	eventID := uuid.New()
+	require.NoError(t, service.Enqueue(context.Background(), wService.EnqueueInput{
+		EventID:   eventID,
+		EventType: "order.placed",
+		Payload:   json.RawMessage(`{"order_id":"42"}`),
+	}))
+
+	// The first attempt gets a 503 and is retried on the next run.
+	succeeded, err := service.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, succeeded)
+	<-requests
+
+	time.Sleep(time.Millisecond)
+	succeeded, err = service.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, succeeded)
+
+	request := <-requests
+	unix, err := strconv.ParseInt(request.header.Get(wService.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, domain.VerifySignature(subscription.Secret, time.Unix(unix, 0), request.body, request.header.Get(wService.HeaderSignature)))
+	assert.Equal(t, eventID.String(), request.header.Get(wService.HeaderEventID))
+	assert.Equal(t, "order.placed", request.header.Get(wService.HeaderEventType))
+
+	var envelope struct {
+		ID   string          `json:"id"`
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(request.body, &envelope))
+	assert.Equal(t, eventID.String(), envelope.ID)
+	assert.JSONEq(t, `{"order_id":"42"}`, string(envelope.Data))
+
+	delivery := deliveries.deliveries[0]
+	assert.Equal(t, domain.DeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, []int{http.StatusServiceUnavailable, http.StatusOK},
+		[]int{delivery.Attempts[0].StatusCode, delivery.Attempts[1].StatusCode})
+}
+
+func TestHTTPSender_DoesNotFollowRedirects(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer receiver.Close()
+
+	statusCode, err := NewHTTPSender(time.Second).Send(context.Background(), wService.Request{URL: receiver.URL, Header: http.Header{}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, statusCode)
+}
+
+func TestHTTPSender_ConnectionError(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	receiver.Close()
+
+	statusCode, err := NewHTTPSender(time.Second).Send(context.Background(), wService.Request{URL: receiver.URL, Header: http.Header{}})
+
+	assert.Error(t, err)
+	assert.Zero(t, statusCode)
+}
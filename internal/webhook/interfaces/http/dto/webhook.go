@@ -0,0 +1,50 @@
+package dto
+
+type CreateSubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,required"`
+	// Secret signs deliveries; one is generated when it is empty.
+	Secret string `json:"secret"`
+}
+
+type SubscriptionResponse struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// Secret is only returned when the subscription is created.
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type SubscriptionListResponse struct {
+	Webhooks []SubscriptionResponse `json:"webhooks"`
+	Limit    int                    `json:"limit"`
+	Offset   int                    `json:"offset"`
+}
+
+type DeliveryAttemptResponse struct {
+	Number      int    `json:"number"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	Error       string `json:"error,omitempty"`
+	DurationMs  int64  `json:"duration_ms"`
+	AttemptedAt string `json:"attempted_at"`
+}
+
+type DeliveryResponse struct {
+	ID            string                    `json:"id"`
+	EventID       string                    `json:"event_id"`
+	EventType     string                    `json:"event_type"`
+	Status        string                    `json:"status"`
+	AttemptCount  int                       `json:"attempt_count"`
+	Attempts      []DeliveryAttemptResponse `json:"attempts"`
+	NextAttemptAt string                    `json:"next_attempt_at,omitempty"`
+	CreatedAt     string                    `json:"created_at"`
+	CompletedAt   string                    `json:"completed_at,omitempty"`
+}
+
+type DeliveryListResponse struct {
+	Deliveries []DeliveryResponse `json:"deliveries"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+}
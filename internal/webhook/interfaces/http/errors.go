@@ -0,0 +1,8 @@
+package http
+
+import "github.com/BlackRRR/Irtea-test/pkg/errcode"
+
+var (
+	ErrInvalidWebhookID  = errcode.New("invalid-webhook-id", "invalid webhook ID format")
+	ErrInvalidDeliveryID = errcode.New("invalid-delivery-id", "invalid delivery ID format")
+)
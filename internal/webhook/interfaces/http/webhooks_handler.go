@@ -0,0 +1,212 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/app"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/domain"
+	"github.com/BlackRRR/Irtea-test/internal/webhook/interfaces/http/dto"
+	"github.com/BlackRRR/Irtea-test/pkg/consts"
+	"github.com/BlackRRR/Irtea-test/pkg/validator"
+)
+
+type WebhooksHandler struct {
+	webhookService *app.WebhookService
+}
+
+func NewWebhooksHandler(webhookService *app.WebhookService) *WebhooksHandler {
+	return &WebhooksHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhooksHandler) CreateSubscription(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	var req dto.CreateSubscriptionRequest
+	if err := validator.ReadRequest(c, &req); err != nil {
+		return err
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(ctx, app.CreateSubscriptionInput{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		return err
+	}
+
+	response := h.mapSubscriptionToResponse(subscription)
+	response.Secret = subscription.Secret
+	return c.Status(http.StatusCreated).JSON(response)
+}
+
+func (h *WebhooksHandler) GetSubscriptions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	limit, offset := h.parsePagination(c)
+
+	subscriptions, err := h.webhookService.GetSubscriptions(ctx, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.SubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, h.mapSubscriptionToResponse(subscription))
+	}
+
+	return c.JSON(dto.SubscriptionListResponse{
+		Webhooks: responses,
+		Limit:    limit,
+		Offset:   offset,
+	})
+}
+
+func (h *WebhooksHandler) GetSubscription(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	subscriptionID, err := h.parseSubscriptionID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidWebhookID
+	}
+
+	subscription, err := h.webhookService.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(h.mapSubscriptionToResponse(subscription))
+}
+
+func (h *WebhooksHandler) DeleteSubscription(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	subscriptionID, err := h.parseSubscriptionID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidWebhookID
+	}
+
+	if err := h.webhookService.DeleteSubscription(ctx, subscriptionID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func (h *WebhooksHandler) GetDeliveries(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	subscriptionID, err := h.parseSubscriptionID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidWebhookID
+	}
+
+	limit, offset := h.parsePagination(c)
+
+	deliveries, err := h.webhookService.GetDeliveries(ctx, subscriptionID, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.DeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, h.mapDeliveryToResponse(delivery))
+	}
+
+	return c.JSON(dto.DeliveryListResponse{
+		Deliveries: responses,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+func (h *WebhooksHandler) Redeliver(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	subscriptionID, err := h.parseSubscriptionID(c.Params("id"))
+	if err != nil {
+		return ErrInvalidWebhookID
+	}
+
+	deliveryID, err := uuid.Parse(c.Params("deliveryId"))
+	if err != nil {
+		return ErrInvalidDeliveryID
+	}
+
+	delivery, err := h.webhookService.Redeliver(ctx, subscriptionID, domain.DeliveryID(deliveryID))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusAccepted).JSON(h.mapDeliveryToResponse(delivery))
+}
+
+func (h *WebhooksHandler) mapSubscriptionToResponse(subscription *domain.Subscription) dto.SubscriptionResponse {
+	return dto.SubscriptionResponse{
+		ID:         subscription.ID.String(),
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt.Format(consts.FormatTimeLayout),
+		UpdatedAt:  subscription.UpdatedAt.Format(consts.FormatTimeLayout),
+	}
+}
+
+func (h *WebhooksHandler) mapDeliveryToResponse(delivery *domain.Delivery) dto.DeliveryResponse {
+	attempts := make([]dto.DeliveryAttemptResponse, 0, len(delivery.Attempts))
+	for _, attempt := range delivery.Attempts {
+		attempts = append(attempts, dto.DeliveryAttemptResponse{
+			Number:      attempt.Number,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  attempt.Duration.Milliseconds(),
+			AttemptedAt: attempt.AttemptedAt.Format(consts.FormatTimeLayout),
+		})
+	}
+
+	response := dto.DeliveryResponse{
+		ID:           delivery.ID.String(),
+		EventID:      delivery.EventID.String(),
+		EventType:    delivery.EventType,
+		Status:       string(delivery.Status),
+		AttemptCount: delivery.AttemptCount,
+		Attempts:     attempts,
+		CreatedAt:    delivery.CreatedAt.Format(consts.FormatTimeLayout),
+	}
+
+	if delivery.NextAttemptAt != nil {
+		response.NextAttemptAt = delivery.NextAttemptAt.Format(consts.FormatTimeLayout)
+	}
+
+	if delivery.CompletedAt != nil {
+		response.CompletedAt = delivery.CompletedAt.Format(consts.FormatTimeLayout)
+	}
+
+	return response
+}
+
+func (h *WebhooksHandler) parsePagination(c *fiber.Ctx) (int, int) {
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
+
+func (h *WebhooksHandler) parseSubscriptionID(s string) (domain.SubscriptionID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return domain.SubscriptionID{}, err
+	}
+	return domain.SubscriptionID(id), err
+}
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products.product
+    ADD COLUMN options JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE IF NOT EXISTS products.variant
+(
+    id         UUID PRIMARY KEY,
+    product_id UUID                     NOT NULL,
+    sku        VARCHAR(100)             NOT NULL CHECK (LENGTH(TRIM(sku)) > 0),
+    barcode    VARCHAR(100)             NOT NULL DEFAULT '',
+    options    JSONB                    NOT NULL DEFAULT '{}',
+    price      numeric                  NOT NULL CHECK (price >= 0),
+    quantity   INTEGER                  NOT NULL CHECK (quantity >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_variant_sku UNIQUE (sku),
+    CONSTRAINT fk_variant_product_id FOREIGN KEY (product_id) REFERENCES products.product (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_variant_product_id ON products.variant (product_id);
+CREATE INDEX idx_variant_barcode ON products.variant (barcode);
+
+ALTER TABLE orders.order_items
+    ADD COLUMN variant_id      UUID,
+    ADD COLUMN variant_sku     VARCHAR(100),
+    ADD COLUMN variant_options JSONB,
+    ADD CONSTRAINT fk_order_items_variant_id FOREIGN KEY (variant_id) REFERENCES products.variant (id) ON DELETE RESTRICT;
+
+DROP INDEX IF EXISTS orders.idx_order_items_order_product;
+CREATE UNIQUE INDEX idx_order_items_order_product ON orders.order_items
+    (order_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid));
+CREATE INDEX idx_order_items_variant_id ON orders.order_items (variant_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS orders.idx_order_items_variant_id;
+DROP INDEX IF EXISTS orders.idx_order_items_order_product;
+CREATE UNIQUE INDEX idx_order_items_order_product ON orders.order_items (order_id, product_id);
+
+ALTER TABLE orders.order_items
+    DROP CONSTRAINT IF EXISTS fk_order_items_variant_id,
+    DROP COLUMN IF EXISTS variant_options,
+    DROP COLUMN IF EXISTS variant_sku,
+    DROP COLUMN IF EXISTS variant_id;
+
+DROP TABLE IF EXISTS products.variant;
+
+ALTER TABLE products.product
+    DROP COLUMN IF EXISTS options;
+-- +goose StatementEnd
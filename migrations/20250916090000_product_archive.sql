@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products.product
+    ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_products_archived_at ON products.product (archived_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS products.idx_products_archived_at;
+
+ALTER TABLE products.product
+    DROP COLUMN IF EXISTS archived_at;
+-- +goose StatementEnd
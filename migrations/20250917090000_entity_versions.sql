@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products.product
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1 CHECK (version > 0);
+
+ALTER TABLE orders.order
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1 CHECK (version > 0);
+
+ALTER TABLE users.user
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1 CHECK (version > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users.user
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE orders.order
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE products.product
+    DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
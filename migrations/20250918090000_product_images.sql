@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS products.image
+(
+    id            UUID PRIMARY KEY,
+    product_id    UUID                     NOT NULL,
+    storage_key   VARCHAR(512)             NOT NULL,
+    thumbnail_key VARCHAR(512)             NOT NULL,
+    content_type  VARCHAR(100)             NOT NULL,
+    size_bytes    BIGINT                   NOT NULL CHECK (size_bytes > 0),
+    width         INTEGER                  NOT NULL CHECK (width > 0),
+    height        INTEGER                  NOT NULL CHECK (height > 0),
+    position      INTEGER                  NOT NULL CHECK (position >= 0),
+    is_primary    BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_image_product_id FOREIGN KEY (product_id) REFERENCES products.product (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_image_product_id ON products.image (product_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS products.image;
+-- +goose StatementEnd
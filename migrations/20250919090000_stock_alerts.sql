@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products.product
+    ADD COLUMN reorder_threshold INTEGER CHECK (reorder_threshold >= 0);
+
+CREATE INDEX idx_product_low_stock ON products.product (quantity)
+    WHERE reorder_threshold IS NOT NULL AND archived_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS products.stock_alert
+(
+    id          UUID PRIMARY KEY,
+    product_id  UUID                     NOT NULL,
+    description TEXT                     NOT NULL,
+    threshold   INTEGER                  NOT NULL CHECK (threshold >= 0),
+    quantity    INTEGER                  NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_stock_alert_product_id FOREIGN KEY (product_id) REFERENCES products.product (id) ON DELETE CASCADE
+);
+
+-- At most one open alert per product.
+CREATE UNIQUE INDEX idx_stock_alert_open ON products.stock_alert (product_id) WHERE resolved_at IS NULL;
+CREATE INDEX idx_stock_alert_created_at ON products.stock_alert (created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS products.stock_alert;
+
+DROP INDEX IF EXISTS products.idx_product_low_stock;
+
+ALTER TABLE products.product
+    DROP COLUMN IF EXISTS reorder_threshold;
+-- +goose StatementEnd
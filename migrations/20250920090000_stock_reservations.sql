@@ -0,0 +1,90 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products.product
+    ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT check_product_reserved CHECK (reserved >= 0 AND reserved <= quantity);
+
+ALTER TABLE products.variant
+    ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT check_variant_reserved CHECK (reserved >= 0 AND reserved <= quantity);
+
+DROP INDEX IF EXISTS products.idx_product_low_stock;
+CREATE INDEX idx_product_low_stock ON products.product ((quantity - reserved))
+    WHERE reorder_threshold IS NOT NULL AND archived_at IS NULL;
+
+-- order_id has no foreign key: reservations are taken before the order row
+-- is written, and orders belong to another context.
+CREATE TABLE IF NOT EXISTS products.stock_reservation
+(
+    id         UUID PRIMARY KEY,
+    order_id   UUID                     NOT NULL,
+    product_id UUID                     NOT NULL,
+    variant_id UUID,
+    quantity   INTEGER                  NOT NULL CHECK (quantity > 0),
+    status     VARCHAR(20)              NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'committed', 'released', 'expired', 'returned')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_stock_reservation_product_id FOREIGN KEY (product_id) REFERENCES products.product (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_stock_reservation_variant_id FOREIGN KEY (variant_id) REFERENCES products.variant (id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_stock_reservation_order_id ON products.stock_reservation (order_id);
+CREATE INDEX idx_stock_reservation_expires_at ON products.stock_reservation (expires_at) WHERE status = 'active';
+
+-- Orders placed before reservations existed took their stock off hand right
+-- away. Pending ones get it back as an active reservation, so confirming
+-- commits it and cancelling releases it, and confirmed ones get a committed
+-- reservation, so cancelling returns their units. Backfilled reservations run
+-- for the default TTL from now.
+UPDATE products.product p
+SET quantity = p.quantity + held.quantity,
+    reserved = held.quantity
+FROM (SELECT i.product_id, SUM(i.quantity) AS quantity
+      FROM orders.order_items i
+               JOIN orders.order o ON o.id = i.order_id
+      WHERE o.status = 'pending' AND i.variant_id IS NULL
+      GROUP BY i.product_id) held
+WHERE p.id = held.product_id;
+
+UPDATE products.variant v
+SET quantity = v.quantity + held.quantity,
+    reserved = held.quantity
+FROM (SELECT i.variant_id, SUM(i.quantity) AS quantity
+      FROM orders.order_items i
+               JOIN orders.order o ON o.id = i.order_id
+      WHERE o.status = 'pending' AND i.variant_id IS NOT NULL
+      GROUP BY i.variant_id) held
+WHERE v.id = held.variant_id;
+
+INSERT INTO products.stock_reservation (id, order_id, product_id, variant_id, quantity, status, expires_at)
+SELECT gen_random_uuid(), i.order_id, i.product_id, i.variant_id, i.quantity,
+       CASE o.status WHEN 'pending' THEN 'active' ELSE 'committed' END,
+       NOW() + INTERVAL '15 minutes'
+FROM orders.order_items i
+         JOIN orders.order o ON o.id = i.order_id
+WHERE o.status IN ('pending', 'confirmed');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Reserved units come off hand right away again, as before reservations.
+UPDATE products.product SET quantity = quantity - reserved WHERE reserved > 0;
+UPDATE products.variant SET quantity = quantity - reserved WHERE reserved > 0;
+
+DROP TABLE IF EXISTS products.stock_reservation;
+
+DROP INDEX IF EXISTS products.idx_product_low_stock;
+CREATE INDEX idx_product_low_stock ON products.product (quantity)
+    WHERE reorder_threshold IS NOT NULL AND archived_at IS NULL;
+
+ALTER TABLE products.variant
+    DROP CONSTRAINT IF EXISTS check_variant_reserved,
+    DROP COLUMN IF EXISTS reserved;
+
+ALTER TABLE products.product
+    DROP CONSTRAINT IF EXISTS check_product_reserved,
+    DROP COLUMN IF EXISTS reserved;
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products.product
+    ADD COLUMN backorder_limit     INTEGER CHECK (backorder_limit >= 0),
+    ADD COLUMN restock_expected_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN release_date        TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE products.stock_reservation
+    DROP CONSTRAINT IF EXISTS stock_reservation_status_check,
+    ADD CONSTRAINT stock_reservation_status_check
+        CHECK (status IN ('waiting', 'active', 'committed', 'released', 'expired', 'returned')),
+    ADD COLUMN kind      VARCHAR(20) NOT NULL DEFAULT 'in_stock'
+        CHECK (kind IN ('in_stock', 'backorder', 'preorder')),
+    ADD COLUMN confirmed BOOLEAN     NOT NULL DEFAULT FALSE,
+    ALTER COLUMN expires_at DROP NOT NULL;
+
+-- Waiting reservations are allocated oldest first.
+CREATE INDEX idx_stock_reservation_waiting ON products.stock_reservation (product_id, created_at)
+    WHERE status = 'waiting';
+
+ALTER TABLE orders.order_items
+    ADD COLUMN fulfillment VARCHAR(20) NOT NULL DEFAULT 'in_stock'
+        CHECK (fulfillment IN ('in_stock', 'backorder', 'preorder')),
+    ADD COLUMN expected_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders.order_items
+    DROP COLUMN IF EXISTS expected_at,
+    DROP COLUMN IF EXISTS fulfillment;
+
+DROP INDEX IF EXISTS products.idx_stock_reservation_waiting;
+
+DELETE FROM products.stock_reservation WHERE status = 'waiting';
+
+ALTER TABLE products.stock_reservation
+    ALTER COLUMN expires_at SET NOT NULL,
+    DROP COLUMN IF EXISTS confirmed,
+    DROP COLUMN IF EXISTS kind,
+    DROP CONSTRAINT IF EXISTS stock_reservation_status_check,
+    ADD CONSTRAINT stock_reservation_status_check
+        CHECK (status IN ('active', 'committed', 'released', 'expired', 'returned'));
+
+ALTER TABLE products.product
+    DROP COLUMN IF EXISTS release_date,
+    DROP COLUMN IF EXISTS restock_expected_at,
+    DROP COLUMN IF EXISTS backorder_limit;
+-- +goose StatementEnd
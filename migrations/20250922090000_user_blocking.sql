@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users.user
+    ADD COLUMN blocked_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users.user
+    DROP COLUMN IF EXISTS blocked_at;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products.product
+    ADD COLUMN max_per_order           INTEGER CHECK (max_per_order > 0),
+    ADD COLUMN max_per_user            INTEGER CHECK (max_per_user > 0),
+    ADD COLUMN purchase_window_seconds BIGINT CHECK (purchase_window_seconds > 0),
+    ADD CONSTRAINT check_product_purchase_window
+        CHECK ((max_per_user IS NULL) = (purchase_window_seconds IS NULL));
+
+CREATE INDEX idx_orders_user_id_created_at ON orders.order (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS orders.idx_orders_user_id_created_at;
+
+ALTER TABLE products.product
+    DROP CONSTRAINT IF EXISTS check_product_purchase_window,
+    DROP COLUMN IF EXISTS purchase_window_seconds,
+    DROP COLUMN IF EXISTS max_per_user,
+    DROP COLUMN IF EXISTS max_per_order;
+-- +goose StatementEnd
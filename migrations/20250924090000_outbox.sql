@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SCHEMA IF NOT EXISTS outbox;
+
+CREATE TABLE IF NOT EXISTS outbox.message
+(
+    id              UUID PRIMARY KEY,
+    event_type      VARCHAR(100)             NOT NULL,
+    aggregate_id    VARCHAR(100)             NOT NULL,
+    payload         JSONB                    NOT NULL,
+    attempts        INTEGER                  NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_outbox_message_next_attempt_at ON outbox.message (next_attempt_at, created_at);
+
+CREATE TABLE IF NOT EXISTS outbox.dead_letter
+(
+    id           UUID PRIMARY KEY,
+    event_type   VARCHAR(100)             NOT NULL,
+    aggregate_id VARCHAR(100)             NOT NULL,
+    payload      JSONB                    NOT NULL,
+    attempts     INTEGER                  NOT NULL,
+    last_error   TEXT,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    failed_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_outbox_dead_letter_failed_at ON outbox.dead_letter (failed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP SCHEMA IF EXISTS outbox CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SCHEMA IF NOT EXISTS webhooks;
+
+CREATE TABLE IF NOT EXISTS webhooks.subscription
+(
+    id          UUID PRIMARY KEY,
+    url         TEXT                     NOT NULL,
+    event_types VARCHAR(100)[]           NOT NULL,
+    secret      VARCHAR(255)             NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_subscription_event_types CHECK (cardinality(event_types) > 0)
+);
+
+CREATE INDEX idx_webhook_subscription_event_types ON webhooks.subscription USING GIN (event_types);
+
+CREATE TABLE IF NOT EXISTS webhooks.delivery
+(
+    id              UUID PRIMARY KEY,
+    subscription_id UUID                     NOT NULL REFERENCES webhooks.subscription (id) ON DELETE CASCADE,
+    event_id        UUID                     NOT NULL,
+    event_type      VARCHAR(100)             NOT NULL,
+    payload         JSONB                    NOT NULL,
+    status          VARCHAR(20)              NOT NULL DEFAULT 'pending',
+    attempt_count   INTEGER                  NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at    TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_delivery_status CHECK (status IN ('pending', 'succeeded', 'failed'))
+);
+
+CREATE INDEX idx_webhook_delivery_due ON webhooks.delivery (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_delivery_subscription_id ON webhooks.delivery (subscription_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS webhooks.delivery_attempt
+(
+    delivery_id  UUID                     NOT NULL REFERENCES webhooks.delivery (id) ON DELETE CASCADE,
+    number       INTEGER                  NOT NULL,
+    status_code  INTEGER,
+    error        TEXT,
+    duration_ms  BIGINT                   NOT NULL,
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (delivery_id, number)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP SCHEMA IF EXISTS webhooks CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS orders.status_change
+(
+    id              BIGSERIAL PRIMARY KEY,
+    order_id        UUID                     NOT NULL REFERENCES orders.order (id) ON DELETE CASCADE,
+    user_id         UUID                     NOT NULL,
+    status          orders.status            NOT NULL,
+    previous_status orders.status            NOT NULL,
+    version         INTEGER                  NOT NULL,
+    occurred_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_order_status_change_order_id ON orders.status_change (order_id, id);
+CREATE INDEX idx_order_status_change_user_id ON orders.status_change (user_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS orders.status_change;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SCHEMA IF NOT EXISTS ratelimit;
+
+-- Token buckets shared by the API replicas. updated_at is NULL for a bucket
+-- that has never been taken from.
+CREATE TABLE IF NOT EXISTS ratelimit.bucket
+(
+    key        VARCHAR(255) PRIMARY KEY,
+    tokens     DOUBLE PRECISION         NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_ratelimit_bucket_expires_at ON ratelimit.bucket (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP SCHEMA IF EXISTS ratelimit CASCADE;
+-- +goose StatementEnd
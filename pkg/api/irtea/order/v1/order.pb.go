@@ -0,0 +1,1291 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: irtea/order/v1/order.proto
+
+package orderv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING     OrderStatus = 1
+	OrderStatus_ORDER_STATUS_CONFIRMED   OrderStatus = 2
+	OrderStatus_ORDER_STATUS_CANCELLED   OrderStatus = 3
+	OrderStatus_ORDER_STATUS_COMPLETED   OrderStatus = 4
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_PENDING",
+		2: "ORDER_STATUS_CONFIRMED",
+		3: "ORDER_STATUS_CANCELLED",
+		4: "ORDER_STATUS_COMPLETED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED": 0,
+		"ORDER_STATUS_PENDING":     1,
+		"ORDER_STATUS_CONFIRMED":   2,
+		"ORDER_STATUS_CANCELLED":   3,
+		"ORDER_STATUS_COMPLETED":   4,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_irtea_order_v1_order_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_irtea_order_v1_order_proto_enumTypes[0]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{0}
+}
+
+type Fulfillment int32
+
+const (
+	Fulfillment_FULFILLMENT_UNSPECIFIED Fulfillment = 0
+	Fulfillment_FULFILLMENT_IN_STOCK    Fulfillment = 1
+	Fulfillment_FULFILLMENT_BACKORDER   Fulfillment = 2
+	Fulfillment_FULFILLMENT_PREORDER    Fulfillment = 3
+)
+
+// Enum value maps for Fulfillment.
+var (
+	Fulfillment_name = map[int32]string{
+		0: "FULFILLMENT_UNSPECIFIED",
+		1: "FULFILLMENT_IN_STOCK",
+		2: "FULFILLMENT_BACKORDER",
+		3: "FULFILLMENT_PREORDER",
+	}
+	Fulfillment_value = map[string]int32{
+		"FULFILLMENT_UNSPECIFIED": 0,
+		"FULFILLMENT_IN_STOCK":    1,
+		"FULFILLMENT_BACKORDER":   2,
+		"FULFILLMENT_PREORDER":    3,
+	}
+)
+
+func (x Fulfillment) Enum() *Fulfillment {
+	p := new(Fulfillment)
+	*p = x
+	return p
+}
+
+func (x Fulfillment) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Fulfillment) Descriptor() protoreflect.EnumDescriptor {
+	return file_irtea_order_v1_order_proto_enumTypes[1].Descriptor()
+}
+
+func (Fulfillment) Type() protoreflect.EnumType {
+	return &file_irtea_order_v1_order_proto_enumTypes[1]
+}
+
+func (x Fulfillment) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Fulfillment.Descriptor instead.
+func (Fulfillment) EnumDescriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{1}
+}
+
+// Money amounts are decimal strings such as "19.99".
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=irtea.order.v1.OrderStatus" json:"status,omitempty"`
+	TotalPrice    string                 `protobuf:"bytes,5,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Version       int32                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	ExpectedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expected_at,json=expectedAt,proto3" json:"expected_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetTotalPrice() string {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return ""
+}
+
+func (x *Order) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Order) GetExpectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpectedAt
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type OrderItem struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ProductId          string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductDescription string                 `protobuf:"bytes,2,opt,name=product_description,json=productDescription,proto3" json:"product_description,omitempty"`
+	ProductPrice       string                 `protobuf:"bytes,3,opt,name=product_price,json=productPrice,proto3" json:"product_price,omitempty"`
+	VariantId          string                 `protobuf:"bytes,4,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	VariantSku         string                 `protobuf:"bytes,5,opt,name=variant_sku,json=variantSku,proto3" json:"variant_sku,omitempty"`
+	VariantOptions     map[string]string      `protobuf:"bytes,6,rep,name=variant_options,json=variantOptions,proto3" json:"variant_options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Quantity           int32                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TotalPrice         string                 `protobuf:"bytes,8,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Fulfillment        Fulfillment            `protobuf:"varint,9,opt,name=fulfillment,proto3,enum=irtea.order.v1.Fulfillment" json:"fulfillment,omitempty"`
+	ExpectedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expected_at,json=expectedAt,proto3" json:"expected_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItem) GetProductDescription() string {
+	if x != nil {
+		return x.ProductDescription
+	}
+	return ""
+}
+
+func (x *OrderItem) GetProductPrice() string {
+	if x != nil {
+		return x.ProductPrice
+	}
+	return ""
+}
+
+func (x *OrderItem) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *OrderItem) GetVariantSku() string {
+	if x != nil {
+		return x.VariantSku
+	}
+	return ""
+}
+
+func (x *OrderItem) GetVariantOptions() map[string]string {
+	if x != nil {
+		return x.VariantOptions
+	}
+	return nil
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetTotalPrice() string {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return ""
+}
+
+func (x *OrderItem) GetFulfillment() Fulfillment {
+	if x != nil {
+		return x.Fulfillment
+	}
+	return Fulfillment_FULFILLMENT_UNSPECIFIED
+}
+
+func (x *OrderItem) GetExpectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpectedAt
+	}
+	return nil
+}
+
+type PlaceOrderRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	UserId        string                    `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items         []*PlaceOrderRequest_Item `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlaceOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetItems() []*PlaceOrderRequest_Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type PlaceOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderResponse) Reset() {
+	*x = PlaceOrderResponse{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderResponse) ProtoMessage() {}
+
+func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlaceOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ListUserOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Defaults to 10.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserOrdersRequest) Reset() {
+	*x = ListUserOrdersRequest{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserOrdersRequest) ProtoMessage() {}
+
+func (x *ListUserOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListUserOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListUserOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUserOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserOrdersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListUserOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserOrdersResponse) Reset() {
+	*x = ListUserOrdersResponse{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserOrdersResponse) ProtoMessage() {}
+
+func (x *ListUserOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListUserOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListUserOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+// expected_version works like the If-Match header of the REST API: the call
+// fails with ABORTED when the order has changed since it was read.
+type ConfirmOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int32                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConfirmOrderRequest) Reset() {
+	*x = ConfirmOrderRequest{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmOrderRequest) ProtoMessage() {}
+
+func (x *ConfirmOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmOrderRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmOrderRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConfirmOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConfirmOrderRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ConfirmOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmOrderResponse) Reset() {
+	*x = ConfirmOrderResponse{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmOrderResponse) ProtoMessage() {}
+
+func (x *ConfirmOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmOrderResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmOrderResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConfirmOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int32                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type WatchOrderStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*WatchOrderStatusRequest_OrderId
+	//	*WatchOrderStatusRequest_UserId
+	Target isWatchOrderStatusRequest_Target `protobuf_oneof:"target"`
+	// Resumes after the change with this ID, like Last-Event-ID of the SSE
+	// endpoints.
+	AfterId       int64 `protobuf:"varint,3,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderStatusRequest) Reset() {
+	*x = WatchOrderStatusRequest{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderStatusRequest) ProtoMessage() {}
+
+func (x *WatchOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchOrderStatusRequest) GetTarget() isWatchOrderStatusRequest_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *WatchOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		if x, ok := x.Target.(*WatchOrderStatusRequest_OrderId); ok {
+			return x.OrderId
+		}
+	}
+	return ""
+}
+
+func (x *WatchOrderStatusRequest) GetUserId() string {
+	if x != nil {
+		if x, ok := x.Target.(*WatchOrderStatusRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return ""
+}
+
+func (x *WatchOrderStatusRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+type isWatchOrderStatusRequest_Target interface {
+	isWatchOrderStatusRequest_Target()
+}
+
+type WatchOrderStatusRequest_OrderId struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3,oneof"`
+}
+
+type WatchOrderStatusRequest_UserId struct {
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+func (*WatchOrderStatusRequest_OrderId) isWatchOrderStatusRequest_Target() {}
+
+func (*WatchOrderStatusRequest_UserId) isWatchOrderStatusRequest_Target() {}
+
+type WatchOrderStatusResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status         OrderStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=irtea.order.v1.OrderStatus" json:"status,omitempty"`
+	PreviousStatus OrderStatus            `protobuf:"varint,5,opt,name=previous_status,json=previousStatus,proto3,enum=irtea.order.v1.OrderStatus" json:"previous_status,omitempty"`
+	Version        int32                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WatchOrderStatusResponse) Reset() {
+	*x = WatchOrderStatusResponse{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderStatusResponse) ProtoMessage() {}
+
+func (x *WatchOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*WatchOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchOrderStatusResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WatchOrderStatusResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *WatchOrderStatusResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WatchOrderStatusResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *WatchOrderStatusResponse) GetPreviousStatus() OrderStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *WatchOrderStatusResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *WatchOrderStatusResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type PlaceOrderRequest_Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VariantId     string                 `protobuf:"bytes,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest_Item) Reset() {
+	*x = PlaceOrderRequest_Item{}
+	mi := &file_irtea_order_v1_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest_Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest_Item) ProtoMessage() {}
+
+func (x *PlaceOrderRequest_Item) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_order_v1_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest_Item.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest_Item) Descriptor() ([]byte, []int) {
+	return file_irtea_order_v1_order_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *PlaceOrderRequest_Item) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest_Item) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest_Item) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+var File_irtea_order_v1_order_proto protoreflect.FileDescriptor
+
+const file_irtea_order_v1_order_proto_rawDesc = "" +
+	"\n" +
+	"\x1airtea/order/v1/order.proto\x12\x0eirtea.order.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x84\x03\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12/\n" +
+	"\x05items\x18\x03 \x03(\v2\x19.irtea.order.v1.OrderItemR\x05items\x123\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1b.irtea.order.v1.OrderStatusR\x06status\x12\x1f\n" +
+	"\vtotal_price\x18\x05 \x01(\tR\n" +
+	"totalPrice\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x05R\aversion\x12;\n" +
+	"\vexpected_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expectedAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x94\x04\n" +
+	"\tOrderItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12/\n" +
+	"\x13product_description\x18\x02 \x01(\tR\x12productDescription\x12#\n" +
+	"\rproduct_price\x18\x03 \x01(\tR\fproductPrice\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x04 \x01(\tR\tvariantId\x12\x1f\n" +
+	"\vvariant_sku\x18\x05 \x01(\tR\n" +
+	"variantSku\x12V\n" +
+	"\x0fvariant_options\x18\x06 \x03(\v2-.irtea.order.v1.OrderItem.VariantOptionsEntryR\x0evariantOptions\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vtotal_price\x18\b \x01(\tR\n" +
+	"totalPrice\x12=\n" +
+	"\vfulfillment\x18\t \x01(\x0e2\x1b.irtea.order.v1.FulfillmentR\vfulfillment\x12;\n" +
+	"\vexpected_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expectedAt\x1aA\n" +
+	"\x13VariantOptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcc\x01\n" +
+	"\x11PlaceOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12<\n" +
+	"\x05items\x18\x02 \x03(\v2&.irtea.order.v1.PlaceOrderRequest.ItemR\x05items\x1a`\n" +
+	"\x04Item\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\tR\tvariantId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"A\n" +
+	"\x12PlaceOrderResponse\x12+\n" +
+	"\x05order\x18\x01 \x01(\v2\x15.irtea.order.v1.OrderR\x05order\"!\n" +
+	"\x0fGetOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x10GetOrderResponse\x12+\n" +
+	"\x05order\x18\x01 \x01(\v2\x15.irtea.order.v1.OrderR\x05order\"^\n" +
+	"\x15ListUserOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"G\n" +
+	"\x16ListUserOrdersResponse\x12-\n" +
+	"\x06orders\x18\x01 \x03(\v2\x15.irtea.order.v1.OrderR\x06orders\"j\n" +
+	"\x13ConfirmOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x05H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"C\n" +
+	"\x14ConfirmOrderResponse\x12+\n" +
+	"\x05order\x18\x01 \x01(\v2\x15.irtea.order.v1.OrderR\x05order\"i\n" +
+	"\x12CancelOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x05H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"B\n" +
+	"\x13CancelOrderResponse\x12+\n" +
+	"\x05order\x18\x01 \x01(\v2\x15.irtea.order.v1.OrderR\x05order\"v\n" +
+	"\x17WatchOrderStatusRequest\x12\x1b\n" +
+	"\border_id\x18\x01 \x01(\tH\x00R\aorderId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x12\x19\n" +
+	"\bafter_id\x18\x03 \x01(\x03R\aafterIdB\b\n" +
+	"\x06target\"\xb0\x02\n" +
+	"\x18WatchOrderStatusResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x123\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1b.irtea.order.v1.OrderStatusR\x06status\x12D\n" +
+	"\x0fprevious_status\x18\x05 \x01(\x0e2\x1b.irtea.order.v1.OrderStatusR\x0epreviousStatus\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x05R\aversion\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt*\x99\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x1a\n" +
+	"\x16ORDER_STATUS_CONFIRMED\x10\x02\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x03\x12\x1a\n" +
+	"\x16ORDER_STATUS_COMPLETED\x10\x04*y\n" +
+	"\vFulfillment\x12\x1b\n" +
+	"\x17FULFILLMENT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14FULFILLMENT_IN_STOCK\x10\x01\x12\x19\n" +
+	"\x15FULFILLMENT_BACKORDER\x10\x02\x12\x18\n" +
+	"\x14FULFILLMENT_PREORDER\x10\x032\xaf\x04\n" +
+	"\fOrderService\x12S\n" +
+	"\n" +
+	"PlaceOrder\x12!.irtea.order.v1.PlaceOrderRequest\x1a\".irtea.order.v1.PlaceOrderResponse\x12M\n" +
+	"\bGetOrder\x12\x1f.irtea.order.v1.GetOrderRequest\x1a .irtea.order.v1.GetOrderResponse\x12_\n" +
+	"\x0eListUserOrders\x12%.irtea.order.v1.ListUserOrdersRequest\x1a&.irtea.order.v1.ListUserOrdersResponse\x12Y\n" +
+	"\fConfirmOrder\x12#.irtea.order.v1.ConfirmOrderRequest\x1a$.irtea.order.v1.ConfirmOrderResponse\x12V\n" +
+	"\vCancelOrder\x12\".irtea.order.v1.CancelOrderRequest\x1a#.irtea.order.v1.CancelOrderResponse\x12g\n" +
+	"\x10WatchOrderStatus\x12'.irtea.order.v1.WatchOrderStatusRequest\x1a(.irtea.order.v1.WatchOrderStatusResponse0\x01B?Z=github.com/BlackRRR/Irtea-test/pkg/api/irtea/order/v1;orderv1b\x06proto3"
+
+var (
+	file_irtea_order_v1_order_proto_rawDescOnce sync.Once
+	file_irtea_order_v1_order_proto_rawDescData []byte
+)
+
+func file_irtea_order_v1_order_proto_rawDescGZIP() []byte {
+	file_irtea_order_v1_order_proto_rawDescOnce.Do(func() {
+		file_irtea_order_v1_order_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_irtea_order_v1_order_proto_rawDesc), len(file_irtea_order_v1_order_proto_rawDesc)))
+	})
+	return file_irtea_order_v1_order_proto_rawDescData
+}
+
+var file_irtea_order_v1_order_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_irtea_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_irtea_order_v1_order_proto_goTypes = []any{
+	(OrderStatus)(0),                 // 0: irtea.order.v1.OrderStatus
+	(Fulfillment)(0),                 // 1: irtea.order.v1.Fulfillment
+	(*Order)(nil),                    // 2: irtea.order.v1.Order
+	(*OrderItem)(nil),                // 3: irtea.order.v1.OrderItem
+	(*PlaceOrderRequest)(nil),        // 4: irtea.order.v1.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),       // 5: irtea.order.v1.PlaceOrderResponse
+	(*GetOrderRequest)(nil),          // 6: irtea.order.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),         // 7: irtea.order.v1.GetOrderResponse
+	(*ListUserOrdersRequest)(nil),    // 8: irtea.order.v1.ListUserOrdersRequest
+	(*ListUserOrdersResponse)(nil),   // 9: irtea.order.v1.ListUserOrdersResponse
+	(*ConfirmOrderRequest)(nil),      // 10: irtea.order.v1.ConfirmOrderRequest
+	(*ConfirmOrderResponse)(nil),     // 11: irtea.order.v1.ConfirmOrderResponse
+	(*CancelOrderRequest)(nil),       // 12: irtea.order.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),      // 13: irtea.order.v1.CancelOrderResponse
+	(*WatchOrderStatusRequest)(nil),  // 14: irtea.order.v1.WatchOrderStatusRequest
+	(*WatchOrderStatusResponse)(nil), // 15: irtea.order.v1.WatchOrderStatusResponse
+	nil,                              // 16: irtea.order.v1.OrderItem.VariantOptionsEntry
+	(*PlaceOrderRequest_Item)(nil),   // 17: irtea.order.v1.PlaceOrderRequest.Item
+	(*timestamppb.Timestamp)(nil),    // 18: google.protobuf.Timestamp
+}
+var file_irtea_order_v1_order_proto_depIdxs = []int32{
+	3,  // 0: irtea.order.v1.Order.items:type_name -> irtea.order.v1.OrderItem
+	0,  // 1: irtea.order.v1.Order.status:type_name -> irtea.order.v1.OrderStatus
+	18, // 2: irtea.order.v1.Order.expected_at:type_name -> google.protobuf.Timestamp
+	18, // 3: irtea.order.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	18, // 4: irtea.order.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 5: irtea.order.v1.OrderItem.variant_options:type_name -> irtea.order.v1.OrderItem.VariantOptionsEntry
+	1,  // 6: irtea.order.v1.OrderItem.fulfillment:type_name -> irtea.order.v1.Fulfillment
+	18, // 7: irtea.order.v1.OrderItem.expected_at:type_name -> google.protobuf.Timestamp
+	17, // 8: irtea.order.v1.PlaceOrderRequest.items:type_name -> irtea.order.v1.PlaceOrderRequest.Item
+	2,  // 9: irtea.order.v1.PlaceOrderResponse.order:type_name -> irtea.order.v1.Order
+	2,  // 10: irtea.order.v1.GetOrderResponse.order:type_name -> irtea.order.v1.Order
+	2,  // 11: irtea.order.v1.ListUserOrdersResponse.orders:type_name -> irtea.order.v1.Order
+	2,  // 12: irtea.order.v1.ConfirmOrderResponse.order:type_name -> irtea.order.v1.Order
+	2,  // 13: irtea.order.v1.CancelOrderResponse.order:type_name -> irtea.order.v1.Order
+	0,  // 14: irtea.order.v1.WatchOrderStatusResponse.status:type_name -> irtea.order.v1.OrderStatus
+	0,  // 15: irtea.order.v1.WatchOrderStatusResponse.previous_status:type_name -> irtea.order.v1.OrderStatus
+	18, // 16: irtea.order.v1.WatchOrderStatusResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	4,  // 17: irtea.order.v1.OrderService.PlaceOrder:input_type -> irtea.order.v1.PlaceOrderRequest
+	6,  // 18: irtea.order.v1.OrderService.GetOrder:input_type -> irtea.order.v1.GetOrderRequest
+	8,  // 19: irtea.order.v1.OrderService.ListUserOrders:input_type -> irtea.order.v1.ListUserOrdersRequest
+	10, // 20: irtea.order.v1.OrderService.ConfirmOrder:input_type -> irtea.order.v1.ConfirmOrderRequest
+	12, // 21: irtea.order.v1.OrderService.CancelOrder:input_type -> irtea.order.v1.CancelOrderRequest
+	14, // 22: irtea.order.v1.OrderService.WatchOrderStatus:input_type -> irtea.order.v1.WatchOrderStatusRequest
+	5,  // 23: irtea.order.v1.OrderService.PlaceOrder:output_type -> irtea.order.v1.PlaceOrderResponse
+	7,  // 24: irtea.order.v1.OrderService.GetOrder:output_type -> irtea.order.v1.GetOrderResponse
+	9,  // 25: irtea.order.v1.OrderService.ListUserOrders:output_type -> irtea.order.v1.ListUserOrdersResponse
+	11, // 26: irtea.order.v1.OrderService.ConfirmOrder:output_type -> irtea.order.v1.ConfirmOrderResponse
+	13, // 27: irtea.order.v1.OrderService.CancelOrder:output_type -> irtea.order.v1.CancelOrderResponse
+	15, // 28: irtea.order.v1.OrderService.WatchOrderStatus:output_type -> irtea.order.v1.WatchOrderStatusResponse
+	23, // [23:29] is the sub-list for method output_type
+	17, // [17:23] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_irtea_order_v1_order_proto_init() }
+func file_irtea_order_v1_order_proto_init() {
+	if File_irtea_order_v1_order_proto != nil {
+		return
+	}
+	file_irtea_order_v1_order_proto_msgTypes[8].OneofWrappers = []any{}
+	file_irtea_order_v1_order_proto_msgTypes[10].OneofWrappers = []any{}
+	file_irtea_order_v1_order_proto_msgTypes[12].OneofWrappers = []any{
+		(*WatchOrderStatusRequest_OrderId)(nil),
+		(*WatchOrderStatusRequest_UserId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_irtea_order_v1_order_proto_rawDesc), len(file_irtea_order_v1_order_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_irtea_order_v1_order_proto_goTypes,
+		DependencyIndexes: file_irtea_order_v1_order_proto_depIdxs,
+		EnumInfos:         file_irtea_order_v1_order_proto_enumTypes,
+		MessageInfos:      file_irtea_order_v1_order_proto_msgTypes,
+	}.Build()
+	File_irtea_order_v1_order_proto = out.File
+	file_irtea_order_v1_order_proto_goTypes = nil
+	file_irtea_order_v1_order_proto_depIdxs = nil
+}
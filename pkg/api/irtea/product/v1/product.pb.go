@@ -0,0 +1,1328 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: irtea/product/v1/product.proto
+
+package productv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Money amounts are decimal strings such as "19.99".
+type Product struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Tags              []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Price             string                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity          int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved          int32                  `protobuf:"varint,6,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available         int32                  `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`
+	Options           []*OptionAxis          `protobuf:"bytes,8,rep,name=options,proto3" json:"options,omitempty"`
+	Variants          []*Variant             `protobuf:"bytes,9,rep,name=variants,proto3" json:"variants,omitempty"`
+	ReorderThreshold  *int32                 `protobuf:"varint,10,opt,name=reorder_threshold,json=reorderThreshold,proto3,oneof" json:"reorder_threshold,omitempty"`
+	LowStock          bool                   `protobuf:"varint,11,opt,name=low_stock,json=lowStock,proto3" json:"low_stock,omitempty"`
+	BackorderLimit    *int32                 `protobuf:"varint,12,opt,name=backorder_limit,json=backorderLimit,proto3,oneof" json:"backorder_limit,omitempty"`
+	RestockExpectedAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=restock_expected_at,json=restockExpectedAt,proto3" json:"restock_expected_at,omitempty"`
+	ReleaseDate       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	Version           int32                  `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ArchivedAt        *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Product) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Product) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Product) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *Product) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Product) GetOptions() []*OptionAxis {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Product) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Product) GetReorderThreshold() int32 {
+	if x != nil && x.ReorderThreshold != nil {
+		return *x.ReorderThreshold
+	}
+	return 0
+}
+
+func (x *Product) GetLowStock() bool {
+	if x != nil {
+		return x.LowStock
+	}
+	return false
+}
+
+func (x *Product) GetBackorderLimit() int32 {
+	if x != nil && x.BackorderLimit != nil {
+		return *x.BackorderLimit
+	}
+	return 0
+}
+
+func (x *Product) GetRestockExpectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RestockExpectedAt
+	}
+	return nil
+}
+
+func (x *Product) GetReleaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return nil
+}
+
+func (x *Product) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+type OptionAxis struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionAxis) Reset() {
+	*x = OptionAxis{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionAxis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionAxis) ProtoMessage() {}
+
+func (x *OptionAxis) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionAxis.ProtoReflect.Descriptor instead.
+func (*OptionAxis) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OptionAxis) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OptionAxis) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Variant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Barcode       string                 `protobuf:"bytes,3,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Options       map[string]string      `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Price         string                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved      int32                  `protobuf:"varint,7,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available     int32                  `protobuf:"varint,8,opt,name=available,proto3" json:"available,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Variant) Reset() {
+	*x = Variant{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variant) ProtoMessage() {}
+
+func (x *Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variant.ProtoReflect.Descriptor instead.
+func (*Variant) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Variant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Variant) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Variant) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *Variant) GetOptions() map[string]string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Variant) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Variant) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Variant) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *Variant) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Variant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Variant) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateProductRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Description       string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Tags              []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Price             string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity          int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Options           []*OptionAxis          `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty"`
+	ReorderThreshold  *int32                 `protobuf:"varint,6,opt,name=reorder_threshold,json=reorderThreshold,proto3,oneof" json:"reorder_threshold,omitempty"`
+	BackorderLimit    *int32                 `protobuf:"varint,7,opt,name=backorder_limit,json=backorderLimit,proto3,oneof" json:"backorder_limit,omitempty"`
+	RestockExpectedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=restock_expected_at,json=restockExpectedAt,proto3" json:"restock_expected_at,omitempty"`
+	ReleaseDate       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetOptions() []*OptionAxis {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetReorderThreshold() int32 {
+	if x != nil && x.ReorderThreshold != nil {
+		return *x.ReorderThreshold
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetBackorderLimit() int32 {
+	if x != nil && x.BackorderLimit != nil {
+		return *x.BackorderLimit
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetRestockExpectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RestockExpectedAt
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetReleaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return nil
+}
+
+type CreateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductResponse) Reset() {
+	*x = CreateProductResponse{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductResponse) ProtoMessage() {}
+
+func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductResponse) Reset() {
+	*x = GetProductResponse{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductResponse) ProtoMessage() {}
+
+func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
+func (*GetProductResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 10.
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// expected_version works like the If-Match header of the REST API: the call
+// fails with ABORTED when the product has changed since it was read.
+type UpdatePriceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Price           string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	ExpectedVersion *int32                 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdatePriceRequest) Reset() {
+	*x = UpdatePriceRequest{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePriceRequest) ProtoMessage() {}
+
+func (x *UpdatePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePriceRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePriceRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdatePriceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdatePriceRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *UpdatePriceRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdatePriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePriceResponse) Reset() {
+	*x = UpdatePriceResponse{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePriceResponse) ProtoMessage() {}
+
+func (x *UpdatePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePriceResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePriceResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdatePriceResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type AdjustStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Signed change of the on-hand quantity.
+	Quantity        int32  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExpectedVersion *int32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AdjustStockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type AdjustStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockResponse) ProtoMessage() {}
+
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AdjustStockResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type ArchiveProductRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int32                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ArchiveProductRequest) Reset() {
+	*x = ArchiveProductRequest{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveProductRequest) ProtoMessage() {}
+
+func (x *ArchiveProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveProductRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveProductRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ArchiveProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ArchiveProductRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ArchiveProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveProductResponse) Reset() {
+	*x = ArchiveProductResponse{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveProductResponse) ProtoMessage() {}
+
+func (x *ArchiveProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveProductResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveProductResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ArchiveProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type RestoreProductRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int32                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestoreProductRequest) Reset() {
+	*x = RestoreProductRequest{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductRequest) ProtoMessage() {}
+
+func (x *RestoreProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductRequest) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestoreProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RestoreProductRequest) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type RestoreProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductResponse) Reset() {
+	*x = RestoreProductResponse{}
+	mi := &file_irtea_product_v1_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductResponse) ProtoMessage() {}
+
+func (x *RestoreProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_irtea_product_v1_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductResponse.ProtoReflect.Descriptor instead.
+func (*RestoreProductResponse) Descriptor() ([]byte, []int) {
+	return file_irtea_product_v1_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestoreProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+var File_irtea_product_v1_product_proto protoreflect.FileDescriptor
+
+const file_irtea_product_v1_product_proto_rawDesc = "" +
+	"\n" +
+	"\x1eirtea/product/v1/product.proto\x12\x10irtea.product.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x06\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x06 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\a \x01(\x05R\tavailable\x126\n" +
+	"\aoptions\x18\b \x03(\v2\x1c.irtea.product.v1.OptionAxisR\aoptions\x125\n" +
+	"\bvariants\x18\t \x03(\v2\x19.irtea.product.v1.VariantR\bvariants\x120\n" +
+	"\x11reorder_threshold\x18\n" +
+	" \x01(\x05H\x00R\x10reorderThreshold\x88\x01\x01\x12\x1b\n" +
+	"\tlow_stock\x18\v \x01(\bR\blowStock\x12,\n" +
+	"\x0fbackorder_limit\x18\f \x01(\x05H\x01R\x0ebackorderLimit\x88\x01\x01\x12J\n" +
+	"\x13restock_expected_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x11restockExpectedAt\x12=\n" +
+	"\frelease_date\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vreleaseDate\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\varchived_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAtB\x14\n" +
+	"\x12_reorder_thresholdB\x12\n" +
+	"\x10_backorder_limit\"8\n" +
+	"\n" +
+	"OptionAxis\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\"\xa5\x03\n" +
+	"\aVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x18\n" +
+	"\abarcode\x18\x03 \x01(\tR\abarcode\x12@\n" +
+	"\aoptions\x18\x04 \x03(\v2&.irtea.product.v1.Variant.OptionsEntryR\aoptions\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\a \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\b \x01(\x05R\tavailable\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a:\n" +
+	"\fOptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcb\x03\n" +
+	"\x14CreateProductRequest\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x126\n" +
+	"\aoptions\x18\x05 \x03(\v2\x1c.irtea.product.v1.OptionAxisR\aoptions\x120\n" +
+	"\x11reorder_threshold\x18\x06 \x01(\x05H\x00R\x10reorderThreshold\x88\x01\x01\x12,\n" +
+	"\x0fbackorder_limit\x18\a \x01(\x05H\x01R\x0ebackorderLimit\x88\x01\x01\x12J\n" +
+	"\x13restock_expected_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x11restockExpectedAt\x12=\n" +
+	"\frelease_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vreleaseDateB\x14\n" +
+	"\x12_reorder_thresholdB\x12\n" +
+	"\x10_backorder_limit\"L\n" +
+	"\x15CreateProductResponse\x123\n" +
+	"\aproduct\x18\x01 \x01(\v2\x19.irtea.product.v1.ProductR\aproduct\"#\n" +
+	"\x11GetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x12GetProductResponse\x123\n" +
+	"\aproduct\x18\x01 \x01(\v2\x19.irtea.product.v1.ProductR\aproduct\"C\n" +
+	"\x13ListProductsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"M\n" +
+	"\x14ListProductsResponse\x125\n" +
+	"\bproducts\x18\x01 \x03(\v2\x19.irtea.product.v1.ProductR\bproducts\"\x7f\n" +
+	"\x12UpdatePriceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\tR\x05price\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x05H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"J\n" +
+	"\x13UpdatePriceResponse\x123\n" +
+	"\aproduct\x18\x01 \x01(\v2\x19.irtea.product.v1.ProductR\aproduct\"\x85\x01\n" +
+	"\x12AdjustStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x05H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"J\n" +
+	"\x13AdjustStockResponse\x123\n" +
+	"\aproduct\x18\x01 \x01(\v2\x19.irtea.product.v1.ProductR\aproduct\"l\n" +
+	"\x15ArchiveProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x05H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"M\n" +
+	"\x16ArchiveProductResponse\x123\n" +
+	"\aproduct\x18\x01 \x01(\v2\x19.irtea.product.v1.ProductR\aproduct\"l\n" +
+	"\x15RestoreProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x05H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"M\n" +
+	"\x16RestoreProductResponse\x123\n" +
+	"\aproduct\x18\x01 \x01(\v2\x19.irtea.product.v1.ProductR\aproduct2\xac\x05\n" +
+	"\x0eProductService\x12`\n" +
+	"\rCreateProduct\x12&.irtea.product.v1.CreateProductRequest\x1a'.irtea.product.v1.CreateProductResponse\x12W\n" +
+	"\n" +
+	"GetProduct\x12#.irtea.product.v1.GetProductRequest\x1a$.irtea.product.v1.GetProductResponse\x12]\n" +
+	"\fListProducts\x12%.irtea.product.v1.ListProductsRequest\x1a&.irtea.product.v1.ListProductsResponse\x12Z\n" +
+	"\vUpdatePrice\x12$.irtea.product.v1.UpdatePriceRequest\x1a%.irtea.product.v1.UpdatePriceResponse\x12Z\n" +
+	"\vAdjustStock\x12$.irtea.product.v1.AdjustStockRequest\x1a%.irtea.product.v1.AdjustStockResponse\x12c\n" +
+	"\x0eArchiveProduct\x12'.irtea.product.v1.ArchiveProductRequest\x1a(.irtea.product.v1.ArchiveProductResponse\x12c\n" +
+	"\x0eRestoreProduct\x12'.irtea.product.v1.RestoreProductRequest\x1a(.irtea.product.v1.RestoreProductResponseBCZAgithub.com/BlackRRR/Irtea-test/pkg/api/irtea/product/v1;productv1b\x06proto3"
+
+var (
+	file_irtea_product_v1_product_proto_rawDescOnce sync.Once
+	file_irtea_product_v1_product_proto_rawDescData []byte
+)
+
+func file_irtea_product_v1_product_proto_rawDescGZIP() []byte {
+	file_irtea_product_v1_product_proto_rawDescOnce.Do(func() {
+		file_irtea_product_v1_product_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_irtea_product_v1_product_proto_rawDesc), len(file_irtea_product_v1_product_proto_rawDesc)))
+	})
+	return file_irtea_product_v1_product_proto_rawDescData
+}
+
+var file_irtea_product_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_irtea_product_v1_product_proto_goTypes = []any{
+	(*Product)(nil),                // 0: irtea.product.v1.Product
+	(*OptionAxis)(nil),             // 1: irtea.product.v1.OptionAxis
+	(*Variant)(nil),                // 2: irtea.product.v1.Variant
+	(*CreateProductRequest)(nil),   // 3: irtea.product.v1.CreateProductRequest
+	(*CreateProductResponse)(nil),  // 4: irtea.product.v1.CreateProductResponse
+	(*GetProductRequest)(nil),      // 5: irtea.product.v1.GetProductRequest
+	(*GetProductResponse)(nil),     // 6: irtea.product.v1.GetProductResponse
+	(*ListProductsRequest)(nil),    // 7: irtea.product.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),   // 8: irtea.product.v1.ListProductsResponse
+	(*UpdatePriceRequest)(nil),     // 9: irtea.product.v1.UpdatePriceRequest
+	(*UpdatePriceResponse)(nil),    // 10: irtea.product.v1.UpdatePriceResponse
+	(*AdjustStockRequest)(nil),     // 11: irtea.product.v1.AdjustStockRequest
+	(*AdjustStockResponse)(nil),    // 12: irtea.product.v1.AdjustStockResponse
+	(*ArchiveProductRequest)(nil),  // 13: irtea.product.v1.ArchiveProductRequest
+	(*ArchiveProductResponse)(nil), // 14: irtea.product.v1.ArchiveProductResponse
+	(*RestoreProductRequest)(nil),  // 15: irtea.product.v1.RestoreProductRequest
+	(*RestoreProductResponse)(nil), // 16: irtea.product.v1.RestoreProductResponse
+	nil,                            // 17: irtea.product.v1.Variant.OptionsEntry
+	(*timestamppb.Timestamp)(nil),  // 18: google.protobuf.Timestamp
+}
+var file_irtea_product_v1_product_proto_depIdxs = []int32{
+	1,  // 0: irtea.product.v1.Product.options:type_name -> irtea.product.v1.OptionAxis
+	2,  // 1: irtea.product.v1.Product.variants:type_name -> irtea.product.v1.Variant
+	18, // 2: irtea.product.v1.Product.restock_expected_at:type_name -> google.protobuf.Timestamp
+	18, // 3: irtea.product.v1.Product.release_date:type_name -> google.protobuf.Timestamp
+	18, // 4: irtea.product.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	18, // 5: irtea.product.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
+	18, // 6: irtea.product.v1.Product.archived_at:type_name -> google.protobuf.Timestamp
+	17, // 7: irtea.product.v1.Variant.options:type_name -> irtea.product.v1.Variant.OptionsEntry
+	18, // 8: irtea.product.v1.Variant.created_at:type_name -> google.protobuf.Timestamp
+	18, // 9: irtea.product.v1.Variant.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 10: irtea.product.v1.CreateProductRequest.options:type_name -> irtea.product.v1.OptionAxis
+	18, // 11: irtea.product.v1.CreateProductRequest.restock_expected_at:type_name -> google.protobuf.Timestamp
+	18, // 12: irtea.product.v1.CreateProductRequest.release_date:type_name -> google.protobuf.Timestamp
+	0,  // 13: irtea.product.v1.CreateProductResponse.product:type_name -> irtea.product.v1.Product
+	0,  // 14: irtea.product.v1.GetProductResponse.product:type_name -> irtea.product.v1.Product
+	0,  // 15: irtea.product.v1.ListProductsResponse.products:type_name -> irtea.product.v1.Product
+	0,  // 16: irtea.product.v1.UpdatePriceResponse.product:type_name -> irtea.product.v1.Product
+	0,  // 17: irtea.product.v1.AdjustStockResponse.product:type_name -> irtea.product.v1.Product
+	0,  // 18: irtea.product.v1.ArchiveProductResponse.product:type_name -> irtea.product.v1.Product
+	0,  // 19: irtea.product.v1.RestoreProductResponse.product:type_name -> irtea.product.v1.Product
+	3,  // 20: irtea.product.v1.ProductService.CreateProduct:input_type -> irtea.product.v1.CreateProductRequest
+	5,  // 21: irtea.product.v1.ProductService.GetProduct:input_type -> irtea.product.v1.GetProductRequest
+	7,  // 22: irtea.product.v1.ProductService.ListProducts:input_type -> irtea.product.v1.ListProductsRequest
+	9,  // 23: irtea.product.v1.ProductService.UpdatePrice:input_type -> irtea.product.v1.UpdatePriceRequest
+	11, // 24: irtea.product.v1.ProductService.AdjustStock:input_type -> irtea.product.v1.AdjustStockRequest
+	13, // 25: irtea.product.v1.ProductService.ArchiveProduct:input_type -> irtea.product.v1.ArchiveProductRequest
+	15, // 26: irtea.product.v1.ProductService.RestoreProduct:input_type -> irtea.product.v1.RestoreProductRequest
+	4,  // 27: irtea.product.v1.ProductService.CreateProduct:output_type -> irtea.product.v1.CreateProductResponse
+	6,  // 28: irtea.product.v1.ProductService.GetProduct:output_type -> irtea.product.v1.GetProductResponse
+	8,  // 29: irtea.product.v1.ProductService.ListProducts:output_type -> irtea.product.v1.ListProductsResponse
+	10, // 30: irtea.product.v1.ProductService.UpdatePrice:output_type -> irtea.product.v1.UpdatePriceResponse
+	12, // 31: irtea.product.v1.ProductService.AdjustStock:output_type -> irtea.product.v1.AdjustStockResponse
+	14, // 32: irtea.product.v1.ProductService.ArchiveProduct:output_type -> irtea.product.v1.ArchiveProductResponse
+	16, // 33: irtea.product.v1.ProductService.RestoreProduct:output_type -> irtea.product.v1.RestoreProductResponse
+	27, // [27:34] is the sub-list for method output_type
+	20, // [20:27] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_irtea_product_v1_product_proto_init() }
+func file_irtea_product_v1_product_proto_init() {
+	if File_irtea_product_v1_product_proto != nil {
+		return
+	}
+	file_irtea_product_v1_product_proto_msgTypes[0].OneofWrappers = []any{}
+	file_irtea_product_v1_product_proto_msgTypes[3].OneofWrappers = []any{}
+	file_irtea_product_v1_product_proto_msgTypes[9].OneofWrappers = []any{}
+	file_irtea_product_v1_product_proto_msgTypes[11].OneofWrappers = []any{}
+	file_irtea_product_v1_product_proto_msgTypes[13].OneofWrappers = []any{}
+	file_irtea_product_v1_product_proto_msgTypes[15].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_irtea_product_v1_product_proto_rawDesc), len(file_irtea_product_v1_product_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_irtea_product_v1_product_proto_goTypes,
+		DependencyIndexes: file_irtea_product_v1_product_proto_depIdxs,
+		MessageInfos:      file_irtea_product_v1_product_proto_msgTypes,
+	}.Build()
+	File_irtea_product_v1_product_proto = out.File
+	file_irtea_product_v1_product_proto_goTypes = nil
+	file_irtea_product_v1_product_proto_depIdxs = nil
+}
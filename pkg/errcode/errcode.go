@@ -0,0 +1,33 @@
+// Package errcode attaches stable, machine-readable codes to errors. Codes
+// never change once published; messages may.
+package errcode
+
+import "errors"
+
+type Error struct {
+	code    string
+	message string
+}
+
+// New returns a sentinel error. Code is a kebab-case identifier such as
+// "product-not-found".
+func New(code, message string) *Error {
+	return &Error{code: code, message: message}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Code() string {
+	return e.code
+}
+
+// Of returns the code of the first coded error in err's chain.
+func Of(err error) (string, bool) {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.code, true
+	}
+	return "", false
+}
@@ -0,0 +1,21 @@
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	errNotFound := New("thing-not-found", "thing not found")
+
+	code, ok := Of(fmt.Errorf("load: %w", errNotFound))
+	assert.True(t, ok)
+	assert.Equal(t, "thing-not-found", code)
+	assert.Equal(t, "thing not found", errNotFound.Error())
+
+	_, ok = Of(errors.New("plain"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,62 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/BlackRRR/Irtea-test/pkg/errcode"
+)
+
+var (
+	ErrMalformed = errcode.New("malformed-etag", "malformed entity tag")
+	ErrWeak      = errcode.New("weak-etag", "weak entity tags cannot be used with If-Match")
+)
+
+// Format renders an entity version as a strong entity tag.
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ParseIfMatch returns the version pinned by an If-Match header value. An
+// empty header or "*" does not pin a version and yields nil.
+func ParseIfMatch(header string) (*int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(header, "W/") {
+		return nil, ErrWeak
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return nil, ErrMalformed
+	}
+
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version <= 0 {
+		return nil, ErrMalformed
+	}
+
+	return &version, nil
+}
+
+// IfMatch reads the version pinned by the request's If-Match header.
+func IfMatch(c *fiber.Ctx) (*int, error) {
+	return ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+}
+
+// Set writes the ETag header for an entity version.
+func Set(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, Format(version))
+}
+
+// ConflictStatus is the status for a version conflict: 412 when the client
+// sent If-Match, 409 when a concurrent writer won the race.
+func ConflictStatus(c *fiber.Ctx) int {
+	if c.Get(fiber.HeaderIfMatch) != "" {
+		return fiber.StatusPreconditionFailed
+	}
+	return fiber.StatusConflict
+}
@@ -0,0 +1,35 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, `"7"`, Format(7))
+}
+
+func TestParseIfMatch(t *testing.T) {
+	version, err := ParseIfMatch(`"7"`)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, *version)
+
+	version, err = ParseIfMatch("")
+	assert.NoError(t, err)
+	assert.Nil(t, version)
+
+	version, err = ParseIfMatch("*")
+	assert.NoError(t, err)
+	assert.Nil(t, version)
+}
+
+func TestParseIfMatch_Invalid(t *testing.T) {
+	_, err := ParseIfMatch(`W/"7"`)
+	assert.Equal(t, ErrWeak, err)
+
+	for _, header := range []string{`7`, `"abc"`, `"0"`, `"1", "2"`} {
+		_, err = ParseIfMatch(header)
+		assert.Equal(t, ErrMalformed, err, header)
+	}
+}
@@ -0,0 +1,27 @@
+// Package event holds the building blocks for domain events.
+package event
+
+// Event is something that happened to an aggregate. Events are serialised
+// to JSON when they are stored, so their fields carry json tags.
+type Event interface {
+	// EventName identifies the event type, such as "order.placed".
+	EventName() string
+	AggregateID() string
+}
+
+// Recorder collects the events an aggregate raises until they are saved.
+// Aggregates embed it.
+type Recorder struct {
+	events []Event
+}
+
+func (r *Recorder) Record(e Event) {
+	r.events = append(r.events, e)
+}
+
+// PullEvents returns the recorded events and forgets them.
+func (r *Recorder) PullEvents() []Event {
+	events := r.events
+	r.events = nil
+	return events
+}
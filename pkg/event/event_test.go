@@ -0,0 +1,23 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct{ id string }
+
+func (e testEvent) EventName() string   { return "test.happened" }
+func (e testEvent) AggregateID() string { return e.id }
+
+func TestRecorder_PullEvents(t *testing.T) {
+	var recorder Recorder
+	recorder.Record(testEvent{id: "1"})
+	recorder.Record(testEvent{id: "2"})
+
+	events := recorder.PullEvents()
+
+	assert.Equal(t, []Event{testEvent{id: "1"}, testEvent{id: "2"}}, events)
+	assert.Empty(t, recorder.PullEvents())
+}
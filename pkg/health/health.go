@@ -0,0 +1,179 @@
+// Package health runs named dependency checks for liveness and readiness
+// probes.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOK Status = "ok"
+	// StatusDegraded means only optional checks fail.
+	StatusDegraded Status = "degraded"
+	StatusFailing  Status = "failing"
+	// StatusDraining means the service is shutting down.
+	StatusDraining Status = "draining"
+)
+
+var ErrTimeout = errors.New("health check timed out")
+
+// Checker returns nil when the dependency is healthy.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Check is a named checker. Zero Timeout and CacheTTL take the registry's
+// defaults.
+type Check struct {
+	Name    string
+	Checker Checker
+	Timeout time.Duration
+	// CacheTTL is how long a result is reused, so probes do not hammer the
+	// dependency.
+	CacheTTL time.Duration
+	// Optional checks are reported but do not fail readiness.
+	Optional bool
+}
+
+type Result struct {
+	Status    Status    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Optional  bool      `json:"optional,omitempty"`
+	Duration  string    `json:"duration"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Ready reports whether the service should receive traffic.
+func (r Report) Ready() bool {
+	return r.Status == StatusOK || r.Status == StatusDegraded
+}
+
+type Config struct {
+	Timeout  time.Duration `env:"TIMEOUT" envDefault:"2s" validate:"gt=0"`
+	CacheTTL time.Duration `env:"CACHE_TTL" envDefault:"5s"`
+	// How long readiness fails before the servers stop on shutdown, so load
+	// balancers notice and drain traffic
+	DrainDelay time.Duration `env:"DRAIN_DELAY" envDefault:"5s"`
+}
+
+type Registry struct {
+	config   Config
+	checks   []*registeredCheck
+	draining atomic.Bool
+	now      func() time.Time
+}
+
+type registeredCheck struct {
+	Check
+
+	mu     sync.Mutex
+	result Result
+}
+
+func NewRegistry(config Config) *Registry {
+	return &Registry{config: config, now: time.Now}
+}
+
+// Register adds checks. It must not be called once probes are served.
+func (r *Registry) Register(checks ...Check) {
+	for _, check := range checks {
+		if check.Timeout == 0 {
+			check.Timeout = r.config.Timeout
+		}
+		if check.CacheTTL == 0 {
+			check.CacheTTL = r.config.CacheTTL
+		}
+		r.checks = append(r.checks, &registeredCheck{Check: check})
+	}
+}
+
+// Drain makes readiness fail from now on, so load balancers stop sending
+// traffic before the servers shut down.
+func (r *Registry) Drain() {
+	r.draining.Store(true)
+}
+
+// Ready runs the checks concurrently, reusing cached results.
+func (r *Registry) Ready(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(r.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result := r.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			switch {
+			case result.Status == StatusOK:
+			case check.Optional:
+				if report.Status == StatusOK {
+					report.Status = StatusDegraded
+				}
+			default:
+				report.Status = StatusFailing
+			}
+		}()
+	}
+	wg.Wait()
+
+	if r.draining.Load() {
+		report.Status = StatusDraining
+	}
+
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, check *registeredCheck) Result {
+	check.mu.Lock()
+	defer check.mu.Unlock()
+
+	now := r.now()
+	if !check.result.CheckedAt.IsZero() && now.Sub(check.result.CheckedAt) < check.CacheTTL {
+		return check.result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() { errc <- check.Checker.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = ErrTimeout
+	}
+
+	result := Result{Status: StatusOK, Optional: check.Optional, Duration: r.now().Sub(now).String(), CheckedAt: now}
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+	check.result = result
+
+	return result
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Ready(t *testing.T) {
+	r := NewRegistry(Config{Timeout: time.Second})
+	r.Register(
+		Check{Name: "db", Checker: CheckerFunc(func(context.Context) error { return nil })},
+		Check{Name: "exporter", Optional: true, Checker: CheckerFunc(func(context.Context) error {
+			return errors.New("unavailable")
+		})},
+	)
+
+	report := r.Ready(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.True(t, report.Ready())
+	assert.Equal(t, StatusOK, report.Checks["db"].Status)
+	assert.Equal(t, "unavailable", report.Checks["exporter"].Error)
+
+	r.Register(Check{Name: "migrations", Checker: CheckerFunc(func(context.Context) error {
+		return errors.New("behind")
+	})})
+
+	report = r.Ready(context.Background())
+	assert.Equal(t, StatusFailing, report.Status)
+	assert.False(t, report.Ready())
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+	r := NewRegistry(Config{Timeout: 10 * time.Millisecond})
+	r.Register(Check{Name: "slow", Checker: CheckerFunc(func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})})
+
+	start := time.Now()
+	report := r.Ready(context.Background())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, ErrTimeout.Error(), report.Checks["slow"].Error)
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	now := time.Date(2025, 9, 28, 12, 0, 0, 0, time.UTC)
+	r := NewRegistry(Config{Timeout: time.Second, CacheTTL: 5 * time.Second})
+	r.now = func() time.Time { return now }
+
+	calls := 0
+	r.Register(Check{Name: "db", Checker: CheckerFunc(func(context.Context) error {
+		calls++
+		return nil
+	})})
+
+	ctx := context.Background()
+	r.Ready(ctx)
+	r.Ready(ctx)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(5 * time.Second)
+	r.Ready(ctx)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRegistry_Drain(t *testing.T) {
+	r := NewRegistry(Config{Timeout: time.Second})
+	require.True(t, r.Ready(context.Background()).Ready())
+
+	r.Drain()
+	report := r.Ready(context.Background())
+	assert.Equal(t, StatusDraining, report.Status)
+	assert.False(t, report.Ready())
+}
+
+func TestHeartbeat(t *testing.T) {
+	now := time.Date(2025, 9, 28, 12, 0, 0, 0, time.UTC)
+	h := NewHeartbeat(time.Minute)
+	h.started = now
+	h.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	assert.NoError(t, h.Check(ctx))
+
+	now = now.Add(2 * time.Minute)
+	assert.EqualError(t, h.Check(ctx), "no heartbeat for 2m0s")
+
+	h.Beat()
+	assert.NoError(t, h.Check(ctx))
+}
@@ -0,0 +1,40 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat checks that a background worker is still running. The worker
+// calls Beat after every run; the check fails when the last beat is older
+// than MaxAge.
+type Heartbeat struct {
+	maxAge  time.Duration
+	started time.Time
+	last    atomic.Int64
+	now     func() time.Time
+}
+
+func NewHeartbeat(maxAge time.Duration) *Heartbeat {
+	return &Heartbeat{maxAge: maxAge, started: time.Now(), now: time.Now}
+}
+
+func (h *Heartbeat) Beat() {
+	h.last.Store(h.now().UnixNano())
+}
+
+// Check fails once maxAge has passed without a beat. A worker that has not
+// run yet gets maxAge from the creation of the heartbeat.
+func (h *Heartbeat) Check(context.Context) error {
+	last := h.started
+	if nanos := h.last.Load(); nanos != 0 {
+		last = time.Unix(0, nanos)
+	}
+
+	if age := h.now().Sub(last); age > h.maxAge {
+		return fmt.Errorf("no heartbeat for %s", age.Round(time.Second))
+	}
+	return nil
+}
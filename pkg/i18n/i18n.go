@@ -0,0 +1,46 @@
+// Package i18n picks the language of a response and holds the translations
+// of API messages.
+package i18n
+
+import "github.com/gofiber/fiber/v2"
+
+const (
+	English = "en"
+	Russian = "ru"
+	// Default is used when the client accepts none of the supported
+	// languages, and for messages missing from a catalog.
+	Default = English
+)
+
+// Languages lists the supported languages in order of preference.
+var Languages = []string{English, Russian}
+
+// Language negotiates the response language from the Accept-Language header.
+// Region subtags are ignored, so "ru-RU" selects Russian.
+func Language(c *fiber.Ctx) string {
+	if lang := c.AcceptsLanguages(Languages...); lang != "" {
+		return lang
+	}
+	return Default
+}
+
+// Messages holds the translations of one message by language.
+type Messages map[string]string
+
+// Catalog holds messages by a stable key, such as an error code.
+type Catalog map[string]Messages
+
+// Message returns the message for key in lang, or in the default language
+// when it is not translated.
+func (c Catalog) Message(lang, key string) (string, bool) {
+	messages, ok := c[key]
+	if !ok {
+		return "", false
+	}
+
+	if message, ok := messages[lang]; ok {
+		return message, true
+	}
+	message, ok := messages[Default]
+	return message, ok
+}
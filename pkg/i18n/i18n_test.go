@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                  English,
+		"ru":                Russian,
+		"ru-RU,ru;q=0.9":    Russian,
+		"de-DE,ru;q=0.5":    Russian,
+		"ru;q=0.5,en;q=0.8": English,
+		"de":                English,
+		"*":                 English,
+	}
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendString(Language(c)) })
+
+	for header, want := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(fiber.HeaderAcceptLanguage, header)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		body := make([]byte, 2)
+		_, _ = resp.Body.Read(body)
+		assert.Equal(t, want, string(body), "Accept-Language: %q", header)
+	}
+}
+
+func TestCatalog_Message(t *testing.T) {
+	catalog := Catalog{
+		"greeting": {English: "Hello", Russian: "Привет"},
+		"farewell": {English: "Bye"},
+	}
+
+	message, ok := catalog.Message(Russian, "greeting")
+	assert.True(t, ok)
+	assert.Equal(t, "Привет", message)
+
+	message, ok = catalog.Message(Russian, "farewell")
+	assert.True(t, ok)
+	assert.Equal(t, "Bye", message)
+
+	_, ok = catalog.Message(English, "unknown")
+	assert.False(t, ok)
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelSetting is the level of a logger and when a temporary change of it
+// is reverted.
+type LevelSetting struct {
+	Level LogLevel
+	// Zero when the level stays until it is changed again.
+	RevertAt time.Time
+}
+
+// LevelController holds the level of the root logger and the overrides of
+// named loggers, both of which can be changed while the service runs. An
+// override applies to the logger and its children, e.g. "order" also covers
+// "order.events".
+type LevelController struct {
+	root zap.AtomicLevel
+
+	mu        sync.RWMutex
+	overrides map[string]zapcore.Level
+	// Pending reverts by logger name, "" for the root logger.
+	reverts map[string]*revert
+}
+
+type revert struct {
+	timer *time.Timer
+	at    time.Time
+	// Level to restore; nil removes the override.
+	to *zapcore.Level
+}
+
+func NewLevelController(level Level) *LevelController {
+	return &LevelController{
+		root:      zap.NewAtomicLevelAt(zapcore.Level(level)),
+		overrides: map[string]zapcore.Level{},
+		reverts:   map[string]*revert{},
+	}
+}
+
+// Enabled reports whether the logger with the given name writes entries at
+// level. Unnamed loggers follow the root level.
+func (c *LevelController) Enabled(name string, level zapcore.Level) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for len(c.overrides) > 0 && name != "" {
+		if override, ok := c.overrides[name]; ok {
+			return override.Enabled(level)
+		}
+
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	return c.root.Enabled(level)
+}
+
+// Levels returns the root level and the overrides by logger name.
+func (c *LevelController) Levels() (LevelSetting, map[string]LevelSetting) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	root := c.setting("", c.root.Level())
+	loggers := make(map[string]LevelSetting, len(c.overrides))
+	for name, level := range c.overrides {
+		loggers[name] = c.setting(name, level)
+	}
+
+	return root, loggers
+}
+
+func (c *LevelController) setting(name string, level zapcore.Level) LevelSetting {
+	s := LevelSetting{Level: LogLevel(level.String())}
+	if r, ok := c.reverts[name]; ok {
+		s.RevertAt = r.at
+	}
+	return s
+}
+
+// Set changes the level of the named logger, or of the root logger when name
+// is empty. With revertAfter above zero the level in place before the first
+// pending change is restored once it passes.
+func (c *LevelController) Set(name string, level LogLevel, revertAfter time.Duration) error {
+	l, ok := logLevelsMap[level]
+	if !ok {
+		return fmt.Errorf("unknown log level %q", level)
+	}
+
+	zl := zapcore.Level(l)
+	c.change(name, &zl, revertAfter)
+	return nil
+}
+
+// Reset removes the override of the named logger, so it follows the root
+// level again.
+func (c *LevelController) Reset(name string, revertAfter time.Duration) {
+	if name == "" {
+		return
+	}
+	c.change(name, nil, revertAfter)
+}
+
+func (c *LevelController) change(name string, level *zapcore.Level, revertAfter time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous := c.current(name)
+	if r, ok := c.reverts[name]; ok {
+		r.timer.Stop()
+		previous = r.to
+		delete(c.reverts, name)
+	}
+
+	c.apply(name, level)
+
+	if revertAfter <= 0 {
+		return
+	}
+
+	r := &revert{at: time.Now().Add(revertAfter), to: previous}
+	r.timer = time.AfterFunc(revertAfter, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		// Superseded by a later change.
+		if c.reverts[name] != r {
+			return
+		}
+		delete(c.reverts, name)
+		c.apply(name, r.to)
+	})
+	c.reverts[name] = r
+}
+
+func (c *LevelController) current(name string) *zapcore.Level {
+	if name == "" {
+		level := c.root.Level()
+		return &level
+	}
+	if level, ok := c.overrides[name]; ok {
+		return &level
+	}
+	return nil
+}
+
+func (c *LevelController) apply(name string, level *zapcore.Level) {
+	switch {
+	case name == "":
+		c.root.SetLevel(*level)
+	case level == nil:
+		delete(c.overrides, name)
+	default:
+		c.overrides[name] = *level
+	}
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/BlackRRR/Irtea-test/pkg/observability/logger/zapslog"
+)
+
+func TestLevelController_Overrides(t *testing.T) {
+	levels := NewLevelController(InfoLevel)
+	require.NoError(t, levels.Set("order", LogLevelDebug, 0))
+	require.NoError(t, levels.Set("order.events", LogLevelError, 0))
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	root := slog.New(zapslog.NewHandler(true, core, zapslog.WithLevels(levels)))
+
+	root.Debug("root debug")
+	Named(root, "order").Debug("order debug")
+	Named(Named(root, "order"), "relay").Debug("order.relay debug")
+	Named(Named(root, "order"), "events").Warn("order.events warn")
+	Named(root, "orders").Debug("orders debug")
+	Named(root, "product").Info("product info")
+
+	var messages []string
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.LoggerName+": "+entry.Message)
+	}
+	assert.Equal(t, []string{"order: order debug", "order.relay: order.relay debug", "product: product info"}, messages)
+
+	rootLevel, loggers := levels.Levels()
+	assert.Equal(t, LevelSetting{Level: LogLevelInfo}, rootLevel)
+	assert.Equal(t, map[string]LevelSetting{
+		"order":        {Level: LogLevelDebug},
+		"order.events": {Level: LogLevelError},
+	}, loggers)
+
+	levels.Reset("order", 0)
+	assert.False(t, levels.Enabled("order", zapcore.DebugLevel))
+	assert.Error(t, levels.Set("", "verbose", 0))
+}
+
+func TestLevelController_Revert(t *testing.T) {
+	levels := NewLevelController(WarnLevel)
+
+	require.NoError(t, levels.Set("", LogLevelInfo, time.Hour))
+	require.NoError(t, levels.Set("", LogLevelDebug, 50*time.Millisecond))
+	require.NoError(t, levels.Set("order", LogLevelDebug, 50*time.Millisecond))
+
+	root, loggers := levels.Levels()
+	assert.Equal(t, LogLevelDebug, root.Level)
+	assert.WithinDuration(t, time.Now().Add(50*time.Millisecond), root.RevertAt, time.Second)
+	assert.False(t, loggers["order"].RevertAt.IsZero())
+
+	// Reverts restore the level in place before the first temporary change.
+	assert.Eventually(t, func() bool {
+		root, loggers := levels.Levels()
+		return root == LevelSetting{Level: LogLevelWarn} && len(loggers) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// A permanent change cancels the pending revert.
+	require.NoError(t, levels.Set("", LogLevelDebug, 20*time.Millisecond))
+	require.NoError(t, levels.Set("", LogLevelError, 0))
+	time.Sleep(50 * time.Millisecond)
+
+	root, _ = levels.Levels()
+	assert.Equal(t, LevelSetting{Level: LogLevelError}, root)
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/BlackRRR/Irtea-test/pkg/environment"
 )
 
-func NewZapLogger(level LogLevel, env environment.AppEnv, logFormat LogFormat) (*slog.Logger, error) {
+// NewZapLogger builds the service logger. Its levels can be changed at
+// runtime through the returned controller.
+func NewZapLogger(level LogLevel, env environment.AppEnv, logFormat LogFormat) (*slog.Logger, *LevelController, error) {
 	logLevel, err := GetLogLevelByName(level)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var encoderCfg zapcore.EncoderConfig
@@ -43,8 +45,12 @@ func NewZapLogger(level LogLevel, env environment.AppEnv, logFormat LogFormat) (
 
 	development := env != environment.AppEnvProduction
 
+	levels := NewLevelController(logLevel)
+
 	cfg := zap.Config{
-		Level:            zap.NewAtomicLevelAt(zapcore.Level(logLevel)),
+		// The controller filters by logger name, so the core lets every
+		// level through.
+		Level:            zap.NewAtomicLevelAt(zapcore.DebugLevel),
 		Development:      development,
 		Encoding:         encoding,
 		EncoderConfig:    encoderCfg,
@@ -54,9 +60,18 @@ func NewZapLogger(level LogLevel, env environment.AppEnv, logFormat LogFormat) (
 
 	zapLogger, err := cfg.Build()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	logger := slog.New(zapslog2.NewHandler(development, zapLogger.Core(), zapslog2.WithCaller(true)))
-	return logger, nil
+	logger := slog.New(zapslog2.NewHandler(development, zapLogger.Core(), zapslog2.WithCaller(true), zapslog2.WithLevels(levels)))
+	return logger, levels, nil
+}
+
+// Named returns a logger whose entries carry the name, appended to the
+// logger's own. Level overrides are set by these names.
+func Named(logger *slog.Logger, name string) *slog.Logger {
+	if h, ok := logger.Handler().(*zapslog2.Handler); ok {
+		return slog.New(h.Named(name))
+	}
+	return logger.With(slog.String("logger", name))
 }
@@ -10,12 +10,15 @@ import (
 	"go.uber.org/zap/zapcore"
 	"github.com/BlackRRR/Irtea-test/pkg/observability/logger/zapslog/stacktrace"
 	"github.com/BlackRRR/Irtea-test/pkg/observability/logger/zapslog/security"
+	"github.com/BlackRRR/Irtea-test/pkg/requestctx"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Handler implements the slog.Handler by writing to a zap Core.
 type Handler struct {
 	core       zapcore.Core
 	name       string // logger name
+	levels     LevelEnabler
 	addCaller  bool
 	addStackAt slog.Level
 	callerSkip int
@@ -112,9 +115,31 @@ func convertSlogLevel(l slog.Level) zapcore.Level {
 	}
 }
 
+// LevelEnabler decides by logger name which levels are written, see
+// [WithLevels].
+type LevelEnabler interface {
+	Enabled(name string, level zapcore.Level) bool
+}
+
 // Enabled reports whether the handler handles records at the given level.
 func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
-	return h.core.Enabled(convertSlogLevel(level))
+	zapLevel := convertSlogLevel(level)
+	if h.levels != nil && !h.levels.Enabled(h.name, zapLevel) {
+		return false
+	}
+	return h.core.Enabled(zapLevel)
+}
+
+// Named returns a Handler whose logger name is the receiver's with name
+// appended, separated by a period as in zap.
+func (h *Handler) Named(name string) *Handler {
+	cloned := *h
+	if h.name != "" {
+		cloned.name = h.name + "." + name
+	} else {
+		cloned.name = name
+	}
+	return &cloned
 }
 
 // Handle handles the Record.
@@ -155,7 +180,12 @@ func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 		ce.Stack = stacktrace.Take(3 + h.callerSkip)
 	}
 
-	fields := make([]zapcore.Field, 0, record.NumAttrs()+len(h.groups))
+	fields := make([]zapcore.Field, 0, record.NumAttrs()+len(h.groups)+4)
+
+	// Added before the attributes so they stay outside the record's groups.
+	if ctx != nil {
+		fields = appendContextFields(ctx, fields)
+	}
 
 	var addedNamespace bool
 	record.Attrs(func(attr slog.Attr) bool {
@@ -170,21 +200,6 @@ func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 		return true
 	})
 
-	// Добавляем обработку контекста
-	if ctx != nil {
-		contextFields := []zap.Field{
-			zap.Any(
-				"requestContext",
-				map[string]any{
-					"requestId":     ctx.Value("requestId"),
-					"userId":        ctx.Value("userId"),
-					"correlationId": ctx.Value("correlationId"),
-				},
-			),
-		}
-		fields = append(fields, contextFields...)
-	}
-
 	if !h.development {
 		for i := range fields {
 			fields[i] = h.processField(fields[i])
@@ -215,6 +230,24 @@ func (h *Handler) processField(field zapcore.Field) zapcore.Field {
 	return field
 }
 
+// appendContextFields adds the IDs that tie the record to its request and
+// trace, so one request can be followed across logs and traces.
+func appendContextFields(ctx context.Context, fields []zapcore.Field) []zapcore.Field {
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", span.TraceID().String()),
+			zap.String("span_id", span.SpanID().String()),
+		)
+	}
+	if requestID, ok := requestctx.RequestID(ctx); ok {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	if userID, ok := requestctx.UserID(ctx); ok {
+		fields = append(fields, zap.String("user_id", userID))
+	}
+	return fields
+}
+
 func (h *Handler) appendGroups(fields []zapcore.Field) []zapcore.Field {
 	for _, g := range h.groups {
 		fields = append(fields, zap.Namespace(g))
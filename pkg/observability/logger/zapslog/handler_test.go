@@ -0,0 +1,42 @@
+package zapslog
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/BlackRRR/Irtea-test/pkg/requestctx"
+)
+
+func TestHandler_ContextFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := slog.New(NewHandler(true, core))
+
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), span)
+	ctx = requestctx.WithRequestID(ctx, "req-1")
+	ctx = requestctx.WithUserID(ctx, "user-1")
+
+	logger.WithGroup("order").InfoContext(ctx, "Order placed", slog.Int("items", 2))
+	logger.InfoContext(context.Background(), "Started")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]any{
+		"trace_id":   span.TraceID().String(),
+		"span_id":    span.SpanID().String(),
+		"request_id": "req-1",
+		"user_id":    "user-1",
+		"order":      map[string]any{"items": int64(2)},
+	}, entries[0].ContextMap())
+	assert.Empty(t, entries[1].ContextMap())
+}
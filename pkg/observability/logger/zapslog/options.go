@@ -21,6 +21,15 @@ func WithName(name string) HandlerOption {
 	})
 }
 
+// WithLevels configures the Logger to consult levels before writing, so
+// loggers can be given their own level by name. The Core still has to
+// enable the level.
+func WithLevels(levels LevelEnabler) HandlerOption {
+	return handlerOptionFunc(func(h *Handler) {
+		h.levels = levels
+	})
+}
+
 // WithCaller configures the Logger to include the filename and line number
 // of the caller in log messages--if available.
 func WithCaller(enabled bool) HandlerOption {
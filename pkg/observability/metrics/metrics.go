@@ -0,0 +1,74 @@
+// Package metrics exports OpenTelemetry metrics in the Prometheus format.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.18.0"
+)
+
+type Config struct {
+	// Prices carry no currency, so revenue is reported in this one.
+	Currency string `env:"CURRENCY" envDefault:"USD" validate:"required"`
+}
+
+// CurrencyKey labels monetary metrics.
+const CurrencyKey = attribute.Key("currency")
+
+var currency = CurrencyKey.String("USD")
+
+// Currency returns the label of the configured currency.
+func Currency() attribute.KeyValue {
+	return currency
+}
+
+// Exporter collects the metrics of the global MeterProvider.
+type Exporter struct {
+	provider *sdkmetric.MeterProvider
+	registry *prometheus.Registry
+}
+
+// NewExporter installs a MeterProvider read by Prometheus as the global one.
+// Instruments created earlier through otel.Meter switch over to it.
+func NewExporter(serviceName string, config Config) (*Exporter, error) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	reader, err := otelprom.New(otelprom.WithRegisterer(registry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+	}
+
+	res, err := resource.New(context.Background(), resource.WithAttributes(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics resource: %w", err)
+	}
+
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(res))
+	otel.SetMeterProvider(provider)
+	currency = CurrencyKey.String(config.Currency)
+
+	return &Exporter{provider: provider, registry: registry}, nil
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return e.provider.Shutdown(ctx)
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestExporter(t *testing.T) {
+	// Created before the exporter, like the package-level instruments.
+	counter, err := otel.Meter("test").Float64Counter("orders.revenue")
+	require.NoError(t, err)
+
+	exporter, err := NewExporter("irtea-test", Config{Currency: "EUR"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = exporter.Shutdown(context.Background()) })
+
+	counter.Add(context.Background(), 12.5, metric.WithAttributes(Currency()))
+
+	rec := httptest.NewRecorder()
+	exporter.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `orders_revenue_total{currency="EUR"`)
+	assert.Contains(t, body, "go_goroutines")
+}
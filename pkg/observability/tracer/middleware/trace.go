@@ -11,7 +11,6 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
-	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -54,7 +53,7 @@ func Middleware(opts ...Option) fiber.Handler {
 	)
 
 	if cfg.MeterProvider == nil {
-		cfg.MeterProvider = noop.NewMeterProvider()
+		cfg.MeterProvider = otel.GetMeterProvider()
 	}
 	meter := cfg.MeterProvider.Meter(
 		instrumentationName,
@@ -135,7 +134,11 @@ func Middleware(opts ...Option) fiber.Handler {
 		)
 
 		requestSize := int64(len(c.Request().Body()))
-		responseSize := int64(len(c.Response().Body()))
+		// Reading a streamed body would consume the stream before it is sent.
+		var responseSize int64
+		if !c.Response().IsBodyStream() {
+			responseSize = int64(len(c.Response().Body()))
+		}
 
 		defer func() {
 			responseMetricAttrs = append(
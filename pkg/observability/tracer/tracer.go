@@ -3,12 +3,14 @@ package tracer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/BlackRRR/Irtea-test/pkg/requestctx"
 	"golang.org/x/exp/constraints"
 	"log/slog"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -20,6 +22,7 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.18.0"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -38,6 +41,9 @@ var tr trace.Tracer
 
 var tracerName string
 
+// exporterConn is the connection spans are exported over.
+var exporterConn *grpc.ClientConn
+
 // InitTracer initialize global tracer with service name and otel url.
 func InitTracer(serviceName string, URL string) *sdktrace.TracerProvider {
 	tracerName = serviceName
@@ -80,10 +86,28 @@ func InitTracer(serviceName string, URL string) *sdktrace.TracerProvider {
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
 	tr = otel.Tracer(serviceName)
+	exporterConn = conn
 
 	return tp
 }
 
+// Health reports whether the exporter can reach the collector.
+func Health(context.Context) error {
+	if exporterConn == nil {
+		return errors.New("tracer is not initialized")
+	}
+
+	switch state := exporterConn.GetState(); state {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return fmt.Errorf("collector connection is %s", strings.ToLower(state.String()))
+	case connectivity.Idle:
+		// Idle connections only reconnect on the next export.
+		exporterConn.Connect()
+	}
+
+	return nil
+}
+
 // Start starts new Trace, returns child Context and new Span.
 func Start(ctx context.Context, spanName string, extraData ...ExtraData) (context.Context, trace.Span) {
 	var span trace.Span
@@ -115,8 +139,8 @@ func StartWithFnName(ctx context.Context, extraData ...ExtraData) (context.Conte
 		ctx, span = otel.Tracer("").Start(ctx, spanName)
 	}
 
-	if requestID, ok := ctx.Value(requestid.ConfigDefault.ContextKey.(string)).(string); ok {
-		span.SetAttributes(attribute.String(requestid.ConfigDefault.ContextKey.(string), requestID))
+	if requestID, ok := requestctx.RequestID(ctx); ok {
+		span.SetAttributes(attribute.String("request_id", requestID))
 	}
 
 	if len(extraData) > 0 {
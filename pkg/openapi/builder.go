@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const MediaTypeJSON = "application/json"
+
+// Route declares one operation. Path uses Fiber syntax; ":name" segments
+// become path parameters with the builder's path parameter schema.
+type Route struct {
+	Method      string
+	Path        string
+	ID          string
+	Summary     string
+	Description string
+	Tag         string
+	Params      []Param
+	Body        []Content
+	Responses   []Reply
+}
+
+type Param struct {
+	In          string
+	Name        string
+	Description string
+	Required    bool
+	// Default is sent when the parameter is missing. Its type gives the
+	// parameter's type.
+	Default any
+	Enum    []any
+}
+
+func QueryParam(name string, def any, description string) Param {
+	return Param{In: "query", Name: name, Default: def, Description: description}
+}
+
+func HeaderParam(name, description string) Param {
+	return Param{In: "header", Name: name, Default: "", Description: description}
+}
+
+// Content is a body in one media type. Its schema is derived from the type
+// of Value, or given as Schema when there is no Go type for it.
+type Content struct {
+	MediaType string
+	Value     any
+	Schema    *Schema
+}
+
+func JSON(value any) Content {
+	return Content{MediaType: MediaTypeJSON, Value: value}
+}
+
+type Reply struct {
+	Status      int
+	Description string
+	Headers     map[string]*Header
+	Content     []Content
+}
+
+type Builder struct {
+	info            Info
+	pathParamSchema *Schema
+	routes          []Route
+}
+
+func New(info Info, pathParamSchema *Schema) *Builder {
+	return &Builder{info: info, pathParamSchema: pathParamSchema}
+}
+
+func (b *Builder) Add(routes ...Route) {
+	b.routes = append(b.routes, routes...)
+}
+
+func (b *Builder) Document() *Document {
+	schemas := newSchemas()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    b.info,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	for _, route := range b.routes {
+		path := PathTemplate(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+
+		operation := &Operation{
+			OperationID: route.ID,
+			Summary:     route.Summary,
+			Description: route.Description,
+			Responses:   make(map[string]*Response),
+		}
+		if route.Tag != "" {
+			operation.Tags = []string{route.Tag}
+		}
+
+		for _, name := range pathParams(route.Path) {
+			schema := *b.pathParamSchema
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &schema,
+			})
+		}
+
+		for _, param := range route.Params {
+			schema := schemas.of(reflect.TypeOf(param.Default))
+			if !reflect.ValueOf(param.Default).IsZero() {
+				schema.Default = param.Default
+			}
+			schema.Enum = param.Enum
+
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.Required,
+				Schema:      schema,
+			})
+		}
+
+		if len(route.Body) > 0 {
+			operation.RequestBody = &RequestBody{
+				Required: true,
+				Content:  contentMap(schemas, route.Body),
+			}
+		}
+
+		for _, reply := range route.Responses {
+			response := &Response{
+				Description: reply.Description,
+				Headers:     reply.Headers,
+			}
+			if len(reply.Content) > 0 {
+				response.Content = contentMap(schemas, reply.Content)
+			}
+			operation.Responses[strconv.Itoa(reply.Status)] = response
+		}
+
+		(*item)[strings.ToLower(route.Method)] = operation
+	}
+
+	doc.Components.Schemas = schemas.byName
+	return doc
+}
+
+func contentMap(schemas *schemas, contents []Content) map[string]*MediaType {
+	media := make(map[string]*MediaType, len(contents))
+	for _, content := range contents {
+		schema := content.Schema
+		if content.Value != nil {
+			schema = schemas.of(reflect.TypeOf(content.Value))
+		}
+		media[content.MediaType] = &MediaType{Schema: schema}
+	}
+
+	return media
+}
+
+// PathTemplate converts a Fiber route path to an OpenAPI path template,
+// e.g. "/orders/:id" to "/orders/{id}".
+func PathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,251 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/BlackRRR/Irtea-test/pkg/patch"
+	"github.com/BlackRRR/Irtea-test/pkg/validator"
+)
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	decimalType = reflect.TypeFor[decimal.Decimal]()
+	patchPkg    = reflect.TypeFor[patch.Field[int]]().PkgPath()
+)
+
+// schemas collects the named struct types referenced by a document.
+type schemas struct {
+	byName map[string]*Schema
+	types  map[string]reflect.Type
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		byName: make(map[string]*Schema),
+		types:  make(map[string]reflect.Type),
+	}
+}
+
+// of returns the schema of t. Named structs are added to the components and
+// referenced.
+func (s *schemas) of(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == decimalType:
+		return &Schema{Type: "string", Format: "decimal"}
+	case isPatchField(t):
+		return nullable(s.of(t.Field(2).Type))
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return s.of(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		return s.ref(t)
+	case reflect.Interface:
+		return &Schema{}
+	default:
+		panic(fmt.Sprintf("openapi: unsupported type %s", t))
+	}
+}
+
+func (s *schemas) ref(t reflect.Type) *Schema {
+	name := t.Name()
+	if seen, ok := s.types[name]; ok {
+		if seen != t {
+			panic(fmt.Sprintf("openapi: schema name %s is used by %s and %s", name, seen, t))
+		}
+	} else {
+		s.types[name] = t
+		s.byName[name] = s.object(t)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (s *schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		property := s.of(field.Type)
+		// A nil pointer is encoded as null unless it is omitted.
+		if field.Type.Kind() == reflect.Pointer && !omitEmpty {
+			property = nullable(property)
+		}
+
+		if applyValidate(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, strings.Contains(options, "omitempty")
+}
+
+func isPatchField(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == patchPkg && strings.HasPrefix(t.Name(), "Field[")
+}
+
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{OneOf: []*Schema{schema, {Type: "null"}}}
+	}
+
+	if typ, ok := schema.Type.(string); ok {
+		schema.Type = []string{typ, "null"}
+	}
+
+	return schema
+}
+
+// applyValidate adds the constraints of a validate tag to the schema and
+// reports whether the field is required. Rules after "dive" apply to the
+// items of a slice or the values of a map.
+func applyValidate(schema *Schema, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	required := false
+	target := schema
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			switch {
+			case target.Items != nil:
+				target = target.Items
+			case target.AdditionalProperties != nil:
+				target = target.AdditionalProperties
+			}
+		case "min", "gte":
+			setBound(target, param, true, false)
+		case "max", "lte":
+			setBound(target, param, false, false)
+		case "gt":
+			setBound(target, param, true, true)
+		case "lt":
+			setBound(target, param, false, true)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				target.Enum = append(target.Enum, value)
+			}
+		case "email":
+			target.Format = "email"
+		case "url":
+			target.Format = "uri"
+		case "uuid":
+			target.Format = "uuid"
+		case "price":
+			scale := param
+			if scale == "" {
+				scale = strconv.Itoa(validator.DefaultPriceScale)
+			}
+			target.Pattern = `^\d+(\.\d{1,` + scale + `})?$`
+		case "tags":
+			if target.Items != nil {
+				minLength, maxLength := 1, validator.MaxTagLength
+				target.Items.MinLength = &minLength
+				target.Items.MaxLength = &maxLength
+				target.Items.Pattern = "^[^,]*$"
+			}
+			target.UniqueItems = true
+		}
+	}
+
+	return required
+}
+
+func setBound(schema *Schema, param string, lower, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch typeOf(schema) {
+	case "string":
+		length := int(n)
+		if lower {
+			schema.MinLength = &length
+		} else {
+			schema.MaxLength = &length
+		}
+	case "array":
+		count := int(n)
+		if lower {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	case "integer", "number":
+		switch {
+		case lower && exclusive:
+			schema.ExclusiveMinimum = &n
+		case lower:
+			schema.Minimum = &n
+		case exclusive:
+			schema.ExclusiveMaximum = &n
+		default:
+			schema.Maximum = &n
+		}
+	}
+}
+
+// typeOf returns the non-null type of a schema.
+func typeOf(schema *Schema) string {
+	switch typ := schema.Type.(type) {
+	case string:
+		return typ
+	case []string:
+		return typ[0]
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/BlackRRR/Irtea-test/pkg/patch"
+)
+
+type testItem struct {
+	Name string `json:"name" validate:"required,min=2,max=10"`
+}
+
+type testRequest struct {
+	Email    string                  `json:"email" validate:"required,email"`
+	Age      int                     `json:"age" validate:"gte=18,lt=150"`
+	Status   string                  `json:"status,omitempty" validate:"omitempty,oneof=open closed"`
+	Tags     []string                `json:"tags" validate:"max=3,dive,min=1"`
+	Items    []testItem              `json:"items" validate:"required,min=1,dive"`
+	Price    decimal.Decimal         `json:"price"`
+	Note     *string                 `json:"note"`
+	Optional *int                    `json:"optional,omitempty"`
+	Item     *testItem               `json:"item"`
+	Release  patch.Field[*time.Time] `json:"release"`
+	Ignored  string                  `json:"-"`
+	private  string
+}
+
+func TestSchemas_Object(t *testing.T) {
+	s := newSchemas()
+
+	ref := s.of(reflect.TypeFor[testRequest]())
+	assert.Equal(t, "#/components/schemas/testRequest", ref.Ref)
+
+	schema := s.byName["testRequest"]
+	require.NotNil(t, schema)
+	assert.Equal(t, []string{"email", "items"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Ignored")
+	assert.NotContains(t, schema.Properties, "private")
+
+	p := schema.Properties
+	assert.Equal(t, "email", p["email"].Format)
+	assert.Equal(t, 18.0, *p["age"].Minimum)
+	assert.Equal(t, 150.0, *p["age"].ExclusiveMaximum)
+	assert.Equal(t, []any{"open", "closed"}, p["status"].Enum)
+	assert.Equal(t, 3, *p["tags"].MaxItems)
+	assert.Equal(t, 1, *p["tags"].Items.MinLength)
+	assert.Equal(t, 1, *p["items"].MinItems)
+	assert.Equal(t, "#/components/schemas/testItem", p["items"].Items.Ref)
+	assert.Equal(t, &Schema{Type: "string", Format: "decimal"}, p["price"])
+	assert.Equal(t, []string{"string", "null"}, p["note"].Type)
+	assert.Equal(t, "integer", p["optional"].Type)
+	assert.Equal(t, &Schema{OneOf: []*Schema{{Ref: "#/components/schemas/testItem"}, {Type: "null"}}}, p["item"])
+	assert.Equal(t, &Schema{Type: []string{"string", "null"}, Format: "date-time"}, p["release"])
+
+	item := s.byName["testItem"]
+	require.NotNil(t, item)
+	assert.Equal(t, []string{"name"}, item.Required)
+	assert.Equal(t, 2, *item.Properties["name"].MinLength)
+	assert.Equal(t, 10, *item.Properties["name"].MaxLength)
+}
+
+func TestSchemas_NameCollision(t *testing.T) {
+	type testItem struct{}
+
+	s := newSchemas()
+	s.of(reflect.TypeFor[testRequest]())
+
+	assert.Panics(t, func() { s.of(reflect.TypeFor[testItem]()) })
+}
+
+func TestBuilder_Document(t *testing.T) {
+	b := New(Info{Title: "test", Version: "1"}, &Schema{Type: "string", Format: "uuid"})
+	b.Add(Route{
+		Method: "PUT", Path: "/items/:id/parts/:partId", ID: "putPart",
+		Params:    []Param{QueryParam("limit", 10, "")},
+		Body:      []Content{JSON(testItem{})},
+		Responses: []Reply{{Status: 200, Description: "OK", Content: []Content{JSON(testItem{})}}},
+	})
+
+	doc := b.Document()
+	operation := (*doc.Paths["/items/{id}/parts/{partId}"])["put"]
+	require.NotNil(t, operation)
+
+	require.Len(t, operation.Parameters, 3)
+	assert.Equal(t, "id", operation.Parameters[0].Name)
+	assert.Equal(t, "path", operation.Parameters[0].In)
+	assert.True(t, operation.Parameters[1].Required)
+	assert.Equal(t, &Schema{Type: "integer", Default: 10}, operation.Parameters[2].Schema)
+	assert.Equal(t, "#/components/schemas/testItem", operation.RequestBody.Content[MediaTypeJSON].Schema.Ref)
+	assert.Contains(t, operation.Responses, "200")
+	assert.Contains(t, doc.Components.Schemas, "testItem")
+}
@@ -0,0 +1,37 @@
+package patch
+
+import "encoding/json"
+
+// Field is a JSON Merge Patch (RFC 7396) member: an absent member leaves the
+// target untouched, null removes it and any other value replaces it.
+type Field[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func Value[T any](value T) Field[T] {
+	return Field[T]{Set: true, Value: value}
+}
+
+func Null[T any]() Field[T] {
+	return Field[T]{Set: true, Null: true}
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if string(data) == "null" {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// Ptr returns the new value, or nil when the member is null.
+func (f Field[T]) Ptr() *T {
+	if f.Null {
+		return nil
+	}
+	value := f.Value
+	return &value
+}
@@ -0,0 +1,36 @@
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type document struct {
+	Description Field[string]   `json:"description"`
+	Tags        Field[[]string] `json:"tags"`
+	Price       Field[int]      `json:"price"`
+}
+
+func TestField_UnmarshalJSON(t *testing.T) {
+	var doc document
+	err := json.Unmarshal([]byte(`{"description": "shirt", "tags": null}`), &doc)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Value("shirt"), doc.Description)
+	assert.Equal(t, Null[[]string](), doc.Tags)
+	assert.False(t, doc.Price.Set)
+}
+
+func TestField_UnmarshalJSON_TypeMismatch(t *testing.T) {
+	var doc document
+	err := json.Unmarshal([]byte(`{"price": "ten"}`), &doc)
+
+	assert.Error(t, err)
+}
+
+func TestField_Ptr(t *testing.T) {
+	assert.Nil(t, Null[int]().Ptr())
+	assert.Equal(t, 5, *Value(5).Ptr())
+}
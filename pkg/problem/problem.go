@@ -0,0 +1,66 @@
+// Package problem implements RFC 7807 problem details for HTTP APIs.
+package problem
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const ContentType = "application/problem+json"
+
+// TypeBlank is the type of problems that need no more explanation than
+// their status code.
+const TypeBlank = "about:blank"
+
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// Code is the stable code of the error, see package errcode.
+	Code     string `json:"code,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// RequestID matches the X-Request-ID response header.
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError explains why one value in the request body was rejected.
+type FieldError struct {
+	// Pointer locates the value in the body (RFC 6901).
+	Pointer string `json:"pointer"`
+	// Rule names the check that failed, with its parameter if any.
+	Rule   string `json:"rule,omitempty"`
+	Param  string `json:"param,omitempty"`
+	Detail string `json:"detail"`
+}
+
+// New returns an untyped problem titled after its status.
+func New(status int, detail string) *Problem {
+	return &Problem{
+		Type:   TypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+	return p.Title
+}
+
+// Write sends the problem as the response, filling in the instance and the
+// request ID.
+func Write(c *fiber.Ctx, p *Problem) error {
+	response := *p
+	if response.Instance == "" {
+		response.Instance = c.OriginalURL()
+	}
+	response.RequestID = c.GetRespHeader(fiber.HeaderXRequestID)
+
+	return c.Status(response.Status).JSON(response, ContentType)
+}
@@ -0,0 +1,45 @@
+package problem
+
+import (
+	"errors"
+
+	"github.com/BlackRRR/Irtea-test/pkg/errcode"
+)
+
+// Mapping describes the problem reported for a sentinel error. The error's
+// code is appended to the registry's base URI to form the problem type.
+type Mapping struct {
+	Err    *errcode.Error
+	Status int
+}
+
+// Registry maps errors to problem types. Mappings are matched with
+// errors.Is in registration order.
+type Registry struct {
+	base     string
+	mappings []Mapping
+}
+
+func NewRegistry(base string, mappings ...Mapping) *Registry {
+	return &Registry{base: base, mappings: mappings}
+}
+
+// Lookup returns the untitled problem for err. err's message is left out:
+// wrappers add server-side context, and it is not translated.
+func (r *Registry) Lookup(err error) (*Problem, bool) {
+	for _, mapping := range r.mappings {
+		if errors.Is(err, mapping.Err) {
+			return &Problem{
+				Type:   r.base + mapping.Err.Code(),
+				Status: mapping.Status,
+				Code:   mapping.Err.Code(),
+			}, true
+		}
+	}
+
+	return nil, false
+}
+
+func (r *Registry) Mappings() []Mapping {
+	return r.mappings
+}
@@ -0,0 +1,42 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/BlackRRR/Irtea-test/pkg/errcode"
+)
+
+var (
+	errFirst  = errcode.New("first", "first")
+	errSecond = errcode.New("second", "second")
+)
+
+func TestRegistry_Lookup(t *testing.T) {
+	registry := NewRegistry("urn:test:",
+		Mapping{Err: errFirst, Status: 404},
+		Mapping{Err: errSecond, Status: 409},
+	)
+
+	p, ok := registry.Lookup(fmt.Errorf("wrapped: %w", errSecond))
+	assert.True(t, ok)
+	// The wrapper's message is not sent to clients.
+	assert.Equal(t, &Problem{Type: "urn:test:second", Status: 409, Code: "second"}, p)
+
+	// The first registered mapping wins for errors that match several.
+	p, ok = registry.Lookup(errors.Join(errSecond, errFirst))
+	assert.True(t, ok)
+	assert.Equal(t, 404, p.Status)
+
+	_, ok = registry.Lookup(errors.New("other"))
+	assert.False(t, ok)
+}
+
+func TestNew(t *testing.T) {
+	p := New(400, "bad id")
+
+	assert.Equal(t, &Problem{Type: TypeBlank, Title: "Bad Request", Status: 400, Detail: "bad id"}, p)
+	assert.Equal(t, "Bad Request: bad id", p.Error())
+}
@@ -0,0 +1,111 @@
+// Package ratelimit implements token-bucket rate limits.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy lets Limit requests through per Period, in bursts of up to Limit.
+// The zero Policy is disabled.
+type Policy struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParsePolicy parses "<limit>/<period>", e.g. "5/1m". "off" and "" give the
+// disabled policy.
+func ParsePolicy(s string) (Policy, error) {
+	if s == "" || s == "off" {
+		return Policy{}, nil
+	}
+
+	limit, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("rate limit policy %q is not <limit>/<period>", s)
+	}
+
+	var p Policy
+	var err error
+	if p.Limit, err = strconv.Atoi(limit); err != nil || p.Limit <= 0 {
+		return Policy{}, fmt.Errorf("rate limit policy %q: limit must be a positive integer", s)
+	}
+	if p.Period, err = time.ParseDuration(period); err != nil || p.Period <= 0 {
+		return Policy{}, fmt.Errorf("rate limit policy %q: period must be a positive duration", s)
+	}
+
+	return p, nil
+}
+
+func (p *Policy) UnmarshalText(text []byte) error {
+	policy, err := ParsePolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
+
+func (p Policy) Enabled() bool {
+	return p.Limit > 0
+}
+
+// String renders the policy as in the RateLimit-Policy header, e.g. "5;w=60".
+func (p Policy) String() string {
+	return strconv.Itoa(p.Limit) + ";w=" + strconv.Itoa(int(math.Ceil(p.Period.Seconds())))
+}
+
+// Bucket is the state of one key. The zero Bucket is full.
+type Bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, zero when allowed.
+	RetryAfter time.Duration
+}
+
+// Take refills b up to now and takes a token from it if there is one.
+func (p Policy) Take(b Bucket, now time.Time) (Bucket, Result) {
+	limit := float64(p.Limit)
+	perSecond := limit / p.Period.Seconds()
+
+	tokens := limit
+	if !b.Updated.IsZero() {
+		tokens = math.Min(limit, b.Tokens+now.Sub(b.Updated).Seconds()*perSecond)
+	}
+
+	result := Result{Limit: p.Limit}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / perSecond)
+	}
+
+	result.Remaining = int(tokens)
+	result.Reset = seconds((limit - tokens) / perSecond)
+
+	return Bucket{Tokens: tokens, Updated: now}, result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// Store keeps buckets by key. Implementations must take tokens atomically,
+// as several requests may share a key.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy("5/1m")
+	require.NoError(t, err)
+	assert.Equal(t, Policy{Limit: 5, Period: time.Minute}, p)
+	assert.Equal(t, "5;w=60", p.String())
+
+	p, err = ParsePolicy("off")
+	require.NoError(t, err)
+	assert.False(t, p.Enabled())
+
+	for _, invalid := range []string{"5", "0/1m", "x/1m", "5/0s", "5/soon"} {
+		_, err := ParsePolicy(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPolicy_Take(t *testing.T) {
+	policy := Policy{Limit: 2, Period: 10 * time.Second}
+	now := time.Date(2025, 9, 27, 12, 0, 0, 0, time.UTC)
+
+	bucket, result := policy.Take(Bucket{}, now)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 5 * time.Second}, result)
+
+	bucket, result = policy.Take(bucket, now)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 10 * time.Second}, result)
+
+	bucket, result = policy.Take(bucket, now.Add(time.Second))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 4*time.Second, result.RetryAfter)
+	assert.Equal(t, 9*time.Second, result.Reset)
+
+	// One token has refilled after five seconds.
+	_, result = policy.Take(bucket, now.Add(5*time.Second))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Date(2025, 9, 27, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	policy := Policy{Limit: 1, Period: time.Minute}
+	ctx := context.Background()
+
+	result, err := store.Take(ctx, "a", policy)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, _ = store.Take(ctx, "a", policy)
+	assert.False(t, result.Allowed)
+
+	// Keys are counted separately.
+	result, _ = store.Take(ctx, "b", policy)
+	assert.True(t, result.Allowed)
+
+	// Refilled buckets are forgotten.
+	now = now.Add(2 * time.Minute)
+	result, _ = store.Take(ctx, "a", policy)
+	assert.True(t, result.Allowed)
+	assert.Len(t, store.buckets, 1)
+}
@@ -0,0 +1,34 @@
+// Package requestctx carries the request and user IDs that identify a request
+// in logs and traces. The API does not authenticate callers yet, so only the
+// request ID is set in production.
+package requestctx
+
+import "context"
+
+type key int
+
+// The keys double as fiber locals: fiber stores locals as values of the
+// request context, so a local set under one of these keys is visible through
+// every context derived from the request.
+const (
+	RequestIDKey key = iota
+	UserIDKey
+)
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(RequestIDKey).(string)
+	return id, ok && id != ""
+}
+
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, UserIDKey, userID)
+}
+
+func UserID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(UserIDKey).(string)
+	return id, ok && id != ""
+}
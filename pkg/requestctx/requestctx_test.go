@@ -0,0 +1,41 @@
+package requestctx
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	_, ok := RequestID(context.Background())
+	assert.False(t, ok)
+
+	id, ok := RequestID(WithRequestID(context.Background(), "req-1"))
+	assert.True(t, ok)
+	assert.Equal(t, "req-1", id)
+}
+
+func TestFiberLocals(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.Locals(UserIDKey, "user-1")
+
+		// Derived the way tracing middleware derives the user context.
+		ctx, cancel := context.WithCancel(c.Context())
+		defer cancel()
+
+		id, _ := UserID(ctx)
+		return c.SendString(id)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", string(body))
+}
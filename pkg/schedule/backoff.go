@@ -0,0 +1,13 @@
+package schedule
+
+import "time"
+
+// Backoff returns the delay before the given retry attempt, starting at base
+// for the first attempt and doubling with every further one, up to max.
+func Backoff(base, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	return min(delay, max)
+}
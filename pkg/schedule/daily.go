@@ -0,0 +1,64 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeOfDay is a wall-clock time such as 08:30.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+// ParseTimeOfDay parses "HH:MM" in 24-hour format.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+// Next returns the first moment at this time of day strictly after now, in
+// now's location.
+func (t TimeOfDay) Next(now time.Time) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), t.Hour, t.Minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Daily runs fn once a day at the given time until ctx is cancelled. Runs do
+// not overlap: a slow run delays the next one.
+func Daily(ctx context.Context, at TimeOfDay, fn func(ctx context.Context)) {
+	for {
+		timer := time.NewTimer(time.Until(at.Next(time.Now())))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			fn(ctx)
+		}
+	}
+}
+
+// Every runs fn at the given interval until ctx is cancelled. Like Daily,
+// runs do not overlap.
+func Every(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
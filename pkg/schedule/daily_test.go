@@ -0,0 +1,31 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	at, err := ParseTimeOfDay("08:30")
+	assert.NoError(t, err)
+	assert.Equal(t, TimeOfDay{Hour: 8, Minute: 30}, at)
+
+	_, err = ParseTimeOfDay("8am")
+	assert.Error(t, err)
+}
+
+func TestTimeOfDay_Next(t *testing.T) {
+	at := TimeOfDay{Hour: 8, Minute: 0}
+	loc := time.FixedZone("UTC+3", 3*60*60)
+
+	before := time.Date(2025, 9, 19, 7, 59, 0, 0, loc)
+	assert.Equal(t, time.Date(2025, 9, 19, 8, 0, 0, 0, loc), at.Next(before))
+
+	exactly := time.Date(2025, 9, 19, 8, 0, 0, 0, loc)
+	assert.Equal(t, time.Date(2025, 9, 20, 8, 0, 0, 0, loc), at.Next(exactly))
+
+	after := time.Date(2025, 12, 31, 22, 0, 0, 0, loc)
+	assert.Equal(t, time.Date(2026, 1, 1, 8, 0, 0, 0, loc), at.Next(after))
+}
@@ -0,0 +1,16 @@
+package schedule
+
+import "context"
+
+// Drain runs batch until it fetches fewer than size items, fails or ctx is
+// done, and returns the sum of the items the batches handled.
+func Drain(ctx context.Context, size int, batch func(ctx context.Context) (fetched, handled int, err error)) (int, error) {
+	var total int
+	for {
+		fetched, handled, err := batch(ctx)
+		total += handled
+		if err != nil || fetched < size || ctx.Err() != nil {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrain(t *testing.T) {
+	pages := []int{3, 3, 1}
+	var calls int
+
+	total, err := Drain(context.Background(), 3, func(context.Context) (int, int, error) {
+		fetched := pages[calls]
+		calls++
+		return fetched, fetched - 1, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 4, total)
+}
+
+func TestDrain_StopsOnError(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	total, err := Drain(context.Background(), 3, func(context.Context) (int, int, error) {
+		return 3, 2, errDown
+	})
+
+	assert.ErrorIs(t, err, errDown)
+	assert.Equal(t, 2, total)
+}
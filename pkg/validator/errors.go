@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/BlackRRR/Irtea-test/pkg/i18n"
+)
+
+// RequestError is a request body that could not be decoded or failed
+// validation.
+type RequestError struct {
+	// Malformed is set when the body could not be decoded, as opposed to
+	// being decoded and breaking a rule.
+	Malformed bool
+	Detail    string
+	Fields    []FieldError
+}
+
+// FieldError is one rejected value.
+type FieldError struct {
+	// Pointer locates the value in the body (RFC 6901), e.g. "/items/0/quantity".
+	Pointer string
+	// Rule is the validate tag that failed and Param its parameter.
+	Rule  string
+	Param string
+
+	field string
+	err   validator.FieldError
+}
+
+// Message describes the error in lang, naming the field as in the body.
+func (f FieldError) Message(lang string) string {
+	trans := translator(lang)
+	if f.err != nil {
+		return f.err.Translate(trans)
+	}
+
+	message, err := trans.T(f.Rule, f.field, f.Param)
+	if err != nil {
+		return f.field + " " + f.Rule
+	}
+	return message
+}
+
+func (e *RequestError) Error() string {
+	if len(e.Fields) == 0 {
+		return "invalid request body: " + e.Detail
+	}
+
+	parts := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		parts = append(parts, field.Pointer+": "+field.Message(i18n.Default))
+	}
+	return "invalid request body: " + strings.Join(parts, "; ")
+}
+
+func newRequestError(errs validator.ValidationErrors) *RequestError {
+	requestErr := &RequestError{Fields: make([]FieldError, 0, len(errs))}
+	for _, err := range errs {
+		requestErr.Fields = append(requestErr.Fields, FieldError{
+			Pointer: pointer(err.Namespace()),
+			Rule:    err.Tag(),
+			Param:   err.Param(),
+			field:   err.Field(),
+			err:     err,
+		})
+	}
+
+	return requestErr
+}
+
+// pointer converts a namespace such as "Request.items[0].quantity" to a JSON
+// pointer. The first segment is the name of the validated struct.
+func pointer(namespace string) string {
+	_, path, _ := strings.Cut(namespace, ".")
+
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+
+	var b strings.Builder
+	for path != "" {
+		var segment string
+		switch {
+		case path[0] == '[':
+			end := strings.IndexByte(path, ']')
+			segment, path = path[1:end], path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			segment, path = path[:end], path[end:]
+		}
+		path = strings.TrimPrefix(path, ".")
+
+		b.WriteByte('/')
+		b.WriteString(escape.Replace(segment))
+	}
+
+	return b.String()
+}
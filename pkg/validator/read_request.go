@@ -3,8 +3,9 @@ package validator
 import (
 	"github.com/gofiber/fiber/v2"
 	"encoding/json"
-	"fmt"
 	"errors"
+	"reflect"
+	"strings"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -12,17 +13,59 @@ var validate *validator.Validate
 
 func Init() {
 	validate = validator.New()
+
+	// Report fields by their JSON names so errors point into the body.
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	registerRules(validate)
+	registerTranslations(validate)
 }
 
+// ReadRequest decodes the body into request and validates it. Decoding and
+// validation failures are returned as *RequestError.
 func ReadRequest(c *fiber.Ctx, request interface{}) error {
 	if err := c.BodyParser(request); err != nil {
 		var jute *json.UnmarshalTypeError
 		if errors.As(err, &jute) {
-			return fmt.Errorf("field %s must be of type %s", jute.Field, jute.Type.String())
+			field := jute.Field[strings.LastIndexByte(jute.Field, '.')+1:]
+			return &RequestError{
+				Malformed: true,
+				Fields: []FieldError{{
+					Pointer: "/" + strings.ReplaceAll(jute.Field, ".", "/"),
+					Rule:    "type",
+					Param:   jute.Type.String(),
+					field:   field,
+				}},
+			}
+		}
+
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return &RequestError{Malformed: true, Detail: "request body is not valid JSON"}
 		}
 
-		return err
+		// Unsupported content types.
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			return err
+		}
+
+		// The rest come from decoders, e.g. a price that is not a number.
+		return &RequestError{Malformed: true, Detail: err.Error()}
+	}
+
+	err := validate.StructCtx(c.UserContext(), request)
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return newRequestError(validationErrs)
 	}
 
-	return validate.StructCtx(c.UserContext(), request)
+	return err
 }
@@ -0,0 +1,148 @@
+package validator
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/BlackRRR/Irtea-test/pkg/patch"
+)
+
+type testItem struct {
+	ID       string `json:"id" validate:"required,uuid"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+type testRequest struct {
+	Name  string                       `json:"name" validate:"required,max=5"`
+	Price decimal.Decimal              `json:"price" validate:"price"`
+	Tags  []string                     `json:"tags" validate:"omitempty,tags"`
+	Items []testItem                   `json:"items" validate:"required,min=1,dive"`
+	Patch patch.Field[decimal.Decimal] `json:"patch" validate:"omitempty,price=0"`
+	Attrs map[string]string            `json:"attrs" validate:"omitempty,dive,max=3"`
+	Every string                       `json:"every" validate:"omitempty,duration"`
+}
+
+func readRequest(t *testing.T, body string) error {
+	t.Helper()
+
+	Init()
+
+	var readErr error
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		var req testRequest
+		readErr = ReadRequest(c, &req)
+		return nil
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	_, err := app.Test(req)
+	require.NoError(t, err)
+
+	return readErr
+}
+
+// fieldMessage flattens a FieldError for comparison.
+type fieldMessage struct {
+	Pointer, Rule, Param, Message string
+}
+
+func fieldMessages(fields []FieldError, lang string) []fieldMessage {
+	messages := make([]fieldMessage, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, fieldMessage{field.Pointer, field.Rule, field.Param, field.Message(lang)})
+	}
+	return messages
+}
+
+func requestError(t *testing.T, err error) *RequestError {
+	t.Helper()
+
+	var requestErr *RequestError
+	require.True(t, errors.As(err, &requestErr), "got %v", err)
+	return requestErr
+}
+
+func TestReadRequest_Valid(t *testing.T) {
+	err := readRequest(t, `{
+		"name": "shoe",
+		"price": "10.50",
+		"tags": ["red", "sale"],
+		"items": [{"id": "3F2504E0-4F89-11D3-9A0C-0305E82C3301", "quantity": 1}],
+		"patch": null,
+		"every": "15m"
+	}`)
+
+	assert.NoError(t, err)
+}
+
+func TestReadRequest_FieldErrors(t *testing.T) {
+	err := readRequest(t, `{
+		"name": "sneaker",
+		"price": "-1.999",
+		"tags": ["red", "Red", "a,b"],
+		"items": [{"id": "3f2504e0", "quantity": 0}],
+		"patch": "1.5",
+		"attrs": {"a/b": "long"},
+		"every": "-1m"
+	}`)
+
+	requestErr := requestError(t, err)
+	assert.False(t, requestErr.Malformed)
+	assert.Equal(t, []fieldMessage{
+		{"/name", "max", "5", "name must be a maximum of 5 characters in length"},
+		{"/price", "price", "", "price must be a non-negative amount with at most 2 decimal places"},
+		{"/tags", "tags", "", "tags must be unique, non-blank tags of at most 64 characters without commas"},
+		{"/items/0/id", "uuid", "", "id must be a valid UUID"},
+		{"/items/0/quantity", "min", "1", "quantity must be 1 or greater"},
+		{"/patch", "price", "0", "patch must be a non-negative amount with at most 0 decimal places"},
+		{"/attrs/a~1b", "max", "3", "attrs[a/b] must be a maximum of 3 characters in length"},
+		{"/every", "duration", "", "every must be a positive duration such as 15m"},
+	}, fieldMessages(requestErr.Fields, "en"))
+}
+
+func TestReadRequest_Russian(t *testing.T) {
+	requestErr := requestError(t, readRequest(t, `{"name": "sneaker", "price": "1.999"}`))
+
+	assert.Equal(t, []fieldMessage{
+		{"/name", "max", "5", "name должен содержать максимум 5 символов"},
+		{"/price", "price", "", "price должен быть неотрицательной суммой, не более 2 знаков после запятой"},
+		{"/items", "required", "", "items обязательное поле"},
+	}, fieldMessages(requestErr.Fields, "ru"))
+
+	// Unsupported languages fall back to English.
+	assert.Equal(t, "items is a required field", requestErr.Fields[2].Message("de"))
+}
+
+func TestReadRequest_Required(t *testing.T) {
+	requestErr := requestError(t, readRequest(t, `{"price": "1"}`))
+
+	assert.Equal(t, []fieldMessage{
+		{"/name", "required", "", "name is a required field"},
+		{"/items", "required", "", "items is a required field"},
+	}, fieldMessages(requestErr.Fields, "en"))
+}
+
+func TestReadRequest_Malformed(t *testing.T) {
+	requestErr := requestError(t, readRequest(t, `{"name": 5}`))
+	assert.True(t, requestErr.Malformed)
+	assert.Equal(t, []fieldMessage{
+		{"/name", "type", "string", "name must be of type string"},
+	}, fieldMessages(requestErr.Fields, "en"))
+	assert.Equal(t, "name должен иметь тип string", requestErr.Fields[0].Message("ru"))
+
+	requestErr = requestError(t, readRequest(t, `{"name": `))
+	assert.True(t, requestErr.Malformed)
+	assert.Empty(t, requestErr.Fields)
+
+	requestErr = requestError(t, readRequest(t, `{"price": "abc"}`))
+	assert.True(t, requestErr.Malformed)
+	assert.NotEmpty(t, requestErr.Detail)
+}
@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/BlackRRR/Irtea-test/pkg/patch"
+)
+
+const (
+	// DefaultPriceScale is the number of decimal places the price rule
+	// allows without a parameter.
+	DefaultPriceScale = 2
+	// MaxTagLength is the length limit of each tag in the tags rule.
+	MaxTagLength = 64
+)
+
+func registerRules(v *validator.Validate) {
+	// Rules see the value of a merge patch member; absent and null members
+	// count as empty, so omitempty skips them.
+	v.RegisterCustomTypeFunc(patchValue[string], patch.Field[string]{})
+	v.RegisterCustomTypeFunc(patchValue[[]string], patch.Field[[]string]{})
+	v.RegisterCustomTypeFunc(patchValue[int], patch.Field[int]{})
+	v.RegisterCustomTypeFunc(patchValue[decimal.Decimal], patch.Field[decimal.Decimal]{})
+	v.RegisterCustomTypeFunc(patchValue[time.Time], patch.Field[time.Time]{})
+
+	_ = v.RegisterValidation("price", validatePrice)
+	_ = v.RegisterValidation("uuid", validateUUID)
+	_ = v.RegisterValidation("tags", validateTags)
+	_ = v.RegisterValidation("duration", validateDuration)
+}
+
+func patchValue[T any](field reflect.Value) any {
+	f := field.Interface().(patch.Field[T])
+	if !f.Set || f.Null {
+		return nil
+	}
+	return f.Value
+}
+
+// validatePrice accepts non-negative decimals with at most param decimal
+// places, two by default.
+func validatePrice(fl validator.FieldLevel) bool {
+	price, ok := fl.Field().Interface().(decimal.Decimal)
+	if !ok {
+		return false
+	}
+
+	scale := DefaultPriceScale
+	if fl.Param() != "" {
+		var err error
+		if scale, err = strconv.Atoi(fl.Param()); err != nil {
+			return false
+		}
+	}
+
+	return !price.IsNegative() && price.Equal(price.Truncate(int32(scale)))
+}
+
+// validateUUID accepts the IDs that the handlers parse: the canonical form
+// in either case. The built-in rule only accepts lower case.
+func validateUUID(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if len(value) != 36 {
+		return false
+	}
+
+	_, err := uuid.Parse(value)
+	return err == nil
+}
+
+// validateTags checks a tag list. Tags are stored comma-separated, so they
+// cannot contain commas.
+func validateTags(fl validator.FieldLevel) bool {
+	tags, ok := fl.Field().Interface().([]string)
+	if !ok {
+		return false
+	}
+
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || len([]rune(tag)) > MaxTagLength || strings.Contains(tag, ",") {
+			return false
+		}
+
+		key := strings.ToLower(tag)
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+	}
+
+	return true
+}
+
+// validateDuration accepts positive Go durations such as "15m".
+func validateDuration(fl validator.FieldLevel) bool {
+	d, err := time.ParseDuration(fl.Field().String())
+	return err == nil && d > 0
+}
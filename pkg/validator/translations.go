@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"strconv"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	ruTranslations "github.com/go-playground/validator/v10/translations/ru"
+	"github.com/BlackRRR/Irtea-test/pkg/i18n"
+)
+
+// ruleMessages translates the custom rules and the "type" rule of bodies
+// that could not be decoded. {0} is the field name and {1} the parameter.
+var ruleMessages = map[string]i18n.Messages{
+	"price": {
+		i18n.English: "{0} must be a non-negative amount with at most {1} decimal places",
+		i18n.Russian: "{0} должен быть неотрицательной суммой, не более {1} знаков после запятой",
+	},
+	"tags": {
+		i18n.English: "{0} must be unique, non-blank tags of at most {1} characters without commas",
+		i18n.Russian: "{0} должен содержать уникальные непустые теги без запятых, не длиннее {1} символов",
+	},
+	"duration": {
+		i18n.English: "{0} must be a positive duration such as 15m",
+		i18n.Russian: "{0} должен быть положительной длительностью, например 15m",
+	},
+	"type": {
+		i18n.English: "{0} must be of type {1}",
+		i18n.Russian: "{0} должен иметь тип {1}",
+	},
+}
+
+var universal *ut.UniversalTranslator
+
+// translator returns the translator for lang, falling back to English.
+func translator(lang string) ut.Translator {
+	trans, _ := universal.GetTranslator(lang)
+	return trans
+}
+
+func registerTranslations(v *validator.Validate) {
+	universal = ut.New(en.New(), en.New(), ru.New())
+
+	_ = enTranslations.RegisterDefaultTranslations(v, translator(i18n.English))
+	_ = ruTranslations.RegisterDefaultTranslations(v, translator(i18n.Russian))
+
+	for _, lang := range i18n.Languages {
+		trans := translator(lang)
+		for rule, messages := range ruleMessages {
+			_ = trans.Add(rule, messages[lang], true)
+		}
+
+		_ = v.RegisterTranslation("price", trans, noRegistration, func(trans ut.Translator, fe validator.FieldError) string {
+			scale := fe.Param()
+			if scale == "" {
+				scale = strconv.Itoa(DefaultPriceScale)
+			}
+			return translate(trans, fe, scale)
+		})
+		_ = v.RegisterTranslation("tags", trans, noRegistration, func(trans ut.Translator, fe validator.FieldError) string {
+			return translate(trans, fe, strconv.Itoa(MaxTagLength))
+		})
+		_ = v.RegisterTranslation("duration", trans, noRegistration, func(trans ut.Translator, fe validator.FieldError) string {
+			return translate(trans, fe, "")
+		})
+	}
+}
+
+// noRegistration is used for rules whose messages are added from
+// ruleMessages.
+func noRegistration(ut.Translator) error {
+	return nil
+}
+
+func translate(trans ut.Translator, fe validator.FieldError, param string) string {
+	message, err := trans.T(fe.Tag(), fe.Field(), param)
+	if err != nil {
+		return fe.Error()
+	}
+	return message
+}